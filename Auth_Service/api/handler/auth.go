@@ -4,10 +4,13 @@ import (
 	"Auth/api/tokens"
 	pb "Auth/genproto/users"
 	"Auth/models"
+	"Auth/service"
+	"Auth/storage"
 	"context"
-	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,9 +18,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyPasswordHash is compared against when the email is unknown, so that a login
+// takes as long whether or not the account exists. It hashes a random password at
+// bcrypt.DefaultCost, the cost account passwords are hashed with.
+const dummyPasswordHash = "$2a$10$1D6yciYCRI5.kSPGX6WP2eJV3ixCntlGJRHEhvOWZbLFDW3X6EPsq"
+
 // Register godoc
 // @Summary Registers user
-// @Description Registers a new user
+// @Description Registers a new user and emails a verification code; the user cannot log in before verifying
 // @Tags auth
 // @Param user body models.RegisterRequest true "User data"
 // @Success 200 {object} users.UserResponse
@@ -29,14 +37,14 @@ func (h *Handler) Register(c *gin.Context) {
 
 	var req models.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.Log.Error("Invalid data provided", err)
+		h.Log.Error("Invalid data provided", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data"})
 		return
 	}
 
 	passByte, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		h.Log.Error("Error hashing password", err)
+		h.Log.Error("Error hashing password", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
 		return
 	}
@@ -51,24 +59,35 @@ func (h *Handler) Register(c *gin.Context) {
 		FirstName:   req.FirstName,
 		LastName:    req.LastName,
 		PhoneNumber: req.PhoneNumber,
+		Role:        "customer",
 	})
 	if err != nil {
-		h.Log.Error("Error registering user", err)
+		h.Log.Error("Error registering user", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
 		return
 	}
 
+	if err := h.Verification.SendCode(ctx, resp.Id, resp.Email); err != nil {
+		// The account exists already; the user can ask for another code.
+		h.Log.Error("Error sending verification code", "error", err)
+	}
+
 	h.Log.Info("Register has successfully finished")
-	c.JSON(http.StatusOK, gin.H{"New user": resp})
+	c.JSON(http.StatusOK, gin.H{"New user": resp, "message": "verification code sent to " + resp.Email})
 }
 
 // Login godoc
 // @Summary Logs user in
-// @Description Logs user in
+// @Description Logs user in. Repeated failures slow down and then lock out the account and the client IP.
+// @Description Users with MFA, or whose role requires it, get {"Challenge": models.MFAChallenge} instead of
+// @Description tokens and finish with /login/mfa or /login/mfa/enroll.
 // @Tags auth
 // @Param data body models.LoginRequest true "User credentials"
 // @Success 200 {object} models.Tokens
 // @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Invalid email or password"
+// @Failure 403 {object} string "Email not verified"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /login [post]
 func (h *Handler) Login(c *gin.Context) {
@@ -85,85 +104,165 @@ func (h *Handler) Login(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, 10*time.Second) // Increased timeout
 	defer cancel()
 
-	id, passwordHash, err := h.User.GetUserByEmail(ctx, req.Email)
+	attempt := service.LoginAttempt{Email: req.Email, IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	wait, err := h.LoginGuard.Check(ctx, attempt)
 	if err != nil {
-		er := errors.Wrap(err, "user not found").Error()
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error("login guard check failed, letting the attempt through", "error", err)
+	}
+	if wait > 0 {
+		er := "too many failed login attempts, try again later"
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": er})
 		h.Log.Error(er)
 		return
 	}
-	println(passwordHash)
-	println(req.Password)
+
+	id, passwordHash, err := h.User.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
+		h.LoginGuard.Fail(ctx, attempt, "unknown email")
+		er := service.ErrInvalidCredentials.Error()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
+		h.Log.Error(er, "error", err)
+		return
+	}
+	attempt.UserID = id
 
 	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password))
 	if err != nil {
-		er := errors.Wrap(err, "invalid password").Error()
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.LoginGuard.Fail(ctx, attempt, "wrong password")
+		er := service.ErrInvalidCredentials.Error()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
 		h.Log.Error(er)
 		return
 	}
 
-	role, err := h.User.GetRole(ctx, req.Email)
+	verified, err := h.User.IsEmailVerified(ctx, id)
 	if err != nil {
-		er := errors.Wrap(err, "error getting user role").Error()
+		er := errors.Wrap(err, "error getting email verification state").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
 		h.Log.Error(er)
 		return
 	}
+	if !verified {
+		er := "email not verified"
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
 
-	accessToken, err := tokens.GenerateAccessToken(id, req.Email, role)
+	role, err := h.User.GetRole(ctx, req.Email)
 	if err != nil {
-		er := errors.Wrap(err, "error generating access token").Error()
+		er := errors.Wrap(err, "error getting user role").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
 		h.Log.Error(er)
 		return
 	}
 
-	refreshToken, err := tokens.GenerateRefreshToken(id)
+	body, ok := h.completeLogin(c, ctx, attempt, role, req.Device)
+	if !ok {
+		return
+	}
+
+	h.Log.Info("Login has successfully finished")
+	c.JSON(http.StatusOK, body)
+}
+
+// completeLogin answers a login whose password or code has been checked: with an
+// MFA challenge when the user needs a second factor and with tokens otherwise. It
+// aborts the request and reports false on errors.
+func (h *Handler) completeLogin(c *gin.Context, ctx context.Context, attempt service.LoginAttempt, role, device string) (gin.H, bool) {
+	mfaEnabled, err := h.MFA.Enabled(ctx, attempt.UserID)
 	if err != nil {
-		er := errors.Wrap(err, "error generating refresh token").Error()
+		er := errors.Wrap(err, "error getting mfa state").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
 		h.Log.Error(er)
-		return
+		return nil, false
+	}
+	if mfaEnabled || h.MFA.Required(role) {
+		purpose := tokens.PurposeMFA
+		if !mfaEnabled {
+			purpose = tokens.PurposeMFAEnroll
+		}
+		challenge, err := h.MFA.NewChallenge(attempt, device, purpose)
+		if err != nil {
+			er := errors.Wrap(err, "error generating mfa challenge").Error()
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+			h.Log.Error(er)
+			return nil, false
+		}
+
+		h.Log.Info("Login is waiting for the second factor", slog.String("user_id", attempt.UserID), slog.String("type", purpose))
+		return gin.H{"Challenge": challenge}, true
+	}
+
+	// With MFA the failure counter is only reset once the second factor is checked.
+	h.LoginGuard.Succeed(ctx, attempt)
+
+	t, err := h.issueTokens(c, ctx, attempt.UserID, attempt.Email, role, device)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.Log.Error(err.Error())
+		return nil, false
+	}
+	return gin.H{"Tokens": t}, true
+}
+
+// issueTokens starts a new session for the user and returns its access and refresh tokens.
+func (h *Handler) issueTokens(c *gin.Context, ctx context.Context, id, email, role, device string) (*models.Tokens, error) {
+	refreshToken, err := tokens.GenerateRefreshToken(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating refresh token")
 	}
 
 	exp, err := tokens.GetRefreshTokenExpiry(refreshToken)
 	if err != nil {
-		er := errors.Wrap(err, "error getting refresh token expiry").Error()
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
-		h.Log.Error(er)
-		return
+		return nil, errors.Wrap(err, "error getting refresh token expiry")
 	}
 
-	// Debugging log to inspect token details
-	h.Log.Info("Storing refresh token", slog.String("user_id", id), slog.String("refresh_token", refreshToken), slog.String("expiry", exp))
+	familyID, err := tokens.NewID()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating token family")
+	}
 
-	err = h.Token.Store(ctx, &models.RefreshTokenDetails{
-		UserID: id,
-		Token:  refreshToken,
-		Expiry: exp,
+	h.Log.Info("Storing refresh token", slog.String("user_id", id), slog.String("family_id", familyID), slog.String("expiry", exp))
+
+	err = h.Session.Create(ctx, &models.Session{
+		ID:        familyID,
+		UserID:    id,
+		Device:    device,
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}, &models.RefreshTokenDetails{
+		UserID:   id,
+		Token:    refreshToken,
+		Expiry:   exp,
+		FamilyID: familyID,
 	})
 	if err != nil {
-		er := errors.Wrap(err, "error storing refresh token").Error()
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
-		h.Log.Error(er)
-		return
+		return nil, errors.Wrap(err, "error storing session")
 	}
 
-	h.Log.Info("Login has successfully finished")
-	c.JSON(http.StatusOK, gin.H{"Tokens": models.Tokens{
+	accessToken, err := tokens.GenerateAccessToken(id, email, role, familyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating access token")
+	}
+
+	return &models.Tokens{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-	}})
+	}, nil
 }
 
 // Refresh godoc
 // @Summary Refreshes refresh token
-// @Description Refreshes refresh token
+// @Description Exchanges a refresh token for a new access and refresh token. The presented
+// @Description refresh token is revoked; presenting it again revokes every token issued from the same login.
 // @Tags auth
 // @Param data body models.RefreshToken true "Refresh token"
 // @Success 200 {object} models.Tokens
 // @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Refresh token reuse detected"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /refresh-token [post]
 func (h Handler) Refresh(c *gin.Context) {
@@ -192,9 +291,9 @@ func (h Handler) Refresh(c *gin.Context) {
 		return
 	}
 
-	valid, err = h.Token.Validate(ctx, t.Token)
-	if !valid || err != nil {
-		er := errors.Wrap(err, "invalid refresh token").Error()
+	id, err := tokens.GetUserIdFromRefreshToken(t.Token)
+	if err != nil {
+		er := errors.Wrap(err, "error getting user id").Error()
 		c.AbortWithStatusJSON(http.StatusBadRequest,
 			gin.H{"error": er},
 		)
@@ -202,29 +301,31 @@ func (h Handler) Refresh(c *gin.Context) {
 		return
 	}
 
-	id, err := tokens.GetUserIdFromRefreshToken(t.Token)
+	// Looked up by ID: users who signed up by phone have no email.
+	profile, err := h.User.GetProfileU(ctx, &pb.Id{UserId: id})
 	if err != nil {
-		er := errors.Wrap(err, "error getting user id").Error()
+		er := errors.Wrap(err, "user not found").Error()
 		c.AbortWithStatusJSON(http.StatusBadRequest,
 			gin.H{"error": er},
 		)
 		h.Log.Error(er)
 		return
 	}
+	email, role := profile.Email, profile.Role
 
-	email, _, err := h.User.GetUserByID(ctx, &pb.Id{UserId: id})
+	refreshToken, err := tokens.GenerateRefreshToken(id)
 	if err != nil {
-		er := errors.Wrap(err, "user not found").Error()
-		c.AbortWithStatusJSON(http.StatusBadRequest,
+		er := errors.Wrap(err, "error generating refresh token").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError,
 			gin.H{"error": er},
 		)
 		h.Log.Error(er)
 		return
 	}
 
-	role, err := h.User.GetRole(ctx, email)
+	exp, err := tokens.GetRefreshTokenExpiry(refreshToken)
 	if err != nil {
-		er := errors.Wrap(err, "error getting user role").Error()
+		er := errors.Wrap(err, "error getting refresh token expiry").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError,
 			gin.H{"error": er},
 		)
@@ -232,7 +333,35 @@ func (h Handler) Refresh(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := tokens.GenerateAccessToken(id, email, role)
+	next := &models.RefreshTokenDetails{
+		UserID: id,
+		Token:  refreshToken,
+		Expiry: exp,
+	}
+	err = h.Token.Rotate(ctx, t.Token, next)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, storage.ErrTokenReused) {
+			status = http.StatusUnauthorized
+			h.Log.Warn("refresh token reuse detected, token family revoked", "user_id", id)
+			// Access tokens issued from the stolen family must stop working as well.
+			if err := h.Revocation.RevokeSession(ctx, next.FamilyID, tokens.AccessTokenTTL); err != nil {
+				h.Log.Error("error revoking access tokens", "error", err)
+			}
+		}
+		er := errors.Wrap(err, "invalid refresh token").Error()
+		c.AbortWithStatusJSON(status,
+			gin.H{"error": er},
+		)
+		h.Log.Error(er)
+		return
+	}
+
+	if err := h.Session.Touch(ctx, next.FamilyID, c.ClientIP()); err != nil {
+		h.Log.Error("error updating session", "error", err)
+	}
+
+	accessToken, err := tokens.GenerateAccessToken(id, email, role, next.FamilyID)
 	if err != nil {
 		er := errors.Wrap(err, "error generating access token").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError,
@@ -245,25 +374,33 @@ func (h Handler) Refresh(c *gin.Context) {
 	h.Log.Info("Refresh has successfully finished")
 	c.JSON(http.StatusOK, gin.H{"Tokens": models.Tokens{
 		AccessToken:  accessToken,
-		RefreshToken: t.Token,
+		RefreshToken: refreshToken,
 	}})
 }
 
 // Logout godoc
 // @Summary Logouts user
-// @Description Logouts user by ID
+// @Description Ends the session the refresh token belongs to, or every session of its owner when "all" is set
 // @Tags auth
-// @Param email query string true "User email"
+// @Param data body models.LogoutRequest true "Own refresh token"
 // @Success 200 {string} string "User logged out successfully"
-// @Failure 400 {object} string "Invalid user id"
+// @Failure 400 {object} string "Invalid refresh token"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /logout [post]
 func (h *Handler) Logout(c *gin.Context) {
 	h.Log.Info("Logout function is starting")
 
-	email := c.Query("email")
-	if email == "" {
-		er := errors.New("invalid email").Error()
+	var req models.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	valid, err := tokens.ValidateRefreshToken(req.RefreshToken)
+	if !valid || err != nil {
+		er := errors.Wrap(err, "invalid refresh token").Error()
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
 		h.Log.Error(er)
 		return
@@ -272,8 +409,19 @@ func (h *Handler) Logout(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, time.Second*5)
 	defer cancel()
 
-	err := h.Token.Delete(ctx, email)
-	fmt.Println("sadfjadfdasfads gadsyf asdfa d8uf9udfugyasdf")
+	userID, familyID, err := h.Token.GetFamily(ctx, req.RefreshToken)
+	if err != nil {
+		er := errors.Wrap(err, "invalid refresh token").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	if req.All {
+		_, err = h.Session.RevokeAll(ctx, userID)
+	} else {
+		err = h.Session.Revoke(ctx, userID, familyID)
+	}
 	if err != nil {
 		er := errors.Wrap(err, "error logging out").Error()
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
@@ -281,9 +429,18 @@ func (h *Handler) Logout(c *gin.Context) {
 		return
 	}
 
+	// Access tokens already handed out for the ended sessions must stop working too.
+	if req.All {
+		err = h.Revocation.RevokeUser(ctx, userID, time.Now(), tokens.AccessTokenTTL)
+	} else {
+		err = h.Revocation.RevokeSession(ctx, familyID, tokens.AccessTokenTTL)
+	}
+	if err != nil {
+		h.Log.Error("error revoking access tokens", "error", err)
+	}
+
 	h.Log.Info("Logout has successfully finished")
 	c.JSON(http.StatusOK, "User logged out successfully")
-
 }
 
 // ValidateToken godoc
@@ -317,3 +474,18 @@ func (h *Handler) ValidateToken(c *gin.Context) {
 	h.Log.Info("ValidateToken handler is completed successfully")
 	c.JSON(http.StatusOK, "Refresh token is valid")
 }
+
+// JWKS publishes the public keys access tokens are signed with as a JSON Web Key
+// Set. It is served at /.well-known/jwks.json, outside the /auth API.
+func (h *Handler) JWKS(c *gin.Context) {
+	keys, err := tokens.Keys()
+	if err != nil {
+		er := errors.Wrap(err, "error loading signing keys").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keys.JWKS())
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"Auth/api/tokens"
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/service"
+	"Auth/storage"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRefresh(t *testing.T) {
+	refreshToken, err := tokens.GenerateRefreshToken("user1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		err     error
+		status  int
+		revoked []string
+	}{
+		{name: "Rotated", token: refreshToken, status: http.StatusOK},
+		{name: "Reused", token: refreshToken, err: storage.ErrTokenReused, status: http.StatusUnauthorized, revoked: []string{"family1"}},
+		{name: "Revoked", token: refreshToken, err: errors.New("token revoked"), status: http.StatusBadRequest},
+		{name: "Malformed", token: "not-a-token", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &authStorage{users: map[string]*pb.GetProfileResponse{
+				"user1": {Id: "user1", Email: "user@example.com", Role: "customer"},
+			}}
+			h := &Handler{
+				User:       service.NewUserService(s),
+				Token:      &refreshTokens{err: tt.err},
+				Session:    &sessions{},
+				Revocation: &revocations{},
+				Log:        discard,
+			}
+
+			w := serve(h.Refresh, http.MethodPost, "/refresh-token", models.RefreshToken{Token: tt.token})
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			require.Equal(t, tt.revoked, h.Revocation.(*revocations).sessions)
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var body struct{ Tokens models.Tokens }
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.NotEqual(t, tt.token, body.Tokens.RefreshToken)
+			rotated := h.Token.(*refreshTokens).rotated
+			require.Len(t, rotated, 1)
+			require.Equal(t, body.Tokens.RefreshToken, rotated[0].Token)
+			require.Equal(t, []string{"family1"}, h.Session.(*sessions).touched)
+
+			claims, err := tokens.ExtractAccessClaims(body.Tokens.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, "family1", claims["sid"])
+		})
+	}
+}
+
+func TestDummyPasswordHash(t *testing.T) {
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	require.NoError(t, err)
+	require.Equal(t, bcrypt.DefaultCost, cost, "unknown emails must cost as much as real accounts")
+}
+
+func TestLoginUniformError(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	s := &authStorage{passwords: map[string]string{"user@example.com": string(hash)}}
+	h := &Handler{
+		User:       service.NewUserService(s),
+		LoginGuard: service.NewLoginGuard(s, config.Load()),
+		Log:        discard,
+	}
+
+	unknown := serve(h.Login, http.MethodPost, "/login", models.LoginRequest{Email: "nobody@example.com", Password: "secret123"})
+	wrong := serve(h.Login, http.MethodPost, "/login", models.LoginRequest{Email: "user@example.com", Password: "secret124"})
+
+	require.Equal(t, http.StatusUnauthorized, unknown.Code)
+	require.Equal(t, wrong.Code, unknown.Code)
+	require.JSONEq(t, wrong.Body.String(), unknown.Body.String())
+}
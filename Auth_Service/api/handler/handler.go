@@ -1,24 +1,54 @@
 package handler
 
 import (
+	"Auth/config"
 	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/pkg/sms"
 	"Auth/service"
 	"Auth/storage"
+	"log"
 	"log/slog"
 )
 
 type Handler struct {
-	User  *service.UserService
-	Log   *slog.Logger
-	Admin *service.AdminService
-	Token storage.ITokenStorage
+	User         *service.UserService
+	Log          *slog.Logger
+	Admin        *service.AdminService
+	Token        storage.ITokenStorage
+	Session      storage.ISessionStorage
+	Revocation   storage.IRevocationStorage
+	Verification *service.VerificationService
+	Password     *service.PasswordService
+	LoginGuard   *service.LoginGuard
+	MFA          *service.MFAService
+	Phone        *service.PhoneService
+	OIDC         *service.OIDCService
 }
 
 func NewHandler(s storage.IStorage) *Handler {
+	cfg := config.Load()
+	m, err := mailer.New(cfg)
+	if err != nil {
+		log.Fatalf("error while creating mailer: %v", err)
+	}
+	sender, err := sms.New(cfg)
+	if err != nil {
+		log.Fatalf("error while creating sms sender: %v", err)
+	}
+
 	return &Handler{
-		User:  service.NewUserService(s),
-		Admin: service.NewAdminService(s),
-		Token: s.Token(),
-		Log:   logger.NewLogger(),
+		User:         service.NewUserService(s),
+		Admin:        service.NewAdminService(s),
+		Token:        s.Token(),
+		Session:      s.Session(),
+		Revocation:   s.Revocation(),
+		Verification: service.NewVerificationService(s, m, cfg),
+		Password:     service.NewPasswordService(s, m, cfg),
+		LoginGuard:   service.NewLoginGuard(s, cfg),
+		MFA:          service.NewMFAService(s, cfg),
+		Phone:        service.NewPhoneService(s, sender, cfg),
+		OIDC:         service.NewOIDCService(s, cfg),
+		Log:          logger.NewLogger(),
 	}
 }
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// TestMain runs the tests in a scratch directory holding the .env that config.Load
+// reads, so the handlers sign tokens with test secrets and a generated key.
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	dir, err := os.MkdirTemp("", "auth-handler")
+	if err != nil {
+		log.Fatal(err)
+	}
+	env := "APP_ENV=development\nREFRESH_TOKEN=test-refresh-secret\nJWT_KEYS_DIR=" + filepath.Join(dir, "keys") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// authStorage serves the profiles in users and the password hashes in passwords,
+// keyed by email. Login failures and security events are dropped; other storages
+// are left nil.
+type authStorage struct {
+	storage.IStorage
+	storage.IUserStorage
+	users     map[string]*pb.GetProfileResponse
+	passwords map[string]string
+}
+
+func (s *authStorage) User() storage.IUserStorage                   { return s }
+func (s *authStorage) LoginFailure() storage.ILoginFailureStorage   { return loginFailures{} }
+func (s *authStorage) SecurityEvent() storage.ISecurityEventStorage { return securityEvents{} }
+
+func (s *authStorage) GetUserByEmail(ctx context.Context, email string) (string, string, error) {
+	if hash, ok := s.passwords[email]; ok {
+		return "user1", hash, nil
+	}
+	return "", "", errors.New("user not found")
+}
+
+func (s *authStorage) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileResponse, error) {
+	if p, ok := s.users[id.UserId]; ok {
+		return p, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+type loginFailures struct{ storage.ILoginFailureStorage }
+
+func (loginFailures) Get(ctx context.Context, key string) (*models.LoginFailure, error) {
+	return nil, nil
+}
+
+func (loginFailures) RecordFailure(ctx context.Context, key string, window time.Duration) (*models.LoginFailure, error) {
+	return &models.LoginFailure{Key: key, Failures: 1}, nil
+}
+
+type securityEvents struct{ storage.ISecurityEventStorage }
+
+func (securityEvents) Save(ctx context.Context, event *pb.SecurityEvent) error { return nil }
+
+// refreshTokens rotates every token into family1, failing with err.
+type refreshTokens struct {
+	storage.ITokenStorage
+	err     error
+	rotated []*models.RefreshTokenDetails
+}
+
+func (t *refreshTokens) Rotate(ctx context.Context, old string, next *models.RefreshTokenDetails) error {
+	next.FamilyID = "family1"
+	if t.err != nil {
+		return t.err
+	}
+	t.rotated = append(t.rotated, next)
+	return nil
+}
+
+type sessions struct {
+	storage.ISessionStorage
+	touched []string
+}
+
+func (s *sessions) Touch(ctx context.Context, id, ip string) error {
+	s.touched = append(s.touched, id)
+	return nil
+}
+
+// revocations records the sessions whose access tokens were revoked.
+type revocations struct {
+	storage.IRevocationStorage
+	sessions []string
+}
+
+func (r *revocations) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	r.sessions = append(r.sessions, sessionID)
+	return nil
+}
+
+// serve runs handle for a JSON request and returns the recorded response.
+func serve(handle gin.HandlerFunc, method, target string, body interface{}) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	c.Request = httptest.NewRequest(method, target, &buf)
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handle(c)
+	return w
+}
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
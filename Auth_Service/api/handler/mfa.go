@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"Auth/api/tokens"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/service"
+	"Auth/storage"
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// MFAStatus godoc
+// @Summary Shows MFA status
+// @Description Shows whether two-factor authentication is enabled or required and how many recovery codes are left
+// @Tags mfa
+// @Security ApiKeyAuth
+// @Success 200 {object} models.MFAStatus
+// @Failure 401 {object} string "Authorization token required"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /mfa [get]
+func (h *Handler) MFAStatus(c *gin.Context) {
+	h.Log.Info("MFAStatus function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	resp, err := h.MFA.Status(ctx, c.GetString("user_id"))
+	if err != nil {
+		h.abortMFAError(c, err, "error getting mfa status")
+		return
+	}
+
+	h.Log.Info("MFAStatus has successfully finished")
+	c.JSON(http.StatusOK, resp)
+}
+
+// EnrollMFA godoc
+// @Summary Starts MFA enrollment
+// @Description Creates a TOTP secret and its otpauth:// URI for an authenticator app. MFA is enabled by /mfa/confirm.
+// @Tags mfa
+// @Security ApiKeyAuth
+// @Success 200 {object} models.MFAEnrollment
+// @Failure 401 {object} string "Authorization token required"
+// @Failure 409 {object} string "MFA is already enabled"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /mfa/enroll [post]
+func (h *Handler) EnrollMFA(c *gin.Context) {
+	h.Log.Info("EnrollMFA function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	resp, err := h.MFA.Enroll(ctx, c.GetString("user_id"))
+	if err != nil {
+		h.abortMFAError(c, err, "error enrolling mfa")
+		return
+	}
+
+	h.Log.Info("EnrollMFA has successfully finished")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ConfirmMFA godoc
+// @Summary Enables MFA
+// @Description Enables MFA with the first code from the authenticator app and returns recovery codes, which are shown only once
+// @Tags mfa
+// @Security ApiKeyAuth
+// @Param data body models.MFACodeRequest true "Authentication code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} string "MFA is not enrolled"
+// @Failure 401 {object} string "Invalid authentication code"
+// @Failure 409 {object} string "MFA is already enabled"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /mfa/confirm [post]
+func (h *Handler) ConfirmMFA(c *gin.Context) {
+	h.Log.Info("ConfirmMFA function is starting")
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		er := "code is required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	codes, err := h.MFA.Confirm(ctx, c.GetString("user_id"), req.Code)
+	if err != nil {
+		h.abortMFAError(c, err, "error confirming mfa")
+		return
+	}
+
+	h.Log.Info("ConfirmMFA has successfully finished")
+	c.JSON(http.StatusOK, models.RecoveryCodes{Codes: codes})
+}
+
+// DisableMFA godoc
+// @Summary Disables MFA
+// @Description Turns MFA off after checking the password and a current code. Not allowed for roles that require MFA.
+// @Tags mfa
+// @Security ApiKeyAuth
+// @Param data body models.MFADisableRequest true "Password and authentication code"
+// @Success 200 {string} string "MFA disabled"
+// @Failure 401 {object} string "Wrong password or invalid authentication code"
+// @Failure 403 {object} string "MFA is required for this role"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /mfa/disable [post]
+func (h *Handler) DisableMFA(c *gin.Context) {
+	h.Log.Info("DisableMFA function is starting")
+
+	var req models.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Password == "" || req.Code == "" {
+		er := "password and code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.MFA.Disable(ctx, c.GetString("user_id"), req.Password, req.Code); err != nil {
+		h.abortMFAError(c, err, "error disabling mfa")
+		return
+	}
+
+	h.Log.Info("DisableMFA has successfully finished")
+	c.JSON(http.StatusOK, "MFA disabled")
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Replaces recovery codes
+// @Description Invalidates all recovery codes and returns new ones after checking a current code
+// @Tags mfa
+// @Security ApiKeyAuth
+// @Param data body models.MFACodeRequest true "Authentication code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} string "MFA is not enrolled"
+// @Failure 401 {object} string "Invalid authentication code"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /mfa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	h.Log.Info("RegenerateRecoveryCodes function is starting")
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		er := "code is required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	codes, err := h.MFA.RegenerateRecoveryCodes(ctx, c.GetString("user_id"), req.Code)
+	if err != nil {
+		h.abortMFAError(c, err, "error replacing recovery codes")
+		return
+	}
+
+	h.Log.Info("RegenerateRecoveryCodes has successfully finished")
+	c.JSON(http.StatusOK, models.RecoveryCodes{Codes: codes})
+}
+
+// LoginMFA godoc
+// @Summary Finishes login with a second factor
+// @Description Exchanges the challenge token from /login and an authentication or recovery code for tokens
+// @Tags auth
+// @Param data body models.MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} models.Tokens
+// @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Invalid challenge token or authentication code"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /login/mfa [post]
+func (h *Handler) LoginMFA(c *gin.Context) {
+	h.Log.Info("LoginMFA function is starting")
+
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ChallengeToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		er := "challenge token and code or recovery code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	attempt, device, ok := h.mfaAttempt(c, ctx, req.ChallengeToken, tokens.PurposeMFA)
+	if !ok {
+		return
+	}
+
+	if err := h.MFA.Verify(ctx, attempt.UserID, req.Code, req.RecoveryCode); err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			h.LoginGuard.Fail(ctx, attempt, "wrong mfa code")
+		}
+		h.abortMFAError(c, err, "error verifying mfa code")
+		return
+	}
+
+	t, ok := h.finishMFALogin(c, ctx, attempt, device)
+	if !ok {
+		return
+	}
+
+	h.Log.Info("LoginMFA has successfully finished")
+	c.JSON(http.StatusOK, gin.H{"Tokens": t})
+}
+
+// LoginMFAEnroll godoc
+// @Summary Starts MFA enrollment during login
+// @Description For roles that require MFA: creates a TOTP secret with the "mfa_enroll" challenge token from /login
+// @Tags auth
+// @Param data body models.MFAChallengeRequest true "Challenge token"
+// @Success 200 {object} models.MFAEnrollment
+// @Failure 401 {object} string "Invalid challenge token"
+// @Failure 409 {object} string "MFA is already enabled"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /login/mfa/enroll [post]
+func (h *Handler) LoginMFAEnroll(c *gin.Context) {
+	h.Log.Info("LoginMFAEnroll function is starting")
+
+	var req models.MFAChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ChallengeToken == "" {
+		er := "challenge token is required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	claims, err := tokens.ExtractChallengeClaims(req.ChallengeToken, tokens.PurposeMFAEnroll)
+	if err != nil {
+		er := err.Error()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	userID, _ := claims["user_id"].(string)
+	resp, err := h.MFA.Enroll(ctx, userID)
+	if err != nil {
+		h.abortMFAError(c, err, "error enrolling mfa")
+		return
+	}
+
+	h.Log.Info("LoginMFAEnroll has successfully finished")
+	c.JSON(http.StatusOK, resp)
+}
+
+// LoginMFAEnrollConfirm godoc
+// @Summary Finishes login by enabling MFA
+// @Description Enables MFA with the first code from the authenticator app and returns tokens and the recovery codes
+// @Tags auth
+// @Param data body models.MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} models.MFAEnrollLoginResponse
+// @Failure 400 {object} string "MFA is not enrolled"
+// @Failure 401 {object} string "Invalid challenge token or authentication code"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /login/mfa/enroll/confirm [post]
+func (h *Handler) LoginMFAEnrollConfirm(c *gin.Context) {
+	h.Log.Info("LoginMFAEnrollConfirm function is starting")
+
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ChallengeToken == "" || req.Code == "" {
+		er := "challenge token and code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	attempt, device, ok := h.mfaAttempt(c, ctx, req.ChallengeToken, tokens.PurposeMFAEnroll)
+	if !ok {
+		return
+	}
+
+	codes, err := h.MFA.Confirm(ctx, attempt.UserID, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			h.LoginGuard.Fail(ctx, attempt, "wrong mfa code")
+		}
+		h.abortMFAError(c, err, "error confirming mfa")
+		return
+	}
+
+	t, ok := h.finishMFALogin(c, ctx, attempt, device)
+	if !ok {
+		return
+	}
+
+	h.Log.Info("LoginMFAEnrollConfirm has successfully finished")
+	c.JSON(http.StatusOK, models.MFAEnrollLoginResponse{Tokens: *t, RecoveryCodes: codes})
+}
+
+// mfaAttempt parses the challenge token and checks that the account and client
+// are not locked out. It aborts the request and reports false otherwise.
+func (h *Handler) mfaAttempt(c *gin.Context, ctx context.Context, challenge, purpose string) (service.LoginAttempt, string, bool) {
+	claims, err := tokens.ExtractChallengeClaims(challenge, purpose)
+	if err != nil {
+		er := err.Error()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
+		h.Log.Error(er)
+		return service.LoginAttempt{}, "", false
+	}
+
+	userID, _ := claims["user_id"].(string)
+	email, _ := claims["email"].(string)
+	phone, _ := claims["phone"].(string)
+	device, _ := claims["device"].(string)
+	attempt := service.LoginAttempt{Email: email, Phone: phone, UserID: userID, IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+
+	wait, err := h.LoginGuard.Check(ctx, attempt)
+	if err != nil {
+		h.Log.Error("login guard check failed, letting the attempt through", "error", err)
+	}
+	if wait > 0 {
+		er := "too many failed login attempts, try again later"
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": er})
+		h.Log.Error(er)
+		return service.LoginAttempt{}, "", false
+	}
+
+	return attempt, device, true
+}
+
+// finishMFALogin issues tokens once the second factor has been checked.
+func (h *Handler) finishMFALogin(c *gin.Context, ctx context.Context, attempt service.LoginAttempt, device string) (*models.Tokens, bool) {
+	profile, err := h.User.GetProfileU(ctx, &pb.Id{UserId: attempt.UserID})
+	if err != nil {
+		er := errors.Wrap(err, "error getting user role").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return nil, false
+	}
+
+	h.LoginGuard.Succeed(ctx, attempt)
+
+	t, err := h.issueTokens(c, ctx, attempt.UserID, attempt.Email, profile.Role, device)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.Log.Error(err.Error())
+		return nil, false
+	}
+	return t, true
+}
+
+func (h *Handler) abortMFAError(c *gin.Context, err error, msg string) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrWrongPassword):
+		code = http.StatusUnauthorized
+	case errors.Is(err, service.ErrMFANotEnrolled):
+		code = http.StatusBadRequest
+	case errors.Is(err, service.ErrMFARequired):
+		code = http.StatusForbidden
+	case errors.Is(err, storage.ErrMFAEnabled):
+		code = http.StatusConflict
+	}
+
+	er := errors.Wrap(err, msg).Error()
+	c.AbortWithStatusJSON(code, gin.H{"error": er})
+	h.Log.Error(er)
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/service"
+	"Auth/storage"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// OIDCProviders godoc
+// @Summary Lists social login providers
+// @Description Lists the names of the configured OpenID Connect providers
+// @Tags oidc
+// @Success 200 {object} []string
+// @Router /oidc/providers [get]
+func (h *Handler) OIDCProviders(c *gin.Context) {
+	h.Log.Info("OIDCProviders function is starting")
+	c.JSON(http.StatusOK, h.OIDC.Providers())
+}
+
+// StartOIDC godoc
+// @Summary Starts social login
+// @Description Starts signing in with the provider using PKCE. Returns the provider's authorization URL, or redirects to it with redirect=true.
+// @Tags oidc
+// @Param provider path string true "Provider name"
+// @Param device query string false "Session label, e.g. iPhone"
+// @Param login_hint query string false "Email to suggest at the provider"
+// @Param redirect query bool false "Redirect instead of returning the URL"
+// @Success 200 {object} models.OIDCStart
+// @Failure 404 {object} string "Unknown provider"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /oidc/{provider}/start [get]
+func (h *Handler) StartOIDC(c *gin.Context) {
+	h.Log.Info("StartOIDC function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	resp, err := h.OIDC.Start(ctx, c.Param("provider"), "", c.Query("device"), c.Query("login_hint"))
+	if err != nil {
+		h.abortOIDCError(c, err, "error starting sign-in")
+		return
+	}
+
+	h.Log.Info("StartOIDC has successfully finished")
+	if c.Query("redirect") == "true" {
+		c.Redirect(http.StatusFound, resp.AuthorizationURL)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// LinkOIDC godoc
+// @Summary Links a social login provider
+// @Description Starts signing in with the provider to link it to the caller's account; the callback finishes the link
+// @Tags oidc
+// @Security ApiKeyAuth
+// @Param provider path string true "Provider name"
+// @Success 200 {object} models.OIDCStart
+// @Failure 401 {object} string "Authorization token required"
+// @Failure 404 {object} string "Unknown provider"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /oidc/{provider}/link [post]
+func (h *Handler) LinkOIDC(c *gin.Context) {
+	h.Log.Info("LinkOIDC function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	resp, err := h.OIDC.Start(ctx, c.Param("provider"), c.GetString("user_id"), "", "")
+	if err != nil {
+		h.abortOIDCError(c, err, "error starting link")
+		return
+	}
+
+	h.Log.Info("LinkOIDC has successfully finished")
+	c.JSON(http.StatusOK, resp)
+}
+
+// OIDCCallback godoc
+// @Summary Finishes social login
+// @Description Redirect target of the provider. Logs the user in, creating a customer account for a new provider account,
+// @Description or finishes linking. Users with MFA get {"Challenge": models.MFAChallenge} instead of tokens, as with /login.
+// @Tags oidc
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State from /oidc/{provider}/start"
+// @Success 200 {object} models.Tokens
+// @Failure 400 {object} string "Invalid or expired sign-in state"
+// @Failure 401 {object} string "Sign-in with the provider failed"
+// @Failure 409 {object} string "Email or identity already in use"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /oidc/{provider}/callback [get]
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	h.Log.Info("OIDCCallback function is starting")
+
+	var req models.OIDCCallbackRequest
+	if err := c.ShouldBind(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	if req.Error != "" {
+		er := "provider returned an error: " + req.Error + " " + req.ErrorDescription
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	if req.Code == "" || req.State == "" {
+		er := "code and state are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*15)
+	defer cancel()
+
+	res, err := h.OIDC.Callback(ctx, c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		h.abortOIDCError(c, err, "error finishing sign-in")
+		return
+	}
+	if res.Linked {
+		h.Log.Info("OIDCCallback has successfully linked a provider")
+		c.JSON(http.StatusOK, "Provider linked")
+		return
+	}
+
+	profile, err := h.User.GetProfileU(ctx, &pb.Id{UserId: res.UserID})
+	if err != nil {
+		er := errors.Wrap(err, "error getting user profile").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	attempt := service.LoginAttempt{Email: res.Email, UserID: res.UserID, IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	if res.Email == "" {
+		attempt.Phone = profile.PhoneNumber
+	}
+	body, ok := h.completeLogin(c, ctx, attempt, profile.Role, res.Device)
+	if !ok {
+		return
+	}
+	body["registered"] = res.Created
+
+	h.Log.Info("OIDCCallback has successfully finished")
+	c.JSON(http.StatusOK, body)
+}
+
+// ListIdentities godoc
+// @Summary Lists my linked providers
+// @Description Lists the social login accounts linked to the caller
+// @Tags oidc
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Identities
+// @Failure 401 {object} string "Authorization token required"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /identities [get]
+func (h *Handler) ListIdentities(c *gin.Context) {
+	h.Log.Info("ListIdentities function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	identities, err := h.OIDC.Identities(ctx, c.GetString("user_id"))
+	if err != nil {
+		h.abortOIDCError(c, err, "error listing identities")
+		return
+	}
+
+	h.Log.Info("ListIdentities has successfully finished")
+	c.JSON(http.StatusOK, models.Identities{Identities: identities})
+}
+
+// UnlinkIdentity godoc
+// @Summary Unlinks a provider
+// @Description Removes the caller's account at the provider, unless it is the only way left to log in
+// @Tags oidc
+// @Security ApiKeyAuth
+// @Param provider path string true "Provider name"
+// @Success 200 {string} string "Provider unlinked"
+// @Failure 401 {object} string "Authorization token required"
+// @Failure 404 {object} string "Identity not found"
+// @Failure 409 {object} string "Only way left to log in"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /identities/{provider} [delete]
+func (h *Handler) UnlinkIdentity(c *gin.Context) {
+	h.Log.Info("UnlinkIdentity function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.OIDC.Unlink(ctx, c.GetString("user_id"), c.Param("provider")); err != nil {
+		h.abortOIDCError(c, err, "error unlinking provider")
+		return
+	}
+
+	h.Log.Info("UnlinkIdentity has successfully finished")
+	c.JSON(http.StatusOK, "Provider unlinked")
+}
+
+func (h *Handler) abortOIDCError(c *gin.Context, err error, msg string) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrUnknownProvider):
+		code = http.StatusNotFound
+	case errors.Is(err, storage.ErrInvalidState), errors.Is(err, service.ErrNoEmail):
+		code = http.StatusBadRequest
+	case errors.Is(err, service.ErrSignInFailed):
+		code = http.StatusUnauthorized
+	case errors.Is(err, service.ErrEmailInUse), errors.Is(err, storage.ErrIdentityTaken), errors.Is(err, service.ErrLastLoginMethod):
+		code = http.StatusConflict
+	case errors.Is(err, storage.ErrIdentityNotFound):
+		code = http.StatusNotFound
+	}
+
+	er := errors.Wrap(err, msg).Error()
+	c.AbortWithStatusJSON(code, gin.H{"error": er})
+	h.Log.Error(er)
+}
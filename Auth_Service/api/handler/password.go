@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"Auth/models"
+	"Auth/service"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ForgotPassword godoc
+// @Summary Requests password reset
+// @Description Emails a single-use password reset link if an account with the email exists
+// @Tags password
+// @Param data body models.EmailRequest true "Email"
+// @Success 200 {string} string "Reset link sent"
+// @Failure 400 {object} string "Invalid data"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /password/forgot [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	h.Log.Info("ForgotPassword function is starting")
+
+	var req models.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Email == "" {
+		er := "email is required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	if err := h.Password.RequestReset(ctx, req.Email); err != nil {
+		er := errors.Wrap(err, "error requesting password reset").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ForgotPassword has successfully finished")
+	c.JSON(http.StatusOK, "If the account exists, a reset link has been sent")
+}
+
+// ResetPassword godoc
+// @Summary Resets password
+// @Description Sets a new password with the token from the reset email and logs the user out everywhere
+// @Tags password
+// @Param data body models.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {string} string "Password reset"
+// @Failure 400 {object} string "Invalid or expired reset token"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /password/reset [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	h.Log.Info("ResetPassword function is starting")
+
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		er := "token and new password are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.Password.Reset(ctx, req.Token, req.NewPassword); err != nil {
+		h.abortPasswordError(c, err, "error resetting password")
+		return
+	}
+
+	h.Log.Info("ResetPassword has successfully finished")
+	c.JSON(http.StatusOK, "Password reset, please log in again")
+}
+
+// ChangePassword godoc
+// @Summary Changes password
+// @Description Changes the password of the caller after checking the old one and logs the user out everywhere
+// @Tags password
+// @Security ApiKeyAuth
+// @Param data body models.ChangePasswordRequest true "Old and new password"
+// @Success 200 {string} string "Password changed"
+// @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 403 {object} string "Old password is incorrect"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /password/change [post]
+func (h *Handler) ChangePassword(c *gin.Context) {
+	h.Log.Info("ChangePassword function is starting")
+
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.Password.Change(ctx, c.GetString("user_id"), req.OldPassword, req.NewPassword); err != nil {
+		h.abortPasswordError(c, err, "error changing password")
+		return
+	}
+
+	h.Log.Info("ChangePassword has successfully finished")
+	c.JSON(http.StatusOK, "Password changed, please log in again")
+}
+
+func (h *Handler) abortPasswordError(c *gin.Context, err error, msg string) {
+	er := errors.Wrap(err, msg).Error()
+	switch {
+	case errors.Is(err, service.ErrWrongPassword):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+	case errors.Is(err, service.ErrWeakPassword), strings.Contains(err.Error(), "invalid or expired reset token"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/service"
+	"Auth/storage"
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// SendPhoneCode godoc
+// @Summary Sends a login code by SMS
+// @Description Texts a one-time code for /phone/login or /phone/verify. Codes are rate limited per phone and per IP.
+// @Tags phone
+// @Param data body models.PhoneCodeRequest true "Phone number in international format"
+// @Success 200 {string} string "Code sent"
+// @Failure 400 {object} string "Invalid phone number"
+// @Failure 429 {object} string "Too many codes requested, see Retry-After"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /phone/send-code [post]
+func (h *Handler) SendPhoneCode(c *gin.Context) {
+	h.Log.Info("SendPhoneCode function is starting")
+
+	var req models.PhoneCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	phone, err := service.NormalizePhone(req.Phone)
+	if err != nil {
+		h.abortPhoneError(c, err, "invalid phone number")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	if err := h.Phone.SendCode(ctx, phone, c.ClientIP()); err != nil {
+		h.abortPhoneError(c, err, "error sending code")
+		return
+	}
+
+	h.Log.Info("SendPhoneCode has successfully finished")
+	c.JSON(http.StatusOK, "Code sent to "+phone)
+}
+
+// PhoneLogin godoc
+// @Summary Logs in with a phone code
+// @Description Logs in with the code from /phone/send-code. A customer account is created when no account has verified the phone.
+// @Description Users with MFA get {"Challenge": models.MFAChallenge} instead of tokens, as with /login.
+// @Tags phone
+// @Param data body models.PhoneLoginRequest true "Phone, code and, for new accounts, name"
+// @Success 200 {object} models.Tokens
+// @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Invalid or expired code"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /phone/login [post]
+func (h *Handler) PhoneLogin(c *gin.Context) {
+	h.Log.Info("PhoneLogin function is starting")
+
+	var req models.PhoneLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		er := "phone and code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	phone, err := service.NormalizePhone(req.Phone)
+	if err != nil {
+		h.abortPhoneError(c, err, "invalid phone number")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	attempt := service.LoginAttempt{Phone: phone, IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	wait, err := h.LoginGuard.Check(ctx, attempt)
+	if err != nil {
+		h.Log.Error("login guard check failed, letting the attempt through", "error", err)
+	}
+	if wait > 0 {
+		er := "too many failed login attempts, try again later"
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	id, created, err := h.Phone.Login(ctx, phone, req.Code, req.FirstName, req.LastName)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidCode) {
+			h.LoginGuard.Fail(ctx, attempt, "wrong phone code")
+		}
+		h.abortPhoneError(c, err, "error logging in")
+		return
+	}
+	attempt.UserID = id
+
+	profile, err := h.User.GetProfileU(ctx, &pb.Id{UserId: id})
+	if err != nil {
+		er := errors.Wrap(err, "error getting user profile").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	attempt.Email = profile.Email
+
+	body, ok := h.completeLogin(c, ctx, attempt, profile.Role, req.Device)
+	if !ok {
+		return
+	}
+	body["registered"] = created
+
+	h.Log.Info("PhoneLogin has successfully finished")
+	c.JSON(http.StatusOK, body)
+}
+
+// VerifyPhone godoc
+// @Summary Verifies a phone number
+// @Description Sets the phone number of the logged-in user and marks it as verified with the code from /phone/send-code
+// @Tags phone
+// @Security ApiKeyAuth
+// @Param data body models.PhoneVerifyRequest true "Phone and code"
+// @Success 200 {string} string "Phone verified"
+// @Failure 400 {object} string "Invalid data"
+// @Failure 401 {object} string "Invalid or expired code"
+// @Failure 409 {object} string "Phone number belongs to another account"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /phone/verify [post]
+func (h *Handler) VerifyPhone(c *gin.Context) {
+	h.Log.Info("VerifyPhone function is starting")
+
+	var req models.PhoneVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		er := "phone and code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	phone, err := service.NormalizePhone(req.Phone)
+	if err != nil {
+		h.abortPhoneError(c, err, "invalid phone number")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.Phone.Verify(ctx, c.GetString("user_id"), phone, req.Code); err != nil {
+		h.abortPhoneError(c, err, "error verifying phone")
+		return
+	}
+
+	h.Log.Info("VerifyPhone has successfully finished")
+	c.JSON(http.StatusOK, "Phone verified")
+}
+
+func (h *Handler) abortPhoneError(c *gin.Context, err error, msg string) {
+	code := http.StatusInternalServerError
+	var tooMany *service.TooManyCodesError
+	switch {
+	case errors.As(err, &tooMany):
+		code = http.StatusTooManyRequests
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+	case errors.Is(err, service.ErrInvalidPhone):
+		code = http.StatusBadRequest
+	case errors.Is(err, storage.ErrInvalidCode):
+		code = http.StatusUnauthorized
+	case errors.Is(err, storage.ErrPhoneTaken):
+		code = http.StatusConflict
+	}
+
+	er := errors.Wrap(err, msg).Error()
+	c.AbortWithStatusJSON(code, gin.H{"error": er})
+	h.Log.Error(er)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"Auth/api/tokens"
+	"Auth/models"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ListSessions godoc
+// @Summary Lists my sessions
+// @Description Lists the active sessions (devices) of the caller; the one the access token belongs to is marked as current
+// @Tags sessions
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Sessions
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /sessions [get]
+func (h *Handler) ListSessions(c *gin.Context) {
+	h.Log.Info("ListSessions function is starting")
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	sessions, err := h.Session.List(ctx, c.GetString("user_id"))
+	if err != nil {
+		er := errors.Wrap(err, "error listing sessions").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	current := c.GetString("session_id")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	h.Log.Info("ListSessions has successfully finished")
+	c.JSON(http.StatusOK, models.Sessions{Sessions: sessions})
+}
+
+// RevokeSession godoc
+// @Summary Revokes one of my sessions
+// @Description Logs one device out by revoking the refresh tokens of the session
+// @Tags sessions
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Success 200 {string} string "Session revoked"
+// @Failure 400 {object} string "Invalid session ID"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "Session not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	h.Log.Info("RevokeSession function is starting")
+
+	id := c.Param("id")
+	if !tokens.ValidID(id) {
+		er := "invalid session id"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er, "session_id", id)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	err := h.Session.Revoke(ctx, c.GetString("user_id"), id)
+	if err != nil {
+		er := errors.Wrap(err, "error revoking session").Error()
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "session not found") {
+			status = http.StatusNotFound
+		}
+		c.AbortWithStatusJSON(status, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	if err := h.Revocation.RevokeSession(ctx, id, tokens.AccessTokenTTL); err != nil {
+		h.Log.Error("error revoking access tokens", "error", err)
+	}
+
+	h.Log.Info("RevokeSession has successfully finished")
+	c.JSON(http.StatusOK, "Session revoked")
+}
+
+// RevokeOtherSessions godoc
+// @Summary Revokes all my other sessions
+// @Description Logs every other device out, keeping only the session the access token belongs to
+// @Tags sessions
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]int64 "Number of revoked sessions"
+// @Failure 400 {object} string "Token has no session"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /sessions/revoke-others [post]
+func (h *Handler) RevokeOtherSessions(c *gin.Context) {
+	h.Log.Info("RevokeOtherSessions function is starting")
+
+	current := c.GetString("session_id")
+	if !tokens.ValidID(current) {
+		er := "access token is not bound to a session"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	ids, err := h.Session.RevokeOthers(ctx, c.GetString("user_id"), current)
+	if err != nil {
+		er := errors.Wrap(err, "error revoking sessions").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	for _, id := range ids {
+		if err := h.Revocation.RevokeSession(ctx, id, tokens.AccessTokenTTL); err != nil {
+			h.Log.Error("error revoking access tokens", "session_id", id, "error", err)
+		}
+	}
+
+	h.Log.Info("RevokeOtherSessions has successfully finished")
+	c.JSON(http.StatusOK, gin.H{"revoked": len(ids)})
+}
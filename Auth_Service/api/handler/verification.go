@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"Auth/models"
+	"Auth/service"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// VerifyEmail godoc
+// @Summary Verifies email
+// @Description Confirms the email address with the code from the verification email
+// @Tags auth
+// @Param data body models.VerifyEmailRequest true "Email and code"
+// @Success 200 {string} string "Email verified"
+// @Failure 400 {object} string "Invalid or expired code"
+// @Router /verify-email [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	h.Log.Info("VerifyEmail function is starting")
+
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBind(&req); err != nil || req.Email == "" || req.Code == "" {
+		er := "email and code are required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*5)
+	defer cancel()
+
+	if err := h.Verification.Verify(ctx, req.Email, req.Code); err != nil {
+		er := errors.Wrap(err, "error verifying email").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("VerifyEmail has successfully finished")
+	c.JSON(http.StatusOK, "Email verified")
+}
+
+// VerifyEmailLink godoc
+// @Summary Verifies email from link
+// @Description Confirms the email address from the link in the verification email
+// @Tags auth
+// @Param email query string true "Email"
+// @Param code query string true "Verification code"
+// @Success 200 {string} string "Email verified"
+// @Failure 400 {object} string "Invalid or expired code"
+// @Router /verify-email [get]
+func (h *Handler) VerifyEmailLink(c *gin.Context) {
+	h.VerifyEmail(c)
+}
+
+// ResendVerification godoc
+// @Summary Resends verification code
+// @Description Emails a new verification code if the account exists and is not verified yet
+// @Tags auth
+// @Param data body models.EmailRequest true "Email"
+// @Success 200 {string} string "Verification code sent"
+// @Failure 400 {object} string "Invalid data"
+// @Failure 429 {object} string "Code was sent recently"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /verify-email/resend [post]
+func (h *Handler) ResendVerification(c *gin.Context) {
+	h.Log.Info("ResendVerification function is starting")
+
+	var req models.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Email == "" {
+		er := "email is required"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, time.Second*10)
+	defer cancel()
+
+	if err := h.Verification.Resend(ctx, req.Email); err != nil {
+		er := errors.Wrap(err, "error resending verification code").Error()
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrResendTooSoon) {
+			status = http.StatusTooManyRequests
+		}
+		c.AbortWithStatusJSON(status, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ResendVerification has successfully finished")
+	c.JSON(http.StatusOK, "If the account needs verification, a new code has been sent")
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"Auth/api/tokens"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate requires a valid access token in the Authorization header and puts
+// its user_id and session_id claims into the context.
+func Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.GetHeader("Authorization")
+		auth = strings.TrimPrefix(auth, "Bearer ")
+		auth = strings.Trim(auth, "\"")
+		if auth == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization token required"})
+			return
+		}
+
+		claims, err := tokens.ExtractAccessClaims(auth)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := claims["user_id"].(string)
+		if userID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+			return
+		}
+		sessionID, _ := claims["sid"].(string)
+
+		c.Set("user_id", userID)
+		c.Set("session_id", sessionID)
+		c.Next()
+	}
+}
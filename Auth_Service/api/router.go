@@ -3,6 +3,7 @@ package api
 import (
 	_ "Auth/api/docs"
 	"Auth/api/handler"
+	"Auth/api/middleware"
 	"Auth/storage"
 
 	"github.com/gin-gonic/gin"
@@ -15,16 +16,55 @@ import (
 // @description Authorazation API
 // @BasePath /auth
 // @schemes http
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
 func NewRouter(s storage.IStorage) *gin.Engine {
 	h := handler.NewHandler(s)
 
 	router := gin.Default()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/.well-known/jwks.json", h.JWKS)
 	auth := router.Group("/auth")
 	auth.POST("/register", h.Register)
 	auth.POST("/login", h.Login)
+	auth.POST("/login/mfa", h.LoginMFA)
+	auth.POST("/login/mfa/enroll", h.LoginMFAEnroll)
+	auth.POST("/login/mfa/enroll/confirm", h.LoginMFAEnrollConfirm)
 	auth.POST("/refresh-token", h.Refresh)
 	auth.POST("/logout", h.Logout)
 	auth.POST("/validate", h.ValidateToken)
+	auth.POST("/verify-email", h.VerifyEmail)
+	auth.GET("/verify-email", h.VerifyEmailLink)
+	auth.POST("/verify-email/resend", h.ResendVerification)
+	auth.POST("/phone/send-code", h.SendPhoneCode)
+	auth.POST("/phone/login", h.PhoneLogin)
+	auth.POST("/phone/verify", middleware.Authenticate(), h.VerifyPhone)
+	auth.POST("/password/forgot", h.ForgotPassword)
+	auth.POST("/password/reset", h.ResetPassword)
+	auth.POST("/password/change", middleware.Authenticate(), h.ChangePassword)
+
+	sessions := auth.Group("/sessions", middleware.Authenticate())
+	sessions.GET("", h.ListSessions)
+	sessions.DELETE("/:id", h.RevokeSession)
+	sessions.POST("/revoke-others", h.RevokeOtherSessions)
+
+	oidc := auth.Group("/oidc")
+	oidc.GET("/providers", h.OIDCProviders)
+	oidc.GET("/:provider/start", h.StartOIDC)
+	oidc.GET("/:provider/callback", h.OIDCCallback)
+	oidc.POST("/:provider/callback", h.OIDCCallback)
+	oidc.POST("/:provider/link", middleware.Authenticate(), h.LinkOIDC)
+
+	identities := auth.Group("/identities", middleware.Authenticate())
+	identities.GET("", h.ListIdentities)
+	identities.DELETE("/:provider", h.UnlinkIdentity)
+
+	mfa := auth.Group("/mfa", middleware.Authenticate())
+	mfa.GET("", h.MFAStatus)
+	mfa.POST("/enroll", h.EnrollMFA)
+	mfa.POST("/confirm", h.ConfirmMFA)
+	mfa.POST("/disable", h.DisableMFA)
+	mfa.POST("/recovery-codes", h.RegenerateRecoveryCodes)
 	return router
 }
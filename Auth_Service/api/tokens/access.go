@@ -1,7 +1,6 @@
 package tokens
 
 import (
-	"Auth/config"
 	"log"
 	"time"
 
@@ -9,17 +8,31 @@ import (
 	"github.com/pkg/errors"
 )
 
-func GenerateAccessToken(id, email, role string) (string, error) {
-	token := *jwt.New(jwt.SigningMethodHS256)
+// AccessTokenTTL is how long an access token is valid. Revocations must be kept at
+// least this long.
+const AccessTokenTTL = 1 * time.Hour
 
-	claims := token.Claims.(jwt.MapClaims)
+func GenerateAccessToken(id, email, role, sessionID string) (string, error) {
+	keys, err := Keys()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load signing keys")
+	}
+
+	jti, err := NewID()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate access token")
+	}
+
+	claims := jwt.MapClaims{}
+	claims["jti"] = jti
 	claims["user_id"] = id
+	claims["sid"] = sessionID
 	claims["email"] = email
 	claims["role"] = role
 	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().Add(1 * time.Hour).Unix()
+	claims["exp"] = time.Now().Add(AccessTokenTTL).Unix()
 
-	newToken, err := token.SignedString([]byte(config.Load().ACCESS_TOKEN))
+	newToken, err := keys.Sign(claims)
 
 	if err != nil {
 		log.Println(err)
@@ -28,3 +41,30 @@ func GenerateAccessToken(id, email, role string) (string, error) {
 
 	return newToken, nil
 }
+
+func ExtractAccessClaims(tokenStr string) (jwt.MapClaims, error) {
+	keys, err := Keys()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load signing keys")
+	}
+
+	token, err := keys.Parse(tokenStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse access token")
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid access token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	// Challenge tokens are signed with the same keys but only finish a login.
+	if _, ok := claims["purpose"]; ok {
+		return nil, errors.New("not an access token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+// Purposes of challenge tokens. A challenge token proves that the password was
+// checked and is only good for finishing the login.
+const (
+	PurposeMFA       = "mfa"
+	PurposeMFAEnroll = "mfa_enroll"
+)
+
+func GenerateChallengeToken(userID, email, phone, device, purpose string, ttl time.Duration) (string, error) {
+	keys, err := Keys()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load signing keys")
+	}
+
+	jti, err := NewID()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate challenge token")
+	}
+
+	claims := jwt.MapClaims{}
+	claims["jti"] = jti
+	claims["purpose"] = purpose
+	claims["user_id"] = userID
+	claims["email"] = email
+	claims["phone"] = phone
+	claims["device"] = device
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = time.Now().Add(ttl).Unix()
+
+	token, err := keys.Sign(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate challenge token")
+	}
+	return token, nil
+}
+
+// ExtractChallengeClaims parses a challenge token issued for purpose.
+func ExtractChallengeClaims(tokenStr, purpose string) (jwt.MapClaims, error) {
+	keys, err := Keys()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load signing keys")
+	}
+
+	token, err := keys.Parse(tokenStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse challenge token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid challenge token")
+	}
+	if p, _ := claims["purpose"].(string); p != purpose {
+		return nil, errors.New("invalid challenge token")
+	}
+	if id, _ := claims["user_id"].(string); id == "" {
+		return nil, errors.New("invalid challenge token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// NewID returns a random RFC 4122 version 4 UUID. It is used as the jti of
+// issued tokens and as the family ID of a refresh-token chain.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate id")
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+var idPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidID reports whether id is a UUID, the format of IDs made by NewID.
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidID(t *testing.T) {
+	id, err := NewID()
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{id, true},
+		{"0F8FAD5B-D9CB-469F-A165-70867728950E", true},
+		{"", false},
+		{"current", false},
+		{"0f8fad5b-d9cb-469f-a165-70867728950", false},
+		{"0f8fad5b-d9cb-469f-a165-70867728950e' or '1'='1", false},
+		{"0f8fad5bd9cb469fa16570867728950e", false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.valid, ValidID(tt.id), tt.id)
+	}
+}
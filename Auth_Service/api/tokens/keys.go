@@ -0,0 +1,224 @@
+package tokens
+
+import (
+	"Auth/config"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+// signingKey is a private key together with its key ID and JWT signing method.
+type signingKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+// KeySet holds every key access tokens may be signed with. Only the active key
+// signs new tokens; the others are kept so that tokens they signed stay valid
+// until they expire, which lets keys be rotated without downtime.
+type KeySet struct {
+	active string
+	keys   map[string]*signingKey
+}
+
+// JWK is the public part of a signing key in RFC 7517 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	keysOnce sync.Once
+	keySet   *KeySet
+	keysErr  error
+)
+
+// Keys returns the key set configured by JWT_KEYS_DIR and JWT_ACTIVE_KID. It is
+// loaded on first use and shared afterwards.
+func Keys() (*KeySet, error) {
+	keysOnce.Do(func() {
+		cfg := config.Load()
+		keySet, keysErr = LoadKeySet(cfg.JWT_KEYS_DIR, cfg.JWT_ACTIVE_KID, cfg.Dev())
+	})
+	return keySet, keysErr
+}
+
+// LoadKeySet reads every <kid>.pem private key (RSA or Ed25519, PKCS#8 or PKCS#1)
+// from dir. When dir holds no keys and generate is set, a new Ed25519 key is generated
+// and saved there; otherwise an empty dir is an error, since a key that does not
+// survive a restart logs every user out. An empty activeKID selects the key whose ID
+// sorts last, so date-named keys roll over by simply adding a newer file.
+func LoadKeySet(dir, activeKID string, generate bool) (*KeySet, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create keys directory")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list keys")
+	}
+	if len(files) == 0 {
+		if !generate {
+			return nil, errors.Errorf("no signing keys in %s; add a <kid>.pem key or set APP_ENV=development to generate one", dir)
+		}
+		file, err := generateKey(dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	set := &KeySet{keys: make(map[string]*signingKey)}
+	var kids []string
+	for _, file := range files {
+		key, err := readKey(file)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[key.kid] = key
+		kids = append(kids, key.kid)
+	}
+	sort.Strings(kids)
+
+	set.active = activeKID
+	if set.active == "" {
+		set.active = kids[len(kids)-1]
+	}
+	if _, ok := set.keys[set.active]; !ok {
+		return nil, errors.Errorf("active signing key %q not found", set.active)
+	}
+
+	return set, nil
+}
+
+// Sign signs the claims with the active key and sets the kid header.
+func (k *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	key := k.keys[k.active]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+
+	return token.SignedString(key.key)
+}
+
+// Parse verifies the token against the key named by its kid header.
+func (k *KeySet) Parse(tokenStr string) (*jwt.Token, error) {
+	return jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := k.keys[kid]
+		if !ok {
+			return nil, errors.Errorf("unknown signing key %q", kid)
+		}
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, errors.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return key.key.Public(), nil
+	})
+}
+
+// JWKS returns the public keys of the set, active key first.
+func (k *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		if kid != k.active {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(kids)))
+
+	for _, kid := range append([]string{k.active}, kids...) {
+		key := k.keys[kid]
+		jwk := JWK{Kid: kid, Use: "sig", Alg: key.method.Alg()}
+		switch pub := key.key.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func readKey(file string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read signing key")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("signing key %s is not PEM encoded", file)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse signing key %s", file)
+	}
+
+	key := &signingKey{kid: strings.TrimSuffix(filepath.Base(file), ".pem")}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.key = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.key = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, errors.Errorf("signing key %s must be RSA or Ed25519", file)
+	}
+
+	return key, nil
+}
+
+func generateKey(dir string) (string, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate signing key")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode signing key")
+	}
+
+	file := filepath.Join(dir, time.Now().UTC().Format("20060102150405")+".pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		return "", errors.Wrap(err, "failed to save signing key")
+	}
+
+	return file, nil
+}
@@ -0,0 +1,167 @@
+package tokens
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRSAKey(t *testing.T, dir, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+	return key
+}
+
+func writeEd25519Key(t *testing.T, dir, kid string) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+	return key
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": "user1", "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Run("NoKeys", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := LoadKeySet(dir, "", false)
+		require.ErrorContains(t, err, "no signing keys")
+	})
+
+	t.Run("GeneratedKeyIsKept", func(t *testing.T) {
+		dir := t.TempDir()
+		first, err := LoadKeySet(dir, "", true)
+		require.NoError(t, err)
+		files, _ := filepath.Glob(filepath.Join(dir, "*.pem"))
+		require.Len(t, files, 1)
+
+		second, err := LoadKeySet(dir, "", false)
+		require.NoError(t, err)
+		require.Equal(t, first.JWKS(), second.JWKS(), "a restart must load the same key")
+	})
+
+	t.Run("ActiveSortsLast", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRSAKey(t, dir, "2024-01")
+		writeEd25519Key(t, dir, "2024-02")
+		set, err := LoadKeySet(dir, "", false)
+		require.NoError(t, err)
+		require.Equal(t, "2024-02", set.active)
+	})
+
+	t.Run("UnknownActive", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEd25519Key(t, dir, "2024-01")
+		_, err := LoadKeySet(dir, "2023-12", false)
+		require.ErrorContains(t, err, `active signing key "2023-12" not found`)
+	})
+}
+
+func TestSignParse(t *testing.T) {
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "2024-01")
+	other := writeEd25519Key(t, dir, "2024-02")
+
+	old, err := LoadKeySet(dir, "2024-01", false)
+	require.NoError(t, err)
+	oldToken, err := old.Sign(testClaims())
+	require.NoError(t, err)
+
+	set, err := LoadKeySet(dir, "", false)
+	require.NoError(t, err)
+	newToken, err := set.Sign(testClaims())
+	require.NoError(t, err)
+
+	// Signed by the given key but naming another kid or an unknown one.
+	swapped := jwt.NewWithClaims(jwt.SigningMethodEdDSA, testClaims())
+	swapped.Header["kid"] = "2024-01"
+	swappedToken, err := swapped.SignedString(other)
+	require.NoError(t, err)
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodEdDSA, testClaims())
+	unknown.Header["kid"] = "2023-12"
+	unknownToken, err := unknown.SignedString(other)
+	require.NoError(t, err)
+
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	hmac.Header["kid"] = "2024-02"
+	hmacToken, err := hmac.SignedString([]byte(other.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		kid     string
+		errText string
+	}{
+		{name: "ActiveKey", token: newToken, kid: "2024-02"},
+		{name: "RotatedOutKey", token: oldToken, kid: "2024-01"},
+		{name: "WrongKid", token: swappedToken, errText: "unexpected signing method"},
+		{name: "UnknownKid", token: unknownToken, errText: `unknown signing key "2023-12"`},
+		{name: "HMACWithPublicKey", token: hmacToken, errText: "unexpected signing method"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := set.Parse(tt.token)
+			if tt.errText != "" {
+				require.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, token.Valid)
+			require.Equal(t, tt.kid, token.Header["kid"])
+			require.Equal(t, "user1", token.Claims.(jwt.MapClaims)["user_id"])
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey := writeRSAKey(t, dir, "2024-01")
+	edKey := writeEd25519Key(t, dir, "2024-02")
+	writeEd25519Key(t, dir, "2024-03")
+
+	set, err := LoadKeySet(dir, "2024-02", false)
+	require.NoError(t, err)
+
+	jwks := set.JWKS()
+	require.Len(t, jwks.Keys, 3)
+	require.Equal(t, []string{"2024-02", "2024-03", "2024-01"},
+		[]string{jwks.Keys[0].Kid, jwks.Keys[1].Kid, jwks.Keys[2].Kid}, "active key first, then newest")
+
+	require.Equal(t, JWK{
+		Kty: "OKP",
+		Kid: "2024-02",
+		Use: "sig",
+		Alg: "EdDSA",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey)),
+	}, jwks.Keys[0])
+
+	require.Equal(t, JWK{
+		Kty: "RSA",
+		Kid: "2024-01",
+		Use: "sig",
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		E:   "AQAB",
+	}, jwks.Keys[2])
+}
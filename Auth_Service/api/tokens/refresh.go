@@ -10,9 +10,15 @@ import (
 )
 
 func GenerateRefreshToken(userID string) (string, error) {
+	jti, err := NewID()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate refresh token")
+	}
+
 	token := *jwt.New(jwt.SigningMethodHS256)
 
 	claims := token.Claims.(jwt.MapClaims)
+	claims["jti"] = jti
 	claims["user_id"] = userID
 	claims["iat"] = time.Now().Unix()
 	claims["exp"] = time.Now().Add(24 * time.Hour).Unix()
@@ -2,11 +2,14 @@ package main
 
 import (
 	"Auth/api"
+	"Auth/api/tokens"
 	"Auth/config"
 	pbu "Auth/genproto/users"
+	"Auth/pkg/mailer"
 	"Auth/service"
 	"Auth/storage"
 	"Auth/storage/postgres"
+	"context"
 	"log"
 	"net"
 	"sync"
@@ -22,6 +25,12 @@ func main() {
 	defer db.Close()
 	cfg := config.Load()
 
+	if _, err := tokens.Keys(); err != nil {
+		log.Fatalf("error while loading signing keys: %v", err)
+	}
+
+	go service.NewTokenService(db).RunCleanup(context.Background(), cfg.TOKEN_CLEANUP_INTERVAL)
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 
@@ -40,11 +49,18 @@ func RunService(wg *sync.WaitGroup, s storage.IStorage, cfg *config.Config) {
 	}
 	defer lis.Close()
 
+	m, err := mailer.New(cfg)
+	if err != nil {
+		log.Fatalf("error while creating mailer: %v", err)
+	}
+
 	u := service.NewUserService(s)
 	a := service.NewAdminService(s)
+	o := service.NewOrganizationService(s, m, cfg)
 	server := grpc.NewServer()
 	pbu.RegisterAuthServiceServer(server, u)
 	pbu.RegisterAdminServer(server, a)
+	pbu.RegisterOrganizationServer(server, o)
 
 	log.Printf("Service is listening on port %s...\n", cfg.AUTH_SERVICE_PORT)
 	if err := server.Serve(lis); err != nil {
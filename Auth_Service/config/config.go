@@ -3,12 +3,26 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 )
 
+// OIDCProvider is an OpenID Connect issuer users can sign in with.
+type OIDCProvider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
 type Config struct {
+	// APP_ENV is "production" unless set; "development" allows conveniences that are
+	// unsafe in production, like generating a signing key on startup.
+	APP_ENV           string
 	AUTH_SERVICE_PORT string
 	AUTH_ROUTER_PORT  string
 	DB_HOST           string
@@ -16,8 +30,56 @@ type Config struct {
 	DB_USER           string
 	DB_NAME           string
 	DB_PASSWORD       string
-	ACCESS_TOKEN      string
 	REFRESH_TOKEN     string
+	JWT_KEYS_DIR      string
+	JWT_ACTIVE_KID    string
+	REDIS_ADDR        string
+	REDIS_PASSWORD    string
+	REDIS_DB          int
+
+	MAILER         string
+	SMTP_HOST      string
+	SMTP_PORT      int
+	SMTP_USER      string
+	SMTP_PASSWORD  string
+	MAIL_FROM      string
+	MAIL_FILE      string
+	VERIFY_URL     string
+	EMAIL_CODE_TTL time.Duration
+
+	RESET_URL       string
+	RESET_TOKEN_TTL time.Duration
+
+	INVITE_URL       string
+	INVITE_TOKEN_TTL time.Duration
+
+	LOGIN_FAILURE_WINDOW  time.Duration
+	LOGIN_DELAY_AFTER     int
+	LOGIN_MAX_DELAY       time.Duration
+	LOGIN_ACCOUNT_LOCK_AT int
+	LOGIN_IP_LOCK_AT      int
+	LOGIN_LOCKOUT         time.Duration
+
+	MFA_ISSUER         string
+	MFA_REQUIRED_ROLES string
+	MFA_CHALLENGE_TTL  time.Duration
+
+	SMS_SENDER                  string
+	PHONE_CODE_TTL              time.Duration
+	PHONE_RESEND_INTERVAL       time.Duration
+	PHONE_MAX_CODES_PER_HOUR    int
+	PHONE_IP_MAX_CODES_PER_HOUR int
+
+	// OIDC_PROVIDERS is keyed by the provider name used in URLs, e.g. "google".
+	OIDC_PROVIDERS map[string]OIDCProvider
+	OIDC_STATE_TTL time.Duration
+
+	TOKEN_CLEANUP_INTERVAL time.Duration
+}
+
+// Dev reports whether the service runs in development mode.
+func (c *Config) Dev() bool {
+	return c.APP_ENV == "development"
 }
 
 func coalesce(env string, defaultValue interface{}) interface{} {
@@ -36,6 +98,7 @@ func Load() *Config {
 
 	cfg := Config{}
 
+	cfg.APP_ENV = cast.ToString(coalesce("APP_ENV", "production"))
 	cfg.AUTH_ROUTER_PORT = cast.ToString(coalesce("AUTH_ROUTER_PORT", ":8081"))
 	cfg.AUTH_SERVICE_PORT = cast.ToString(coalesce("AUTH_SERVICE_PORT", ":50051"))
 	cfg.DB_HOST = cast.ToString(coalesce("DB_HOST", "localhost"))
@@ -43,8 +106,67 @@ func Load() *Config {
 	cfg.DB_USER = cast.ToString(coalesce("DB_USER", "postgres"))
 	cfg.DB_NAME = cast.ToString(coalesce("DB_NAME", "auth_i"))
 	cfg.DB_PASSWORD = cast.ToString(coalesce("DB_PASSWORD", "123321"))
-	cfg.ACCESS_TOKEN = cast.ToString(coalesce("ACCESS_TOKEN", "access_key"))
 	cfg.REFRESH_TOKEN = cast.ToString(coalesce("REFRESH_TOKEN", "refresh_key"))
+	cfg.JWT_KEYS_DIR = cast.ToString(coalesce("JWT_KEYS_DIR", "keys"))
+	cfg.JWT_ACTIVE_KID = cast.ToString(coalesce("JWT_ACTIVE_KID", ""))
+
+	cfg.REDIS_ADDR = cast.ToString(coalesce("REDIS_ADDR", "redis:6379"))
+	cfg.REDIS_PASSWORD = cast.ToString(coalesce("REDIS_PASSWORD", ""))
+	cfg.REDIS_DB = cast.ToInt(coalesce("REDIS_DB", 0))
+
+	cfg.MAILER = cast.ToString(coalesce("MAILER", "file"))
+	cfg.SMTP_HOST = cast.ToString(coalesce("SMTP_HOST", "localhost"))
+	cfg.SMTP_PORT = cast.ToInt(coalesce("SMTP_PORT", 587))
+	cfg.SMTP_USER = cast.ToString(coalesce("SMTP_USER", ""))
+	cfg.SMTP_PASSWORD = cast.ToString(coalesce("SMTP_PASSWORD", ""))
+	cfg.MAIL_FROM = cast.ToString(coalesce("MAIL_FROM", "no-reply@carwash.local"))
+	cfg.MAIL_FILE = cast.ToString(coalesce("MAIL_FILE", "mail.log"))
+	cfg.VERIFY_URL = cast.ToString(coalesce("VERIFY_URL", "http://localhost:8081/auth/verify-email"))
+	cfg.EMAIL_CODE_TTL = cast.ToDuration(coalesce("EMAIL_CODE_TTL", "24h"))
+
+	cfg.RESET_URL = cast.ToString(coalesce("RESET_URL", "http://localhost:3000/reset-password"))
+	cfg.RESET_TOKEN_TTL = cast.ToDuration(coalesce("RESET_TOKEN_TTL", "1h"))
+
+	cfg.INVITE_URL = cast.ToString(coalesce("INVITE_URL", "http://localhost:3000/organizations/join"))
+	cfg.INVITE_TOKEN_TTL = cast.ToDuration(coalesce("INVITE_TOKEN_TTL", "168h"))
+
+	cfg.LOGIN_FAILURE_WINDOW = cast.ToDuration(coalesce("LOGIN_FAILURE_WINDOW", "15m"))
+	cfg.LOGIN_DELAY_AFTER = cast.ToInt(coalesce("LOGIN_DELAY_AFTER", 3))
+	cfg.LOGIN_MAX_DELAY = cast.ToDuration(coalesce("LOGIN_MAX_DELAY", "1m"))
+	cfg.LOGIN_ACCOUNT_LOCK_AT = cast.ToInt(coalesce("LOGIN_ACCOUNT_LOCK_AT", 10))
+	cfg.LOGIN_IP_LOCK_AT = cast.ToInt(coalesce("LOGIN_IP_LOCK_AT", 50))
+	cfg.LOGIN_LOCKOUT = cast.ToDuration(coalesce("LOGIN_LOCKOUT", "15m"))
+
+	cfg.MFA_ISSUER = cast.ToString(coalesce("MFA_ISSUER", "On-demand Car Wash"))
+	cfg.MFA_REQUIRED_ROLES = cast.ToString(coalesce("MFA_REQUIRED_ROLES", "admin"))
+	cfg.MFA_CHALLENGE_TTL = cast.ToDuration(coalesce("MFA_CHALLENGE_TTL", "5m"))
+
+	cfg.SMS_SENDER = cast.ToString(coalesce("SMS_SENDER", "log"))
+	cfg.PHONE_CODE_TTL = cast.ToDuration(coalesce("PHONE_CODE_TTL", "5m"))
+	cfg.PHONE_RESEND_INTERVAL = cast.ToDuration(coalesce("PHONE_RESEND_INTERVAL", "1m"))
+	cfg.PHONE_MAX_CODES_PER_HOUR = cast.ToInt(coalesce("PHONE_MAX_CODES_PER_HOUR", 5))
+	cfg.PHONE_IP_MAX_CODES_PER_HOUR = cast.ToInt(coalesce("PHONE_IP_MAX_CODES_PER_HOUR", 20))
+
+	// Every name in OIDC_PROVIDERS is configured by OIDC_<NAME>_ISSUER, _CLIENT_ID,
+	// _CLIENT_SECRET, _REDIRECT_URL and _SCOPES.
+	cfg.OIDC_PROVIDERS = map[string]OIDCProvider{}
+	for _, name := range strings.Split(cast.ToString(coalesce("OIDC_PROVIDERS", "")), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		cfg.OIDC_PROVIDERS[name] = OIDCProvider{
+			Issuer:       cast.ToString(coalesce(prefix+"ISSUER", "")),
+			ClientID:     cast.ToString(coalesce(prefix+"CLIENT_ID", "")),
+			ClientSecret: cast.ToString(coalesce(prefix+"CLIENT_SECRET", "")),
+			RedirectURL:  cast.ToString(coalesce(prefix+"REDIRECT_URL", "")),
+			Scopes:       strings.Fields(cast.ToString(coalesce(prefix+"SCOPES", "openid email profile"))),
+		}
+	}
+	cfg.OIDC_STATE_TTL = cast.ToDuration(coalesce("OIDC_STATE_TTL", "10m"))
+
+	cfg.TOKEN_CLEANUP_INTERVAL = cast.ToDuration(coalesce("TOKEN_CLEANUP_INTERVAL", "1h"))
 
 	return &cfg
 }
@@ -0,0 +1,8 @@
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id   UUID NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN replaced_by UUID,
+    ADD COLUMN revoked_at  TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS refresh_tokens_token_idx ON refresh_tokens (token);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_idx ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_expires_idx ON refresh_tokens (expires_at);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS sessions (
+                                        id UUID PRIMARY KEY,
+                                        user_id UUID NOT NULL,
+                                        device VARCHAR(100),
+                                        user_agent TEXT,
+                                        ip VARCHAR(45),
+                                        created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                        last_used_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                        revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_idx ON sessions (user_id);
+
+-- Every refresh-token family issued before sessions existed becomes a session of its own.
+INSERT INTO sessions (id, user_id, created_at, last_used_at)
+SELECT family_id, user_id, min(created_at), max(created_at)
+FROM refresh_tokens
+GROUP BY family_id, user_id
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT false;
+
+-- Accounts created before verification existed keep working.
+UPDATE users SET email_verified = true;
+
+CREATE TABLE IF NOT EXISTS email_verifications (
+                                                   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                   user_id UUID NOT NULL,
+                                                   code_hash VARCHAR(64) NOT NULL,
+                                                   attempts INT NOT NULL DEFAULT 0,
+                                                   expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                                   used_at TIMESTAMP WITH TIME ZONE,
+                                                   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS email_verifications_user_idx ON email_verifications (user_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS password_resets (
+                                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                               user_id UUID NOT NULL,
+                                               token_hash VARCHAR(64) UNIQUE NOT NULL,
+                                               expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                               used_at TIMESTAMP WITH TIME ZONE,
+                                               created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS password_resets_user_idx ON password_resets (user_id);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS login_failures (
+                                              key VARCHAR(320) PRIMARY KEY, -- "account:<email>" or "ip:<address>"
+                                              failures INT NOT NULL DEFAULT 0,
+                                              last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                              locked_until TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS security_events (
+                                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                               type VARCHAR(50) NOT NULL,
+                                               user_id UUID,
+                                               email VARCHAR(255),
+                                               ip VARCHAR(45),
+                                               user_agent TEXT,
+                                               details TEXT,
+                                               created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS security_events_created_idx ON security_events (created_at DESC);
+CREATE INDEX IF NOT EXISTS security_events_user_idx ON security_events (user_id);
+CREATE INDEX IF NOT EXISTS security_events_ip_idx ON security_events (ip);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS user_mfa (
+                                        user_id UUID PRIMARY KEY,
+                                        secret VARCHAR(64) NOT NULL,
+                                        enabled BOOLEAN NOT NULL DEFAULT false,
+                                        last_used_step BIGINT NOT NULL DEFAULT 0, -- codes of this or earlier time steps are rejected
+                                        created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                        enabled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+                                                  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                  user_id UUID NOT NULL,
+                                                  code_hash VARCHAR(64) NOT NULL,
+                                                  used_at TIMESTAMP WITH TIME ZONE,
+                                                  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS mfa_recovery_codes_user_idx ON mfa_recovery_codes (user_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS phone_otps;
+DROP INDEX IF EXISTS users_verified_phone_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_verified;
+DELETE FROM users WHERE email IS NULL;
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
+ALTER TABLE users ALTER COLUMN email SET NOT NULL;
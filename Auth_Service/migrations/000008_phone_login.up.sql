@@ -0,0 +1,21 @@
+-- Customers who sign up by phone have neither an email nor a password.
+ALTER TABLE users ALTER COLUMN email DROP NOT NULL;
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+ALTER TABLE users ADD COLUMN phone_verified BOOLEAN NOT NULL DEFAULT false;
+
+-- A phone number can be verified on one live account only.
+CREATE UNIQUE INDEX IF NOT EXISTS users_verified_phone_idx ON users (phone_number) WHERE phone_verified AND deleted_at = 0;
+
+CREATE TABLE IF NOT EXISTS phone_otps (
+                                          id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                          phone VARCHAR(20) NOT NULL,
+                                          code_hash VARCHAR(64) NOT NULL,
+                                          ip VARCHAR(45),
+                                          attempts INT NOT NULL DEFAULT 0,
+                                          expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                          used_at TIMESTAMP WITH TIME ZONE,
+                                          created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS phone_otps_phone_idx ON phone_otps (phone, created_at DESC);
+CREATE INDEX IF NOT EXISTS phone_otps_ip_idx ON phone_otps (ip, created_at DESC);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+                                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                               user_id UUID NOT NULL,
+                                               provider VARCHAR(50) NOT NULL,
+                                               subject VARCHAR(255) NOT NULL,
+                                               email VARCHAR(255),
+                                               created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                               UNIQUE (provider, subject),
+                                               UNIQUE (user_id, provider)
+);
+
+-- Pending sign-ins, from redirecting to the issuer until its callback.
+CREATE TABLE IF NOT EXISTS oidc_states (
+                                           state_hash VARCHAR(64) PRIMARY KEY,
+                                           provider VARCHAR(50) NOT NULL,
+                                           nonce VARCHAR(64) NOT NULL,
+                                           code_verifier VARCHAR(128) NOT NULL,
+                                           user_id UUID, -- set when linking to a logged-in user
+                                           device VARCHAR(100),
+                                           expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                           created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
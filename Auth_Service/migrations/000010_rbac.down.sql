@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS casbin_seeds;
+DROP TABLE IF EXISTS casbin_rule;
+DROP TABLE IF EXISTS roles;
+
+UPDATE users SET role = 'customer' WHERE role NOT IN ('customer', 'provider', 'admin');
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'provider', 'admin'));
@@ -0,0 +1,38 @@
+-- Roles are managed at runtime, so users.role is no longer limited to a fixed list.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+
+CREATE TABLE IF NOT EXISTS roles (
+                                     name VARCHAR(20) PRIMARY KEY,
+                                     description TEXT NOT NULL DEFAULT '',
+                                     built_in BOOLEAN NOT NULL DEFAULT FALSE, -- built-in roles cannot be deleted
+                                     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO roles (name, description, built_in)
+VALUES
+    ('customer', 'Books and pays for washes', TRUE),
+    ('provider', 'Runs a car wash business and serves bookings', TRUE),
+    ('admin', 'Full access to the admin API', TRUE),
+    ('support', 'Read-only access to users, bookings and payments for support staff', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Casbin policies of the api-gateway: p rows grant a role (v0) a path (v1) and method (v2),
+-- g rows give a user (v0) an additional role (v1).
+CREATE TABLE IF NOT EXISTS casbin_rule (
+                                           id SERIAL PRIMARY KEY,
+                                           ptype VARCHAR(10) NOT NULL,
+                                           v0 VARCHAR(255) NOT NULL DEFAULT '',
+                                           v1 VARCHAR(255) NOT NULL DEFAULT '',
+                                           v2 VARCHAR(255) NOT NULL DEFAULT '',
+                                           v3 VARCHAR(255) NOT NULL DEFAULT '',
+                                           v4 VARCHAR(255) NOT NULL DEFAULT '',
+                                           v5 VARCHAR(255) NOT NULL DEFAULT '',
+                                           UNIQUE (ptype, v0, v1, v2, v3, v4, v5)
+);
+
+-- Default rules of config/policy.csv that the gateway already applied once. A default
+-- an admin revoked afterwards is not granted again on the next start.
+CREATE TABLE IF NOT EXISTS casbin_seeds (
+                                            rule TEXT PRIMARY KEY,
+                                            applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,33 @@
+-- Organizations (fleets) book and pay for washes of many cars under one account.
+CREATE TABLE IF NOT EXISTS organizations (
+                                             id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                             name VARCHAR(100) NOT NULL,
+                                             billing_email VARCHAR(255),
+                                             created_by UUID NOT NULL,
+                                             created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                             updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+                                                    organization_id UUID NOT NULL,
+                                                    user_id UUID NOT NULL,
+                                                    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'driver')),
+                                                    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                                    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS organization_members_user_idx ON organization_members (user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+                                                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                        organization_id UUID NOT NULL,
+                                                        email VARCHAR(255) NOT NULL,
+                                                        role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'driver')),
+                                                        token_hash VARCHAR(64) UNIQUE NOT NULL,
+                                                        invited_by UUID NOT NULL,
+                                                        expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                                        accepted_at TIMESTAMP WITH TIME ZONE,
+                                                        created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS organization_invitations_org_idx ON organization_invitations (organization_id);
@@ -0,0 +1,6 @@
+-- users.role must name a role of the roles table. Profiles with a role that was never
+-- created fall back to customer first, as the users_role_check constraint did.
+UPDATE users SET role = 'customer' WHERE role NOT IN (SELECT name FROM roles);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (name) ON UPDATE CASCADE;
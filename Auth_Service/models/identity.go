@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Identity is an account at an external OpenID Connect provider linked to a user.
+type Identity struct {
+	Provider  string `json:"provider"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+}
+
+type Identities struct {
+	Identities []Identity `json:"identities"`
+}
+
+// OIDCState is a pending sign-in at a provider.
+type OIDCState struct {
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	// UserID is set when a logged-in user links the provider.
+	UserID    string
+	Device    string
+	ExpiresAt time.Time
+}
+
+type OIDCStart struct {
+	// AuthorizationURL is where the user signs in at the provider.
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OIDCCallbackRequest is what the provider sends back to the redirect URL. Apps that
+// catch the redirect themselves can post it as JSON.
+type OIDCCallbackRequest struct {
+	Code             string `json:"code" form:"code"`
+	State            string `json:"state" form:"state"`
+	Error            string `json:"error" form:"error"`
+	ErrorDescription string `json:"error_description" form:"error_description"`
+}
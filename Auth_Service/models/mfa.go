@@ -0,0 +1,61 @@
+package models
+
+// MFA is the TOTP enrollment of a user. It is pending until the first code is
+// confirmed.
+type MFA struct {
+	UserID       string
+	Secret       string
+	Enabled      bool
+	LastUsedStep int64
+}
+
+type MFAStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is set when the user's role must use MFA.
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	// URI is the otpauth:// provisioning URI to show as a QR code.
+	URI string `json:"uri"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge is returned by login instead of tokens when a second factor is needed.
+type MFAChallenge struct {
+	// Type is "mfa" when a code has to be entered and "mfa_enroll" when the account
+	// must enroll first.
+	Type           string `json:"type"`
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
+
+type MFALoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+// MFAEnrollLoginResponse completes a login that required enrolling MFA.
+type MFAEnrollLoginResponse struct {
+	Tokens        Tokens   `json:"tokens"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
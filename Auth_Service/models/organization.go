@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Organization member roles.
+const (
+	OrgOwner   = "owner"
+	OrgManager = "manager"
+	OrgDriver  = "driver"
+)
+
+// Invitation is a pending invitation to join an organization.
+type Invitation struct {
+	OrganizationID string
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      string
+	ExpiresAt      time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+type PhoneCodeRequest struct {
+	Phone string `json:"phone"`
+}
+
+type PhoneLoginRequest struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+	// FirstName and LastName are used when the phone has no account yet.
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// Device is a label the client picks for the session, e.g. "iPhone" or "Chrome on Windows".
+	Device string `json:"device"`
+}
+
+type PhoneVerifyRequest struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// LoginFailure counts recent failed logins of one account or IP address.
+type LoginFailure struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+const (
+	EventLoginFailed    = "login_failed"
+	EventLoginSucceeded = "login_succeeded"
+	EventLoginBlocked   = "login_blocked"
+	EventAccountLocked  = "account_locked"
+	EventIPLocked       = "ip_locked"
+)
@@ -0,0 +1,22 @@
+package models
+
+type Session struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	Device     string `json:"device"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	Current    bool   `json:"current"`
+}
+
+type Sessions struct {
+	Sessions []Session `json:"sessions"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	// All ends every session of the user instead of only the one the token belongs to.
+	All bool `json:"all"`
+}
@@ -18,6 +18,8 @@ type User struct {
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Device is a label the client picks for the session, e.g. "iPhone" or "Chrome on Windows".
+	Device string `json:"device"`
 }
 
 type AccessToken struct {
@@ -34,7 +36,27 @@ type Tokens struct {
 }
 
 type RefreshTokenDetails struct {
-	UserID string `json:"user_id"`
-	Token  string `json:"token"`
-	Expiry string `json:"expiry"`
+	UserID   string `json:"user_id"`
+	Token    string `json:"token"`
+	Expiry   string `json:"expiry"`
+	FamilyID string `json:"family_id"`
+}
+
+type VerifyEmailRequest struct {
+	Email string `json:"email" form:"email"`
+	Code  string `json:"code" form:"code"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
 }
@@ -0,0 +1,209 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// issuer is a minimal OpenID provider serving discovery, JWKS and a token endpoint
+// that answers every code with idToken.
+type issuer struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	idToken string
+	form    url.Values
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss := &issuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                iss.URL,
+			AuthorizationEndpoint: iss.URL + "/authorize",
+			TokenEndpoint:         iss.URL + "/token",
+			JWKSURI:               iss.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string][]jwk{"keys": {
+			{Kty: "RSA", Kid: "enc", Use: "enc", N: "AQAB", E: "AQAB"},
+			{
+				Kty: "RSA",
+				Kid: "key1",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		iss.form = r.PostForm
+		if r.PostForm.Get("code") != "code1" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "unknown code"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": iss.idToken})
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (i *issuer) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            i.URL,
+		"aud":            "client1",
+		"sub":            "subject1",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          "nonce1",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"given_name":     "Ali",
+		"family_name":    "Valiyev",
+	}
+}
+
+func (i *issuer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(i.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (i *issuer) client() *Client {
+	return NewClient(Config{Issuer: i.URL, ClientID: "client1", RedirectURL: "http://localhost/callback", Scopes: []string{"openid", "email"}})
+}
+
+func TestVerify(t *testing.T) {
+	iss := newIssuer(t)
+
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := iss.claims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, iss.claims())
+	hmac.Header["kid"] = "key1"
+	hmacToken, err := hmac.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		errText string
+	}{
+		{name: "Valid", token: iss.sign(t, "key1", iss.claims())},
+		{name: "AudienceList", token: iss.sign(t, "key1", with("aud", []string{"other", "client1"}))},
+		{name: "OtherIssuer", token: iss.sign(t, "key1", with("iss", "https://evil.example.com")), errText: "id token issued by"},
+		{name: "OtherAudience", token: iss.sign(t, "key1", with("aud", "other")), errText: "not issued for this client"},
+		{name: "Expired", token: iss.sign(t, "key1", with("exp", time.Now().Add(-time.Minute).Unix())), errText: "expired"},
+		{name: "NoExpiry", token: iss.sign(t, "key1", with("exp", nil)), errText: "no expiry"},
+		{name: "OtherNonce", token: iss.sign(t, "key1", with("nonce", "nonce2")), errText: "nonce mismatch"},
+		{name: "NoNonce", token: iss.sign(t, "key1", with("nonce", nil)), errText: "nonce mismatch"},
+		{name: "NoSubject", token: iss.sign(t, "key1", with("sub", nil)), errText: "no subject"},
+		{name: "UnknownKid", token: iss.sign(t, "key2", iss.claims()), errText: `unknown key "key2"`},
+		{name: "EncryptionKey", token: iss.sign(t, "enc", iss.claims()), errText: `unknown key "enc"`},
+		{name: "HMAC", token: hmacToken, errText: "signing method HS256 is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := iss.client().Verify(context.Background(), tt.token, "nonce1")
+			if tt.errText != "" {
+				require.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &Claims{
+				Subject:       "subject1",
+				Email:         "user@example.com",
+				EmailVerified: true,
+				GivenName:     "Ali",
+				FamilyName:    "Valiyev",
+			}, claims)
+		})
+	}
+}
+
+func TestVerifyEmailVerifiedString(t *testing.T) {
+	iss := newIssuer(t)
+
+	for value, verified := range map[string]bool{"true": true, "false": false} {
+		claims := iss.claims()
+		claims["email_verified"] = value
+		out, err := iss.client().Verify(context.Background(), iss.sign(t, "key1", claims), "nonce1")
+		require.NoError(t, err)
+		require.Equal(t, verified, out.EmailVerified, value)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	iss := newIssuer(t)
+	iss.idToken = iss.sign(t, "key1", iss.claims())
+	client := iss.client()
+
+	claims, err := client.Exchange(context.Background(), "code1", "verifier1", "nonce1")
+	require.NoError(t, err)
+	require.Equal(t, "subject1", claims.Subject)
+	require.Equal(t, "verifier1", iss.form.Get("code_verifier"))
+	require.Equal(t, "client1", iss.form.Get("client_id"))
+	require.Equal(t, "http://localhost/callback", iss.form.Get("redirect_uri"))
+
+	_, err = client.Exchange(context.Background(), "code2", "verifier1", "nonce1")
+	require.EqualError(t, err, "token exchange failed: invalid_grant unknown code")
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	iss := newIssuer(t)
+
+	u, err := iss.client().AuthCodeURL(context.Background(), "state1", "nonce1", "verifier1", "user@example.com")
+	require.NoError(t, err)
+	parsed, err := url.Parse(u)
+	require.NoError(t, err)
+	require.Equal(t, iss.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	q := parsed.Query()
+	require.Equal(t, "code", q.Get("response_type"))
+	require.Equal(t, "openid email", q.Get("scope"))
+	require.Equal(t, "state1", q.Get("state"))
+	require.Equal(t, "nonce1", q.Get("nonce"))
+	require.Equal(t, Challenge("verifier1"), q.Get("code_challenge"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	require.Equal(t, "user@example.com", q.Get("login_hint"))
+}
+
+func TestChallenge(t *testing.T) {
+	// RFC 7636 appendix B.
+	require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	iss := newIssuer(t)
+
+	client := NewClient(Config{Issuer: iss.URL + "/", ClientID: "client1"})
+	_, err := client.Discover(context.Background())
+	require.ErrorContains(t, err, "does not match")
+}
@@ -0,0 +1,41 @@
+package sms
+
+import (
+	"Auth/config"
+	"Auth/pkg/logger"
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+)
+
+// Sender delivers text messages to phone numbers in E.164 format.
+type Sender interface {
+	Send(ctx context.Context, phone, message string) error
+}
+
+// New returns the sender selected by SMS_SENDER. Only "log" exists for now; a real
+// gateway is plugged in by implementing Sender.
+func New(cfg *config.Config) (Sender, error) {
+	switch cfg.SMS_SENDER {
+	case "log":
+		return NewLogSender(logger.NewLogger()), nil
+	default:
+		return nil, errors.Errorf("unknown sms sender %q", cfg.SMS_SENDER)
+	}
+}
+
+// LogSender writes messages to the log instead of sending them. It is meant for
+// local development.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, phone, message string) error {
+	s.logger.Info("SMS", "to", phone, "message", message)
+	return nil
+}
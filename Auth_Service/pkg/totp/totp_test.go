@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// RFC 6238 appendix B, truncated to 6 digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tt.code, code, "t=%d", tt.unix)
+	}
+
+	code, err := Code(strings.ToLower(rfcSecret), Step(time.Unix(59, 0)))
+	require.NoError(t, err)
+	require.Equal(t, "287082", code, "secrets are case insensitive")
+
+	_, err = Code("not base32!", 1)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		require.NoError(t, err)
+		return c
+	}
+
+	tests := []struct {
+		name string
+		code string
+		step int64
+		ok   bool
+	}{
+		{name: "Current", code: code(step), step: step, ok: true},
+		{name: "Previous", code: code(step - 1), step: step - 1, ok: true},
+		{name: "Next", code: code(step + 1), step: step + 1, ok: true},
+		{name: "Spaces", code: " " + code(step)[:3] + " " + code(step)[3:] + " ", step: step, ok: true},
+		{name: "TooOld", code: code(step - 2)},
+		{name: "TooNew", code: code(step + 2)},
+		{name: "Short", code: code(step)[:5]},
+		{name: "Empty", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Validate(rfcSecret, tt.code, now, 1)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.step, got)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	require.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	require.Equal(t,
+		"otpauth://totp/Home%20Services:user@example.com?algorithm=SHA1&digits=6&issuer=Home%20Services&period=30&secret="+rfcSecret,
+		URI("Home Services", "user@example.com", rfcSecret))
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"Auth/api/tokens"
 	pb "Auth/genproto/users"
 	"Auth/pkg/logger"
 	"Auth/storage"
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -37,6 +39,13 @@ func (a *AdminService) GetProfile(ctx context.Context, UserId *pb.Id) (*pb.GetPr
 }
 func (a *AdminService) UpdateProfileA(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserResponse, error) {
 	a.logger.Info("UpdateUserProfile is starting")
+	old, err := a.storage.Admin().GetProfile(ctx, &pb.Id{UserId: req.UserId})
+	if err != nil {
+		er := errors.Wrap(err, "failed to get profile")
+		a.logger.Error(er.Error())
+		return nil, er
+	}
+
 	res, err := a.storage.Admin().UpdateProfileA(ctx, req)
 	if err != nil {
 		er := errors.Wrap(err, "failed to update profile")
@@ -44,6 +53,11 @@ func (a *AdminService) UpdateProfileA(ctx context.Context, req *pb.UpdateProfile
 		return nil, er
 	}
 
+	if old.Role != res.Role {
+		// Access tokens carry the role, so the ones issued with the old role must stop working.
+		a.revokeUserTokens(ctx, res.Id)
+	}
+
 	a.logger.Info("UpdateUserProfile has finished")
 	return res, nil
 }
@@ -70,6 +84,11 @@ func (a *AdminService) DeleteUser(ctx context.Context, req *pb.Id) (*pb.Void, er
 		return nil, er
 	}
 
+	a.revokeUserTokens(ctx, req.UserId)
+	if _, err := a.storage.Session().RevokeAll(ctx, req.UserId); err != nil {
+		a.logger.Error("failed to revoke sessions of deleted user", "error", err)
+	}
+
 	a.logger.Info("DeleteUser is finished")
 	return &pb.Void{}, nil
 }
@@ -87,3 +106,27 @@ func (a *AdminService) FetchUsers(ctx context.Context, req *pb.Filter) (*pb.User
 	a.logger.Info("FetchUsers is finished")
 	return resp, nil
 }
+
+// ListSecurityEvents returns recorded login attempts, lockouts and other security
+// events, newest first.
+func (a *AdminService) ListSecurityEvents(ctx context.Context, req *pb.SecurityEventFilter) (*pb.SecurityEvents, error) {
+	a.logger.Info("ListSecurityEvents is starting")
+
+	resp, err := a.storage.SecurityEvent().List(ctx, req)
+	if err != nil {
+		er := errors.Wrap(err, "failed to list security events")
+		a.logger.Error(er.Error())
+		return nil, er
+	}
+
+	a.logger.Info("ListSecurityEvents is finished")
+	return resp, nil
+}
+
+// revokeUserTokens revokes every access token issued to the user so far.
+func (a *AdminService) revokeUserTokens(ctx context.Context, userID string) {
+	err := a.storage.Revocation().RevokeUser(ctx, userID, time.Now(), tokens.AccessTokenTTL)
+	if err != nil {
+		a.logger.Error("failed to revoke access tokens of user", "user_id", userID, "error", err)
+	}
+}
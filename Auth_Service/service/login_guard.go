@@ -0,0 +1,191 @@
+package service
+
+import (
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/storage"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// LoginAttempt describes who is trying to log in.
+type LoginAttempt struct {
+	Email string
+	// Phone is set instead of Email for phone logins.
+	Phone     string
+	UserID    string
+	IP        string
+	UserAgent string
+}
+
+// LoginGuard slows down and locks out repeated failed logins, per account and per
+// client IP, and records every attempt as a security event.
+type LoginGuard struct {
+	storage       storage.IStorage
+	logger        *slog.Logger
+	window        time.Duration
+	delayAfter    int
+	maxDelay      time.Duration
+	accountLockAt int
+	ipLockAt      int
+	lockout       time.Duration
+}
+
+func NewLoginGuard(s storage.IStorage, cfg *config.Config) *LoginGuard {
+	return &LoginGuard{
+		storage:       s,
+		logger:        logger.NewLogger(),
+		window:        cfg.LOGIN_FAILURE_WINDOW,
+		delayAfter:    cfg.LOGIN_DELAY_AFTER,
+		maxDelay:      cfg.LOGIN_MAX_DELAY,
+		accountLockAt: cfg.LOGIN_ACCOUNT_LOCK_AT,
+		ipLockAt:      cfg.LOGIN_IP_LOCK_AT,
+		lockout:       cfg.LOGIN_LOCKOUT,
+	}
+}
+
+// Check returns how long the client has to wait before it may try again, or zero
+// when the attempt is allowed. Blocked attempts are recorded as security events.
+func (g *LoginGuard) Check(ctx context.Context, a LoginAttempt) (time.Duration, error) {
+	g.logger.Info("LoginGuard Check is starting")
+
+	now := time.Now()
+	var wait time.Duration
+
+	account, err := g.storage.LoginFailure().Get(ctx, a.accountKey())
+	if err != nil {
+		er := errors.Wrap(err, "failed to get account login failures")
+		g.logger.Error(er.Error())
+		return 0, er
+	}
+	if account != nil {
+		wait = max(wait, account.LockedUntil.Sub(now))
+		if d := g.delay(account.Failures); d > 0 {
+			wait = max(wait, account.LastFailureAt.Add(d).Sub(now))
+		}
+	}
+
+	if a.IP != "" {
+		ip, err := g.storage.LoginFailure().Get(ctx, ipKey(a.IP))
+		if err != nil {
+			er := errors.Wrap(err, "failed to get IP login failures")
+			g.logger.Error(er.Error())
+			return 0, er
+		}
+		if ip != nil {
+			wait = max(wait, ip.LockedUntil.Sub(now))
+		}
+	}
+
+	if wait <= 0 {
+		wait = 0
+	} else {
+		g.record(ctx, models.EventLoginBlocked, a, fmt.Sprintf("retry after %s", wait.Round(time.Second)))
+	}
+
+	g.logger.Info("LoginGuard Check has finished")
+	return wait, nil
+}
+
+// Fail counts a failed attempt against the account and the IP and locks either of
+// them once it reaches its limit.
+func (g *LoginGuard) Fail(ctx context.Context, a LoginAttempt, reason string) {
+	g.logger.Info("LoginGuard Fail is starting")
+
+	g.record(ctx, models.EventLoginFailed, a, reason)
+
+	account, err := g.storage.LoginFailure().RecordFailure(ctx, a.accountKey(), g.window)
+	if err != nil {
+		g.logger.Error(errors.Wrap(err, "failed to record account login failure").Error())
+	} else if account.Failures >= g.accountLockAt && account.LockedUntil.IsZero() {
+		if err := g.storage.LoginFailure().Lock(ctx, account.Key, time.Now().Add(g.lockout)); err != nil {
+			g.logger.Error(errors.Wrap(err, "failed to lock account").Error())
+		} else {
+			g.record(ctx, models.EventAccountLocked, a, fmt.Sprintf("%d failed logins, locked for %s", account.Failures, g.lockout))
+		}
+	}
+
+	if a.IP == "" {
+		return
+	}
+	ip, err := g.storage.LoginFailure().RecordFailure(ctx, ipKey(a.IP), g.window)
+	if err != nil {
+		g.logger.Error(errors.Wrap(err, "failed to record IP login failure").Error())
+	} else if ip.Failures >= g.ipLockAt && ip.LockedUntil.IsZero() {
+		if err := g.storage.LoginFailure().Lock(ctx, ip.Key, time.Now().Add(g.lockout)); err != nil {
+			g.logger.Error(errors.Wrap(err, "failed to lock IP").Error())
+		} else {
+			g.record(ctx, models.EventIPLocked, a, fmt.Sprintf("%d failed logins, locked for %s", ip.Failures, g.lockout))
+		}
+	}
+
+	g.logger.Info("LoginGuard Fail has finished")
+}
+
+// Succeed clears the failures of the account. Failures of the IP are kept so that
+// one valid account cannot be used to reset the counter while guessing others.
+func (g *LoginGuard) Succeed(ctx context.Context, a LoginAttempt) {
+	g.logger.Info("LoginGuard Succeed is starting")
+
+	if err := g.storage.LoginFailure().Reset(ctx, a.accountKey()); err != nil {
+		g.logger.Error(errors.Wrap(err, "failed to reset account login failures").Error())
+	}
+	g.record(ctx, models.EventLoginSucceeded, a, "")
+
+	g.logger.Info("LoginGuard Succeed has finished")
+}
+
+// delay is how long an account has to wait after its n-th consecutive failure:
+// nothing at first, then one second doubling with every failure up to maxDelay.
+func (g *LoginGuard) delay(failures int) time.Duration {
+	if failures < g.delayAfter {
+		return 0
+	}
+	shift := failures - g.delayAfter
+	if shift > 30 {
+		return g.maxDelay
+	}
+	return min(time.Second<<shift, g.maxDelay)
+}
+
+func (g *LoginGuard) record(ctx context.Context, eventType string, a LoginAttempt, details string) {
+	if a.Phone != "" {
+		details = strings.TrimSpace("phone " + a.Phone + " " + details)
+	}
+	err := g.storage.SecurityEvent().Save(ctx, &pb.SecurityEvent{
+		Type:      eventType,
+		UserId:    a.UserID,
+		Email:     normalizeEmail(a.Email),
+		Ip:        a.IP,
+		UserAgent: a.UserAgent,
+		Details:   details,
+	})
+	if err != nil {
+		g.logger.Error(errors.Wrap(err, "failed to record security event").Error())
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// accountKey identifies the account being logged into by its email or phone.
+func (a LoginAttempt) accountKey() string {
+	if a.Phone != "" {
+		return "phone:" + a.Phone
+	}
+	return "account:" + normalizeEmail(a.Email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
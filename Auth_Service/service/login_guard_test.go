@@ -0,0 +1,191 @@
+package service
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/storage"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// guardStorage keeps login failures and security events in memory. Other
+// storages are left nil, the guard does not use them.
+type guardStorage struct {
+	storage.IStorage
+	failures map[string]*models.LoginFailure
+	events   []*pb.SecurityEvent
+}
+
+func newGuardStorage() *guardStorage {
+	return &guardStorage{failures: make(map[string]*models.LoginFailure)}
+}
+
+func (s *guardStorage) LoginFailure() storage.ILoginFailureStorage   { return (*loginFailures)(s) }
+func (s *guardStorage) SecurityEvent() storage.ISecurityEventStorage { return (*securityEvents)(s) }
+
+func (s *guardStorage) eventTypes() []string {
+	var types []string
+	for _, e := range s.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+type loginFailures guardStorage
+
+func (l *loginFailures) Get(ctx context.Context, key string) (*models.LoginFailure, error) {
+	if f, ok := l.failures[key]; ok {
+		copied := *f
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (l *loginFailures) RecordFailure(ctx context.Context, key string, window time.Duration) (*models.LoginFailure, error) {
+	f, ok := l.failures[key]
+	if !ok {
+		f = &models.LoginFailure{Key: key}
+		l.failures[key] = f
+	}
+	f.Failures++
+	f.LastFailureAt = time.Now()
+	copied := *f
+	return &copied, nil
+}
+
+func (l *loginFailures) Lock(ctx context.Context, key string, until time.Time) error {
+	l.failures[key].LockedUntil = until
+	return nil
+}
+
+func (l *loginFailures) Reset(ctx context.Context, key string) error {
+	delete(l.failures, key)
+	return nil
+}
+
+type securityEvents guardStorage
+
+func (s *securityEvents) Save(ctx context.Context, event *pb.SecurityEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *securityEvents) List(ctx context.Context, req *pb.SecurityEventFilter) (*pb.SecurityEvents, error) {
+	return &pb.SecurityEvents{Events: s.events}, nil
+}
+
+func newGuard(s storage.IStorage) *LoginGuard {
+	return &LoginGuard{
+		storage:       s,
+		logger:        logger.NewLogger(),
+		window:        15 * time.Minute,
+		delayAfter:    3,
+		maxDelay:      30 * time.Second,
+		accountLockAt: 5,
+		ipLockAt:      8,
+		lockout:       15 * time.Minute,
+	}
+}
+
+func TestLoginGuardDelay(t *testing.T) {
+	g := newGuard(nil)
+
+	tests := []struct {
+		failures int
+		delay    time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{6, 8 * time.Second},
+		{8, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.delay, g.delay(tt.failures), "%d failures", tt.failures)
+	}
+}
+
+func TestLoginGuardLocksAccount(t *testing.T) {
+	ctx := context.Background()
+	s := newGuardStorage()
+	g := newGuard(s)
+	attempt := LoginAttempt{Email: " User@Example.com ", IP: "10.0.0.1"}
+
+	wait, err := g.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	for i := 0; i < 2; i++ {
+		g.Fail(ctx, attempt, "wrong password")
+	}
+	wait, err = g.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.Zero(t, wait, "the first failures are not delayed")
+
+	g.Fail(ctx, attempt, "wrong password")
+	wait, err = g.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.InDelta(t, time.Second, wait, float64(100*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		g.Fail(ctx, attempt, "wrong password")
+	}
+	require.False(t, s.failures["account:user@example.com"].LockedUntil.IsZero())
+	require.Contains(t, s.eventTypes(), models.EventAccountLocked)
+	require.NotContains(t, s.eventTypes(), models.EventIPLocked)
+
+	// The lock follows the account to another IP and to another spelling of the email.
+	wait, err = g.Check(ctx, LoginAttempt{Email: "user@example.com", IP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.InDelta(t, 15*time.Minute, wait, float64(time.Second))
+	require.Equal(t, models.EventLoginBlocked, s.events[len(s.events)-1].Type)
+	require.Equal(t, "user@example.com", s.events[len(s.events)-1].Email)
+}
+
+func TestLoginGuardLocksIP(t *testing.T) {
+	ctx := context.Background()
+	s := newGuardStorage()
+	g := newGuard(s)
+
+	// Guessing across many accounts from one IP never reaches an account limit.
+	for i := 0; i < 8; i++ {
+		g.Fail(ctx, LoginAttempt{Email: string(rune('a'+i)) + "@example.com", IP: "10.0.0.1"}, "unknown email")
+	}
+	require.Contains(t, s.eventTypes(), models.EventIPLocked)
+	require.NotContains(t, s.eventTypes(), models.EventAccountLocked)
+
+	wait, err := g.Check(ctx, LoginAttempt{Email: "z@example.com", IP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.InDelta(t, 15*time.Minute, wait, float64(time.Second))
+
+	wait, err = g.Check(ctx, LoginAttempt{Email: "z@example.com", IP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestLoginGuardSucceed(t *testing.T) {
+	ctx := context.Background()
+	s := newGuardStorage()
+	g := newGuard(s)
+	attempt := LoginAttempt{Phone: "+998901234567", IP: "10.0.0.1"}
+
+	for i := 0; i < 4; i++ {
+		g.Fail(ctx, attempt, "wrong code")
+	}
+	require.Contains(t, s.failures, "phone:+998901234567")
+
+	g.Succeed(ctx, attempt)
+	require.NotContains(t, s.failures, "phone:+998901234567")
+	require.Equal(t, 4, s.failures["ip:10.0.0.1"].Failures, "IP failures survive a successful login")
+
+	last := s.events[len(s.events)-1]
+	require.Equal(t, models.EventLoginSucceeded, last.Type)
+	require.Equal(t, "phone +998901234567", last.Details)
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"Auth/api/tokens"
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/pkg/totp"
+	"Auth/storage"
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RecoveryCodeCount is how many recovery codes are issued at a time.
+const RecoveryCodeCount = 10
+
+var (
+	ErrMFANotEnrolled = errors.New("mfa is not enrolled")
+	ErrInvalidMFACode = errors.New("invalid authentication code")
+	ErrMFARequired    = errors.New("mfa is required for this role")
+)
+
+const (
+	EventMFAEnabled           = "mfa_enabled"
+	EventMFADisabled          = "mfa_disabled"
+	EventRecoveryCodeUsed     = "mfa_recovery_code_used"
+	EventRecoveryCodesRenewed = "mfa_recovery_codes_renewed"
+)
+
+type MFAService struct {
+	storage      storage.IStorage
+	logger       *slog.Logger
+	issuer       string
+	required     map[string]bool
+	challengeTTL time.Duration
+}
+
+func NewMFAService(s storage.IStorage, cfg *config.Config) *MFAService {
+	required := map[string]bool{}
+	for _, role := range strings.Split(cfg.MFA_REQUIRED_ROLES, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			required[role] = true
+		}
+	}
+
+	return &MFAService{
+		storage:      s,
+		logger:       logger.NewLogger(),
+		issuer:       cfg.MFA_ISSUER,
+		required:     required,
+		challengeTTL: cfg.MFA_CHALLENGE_TTL,
+	}
+}
+
+// Required reports whether users of the role must use MFA.
+func (m *MFAService) Required(role string) bool {
+	return m.required[role]
+}
+
+// Enabled reports whether the user has confirmed an MFA enrollment.
+func (m *MFAService) Enabled(ctx context.Context, userID string) (bool, error) {
+	mfa, err := m.storage.MFA().Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa != nil && mfa.Enabled, nil
+}
+
+func (m *MFAService) Status(ctx context.Context, userID string) (*models.MFAStatus, error) {
+	m.logger.Info("MFAStatus is starting")
+
+	_, role, err := m.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := m.Enabled(ctx, userID)
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	left, err := m.storage.MFA().CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+
+	m.logger.Info("MFAStatus has finished")
+	return &models.MFAStatus{Enabled: enabled, Required: m.Required(role), RecoveryCodesLeft: left}, nil
+}
+
+// Enroll creates a new secret for the user. MFA stays off until Confirm is called
+// with a code generated from it.
+func (m *MFAService) Enroll(ctx context.Context, userID string) (*models.MFAEnrollment, error) {
+	m.logger.Info("EnrollMFA is starting", "user_id", userID)
+
+	account, _, err := m.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	if err := m.storage.MFA().SavePending(ctx, userID, secret); err != nil {
+		m.logger.Error("failed to save mfa secret", "error", err)
+		return nil, err
+	}
+
+	m.logger.Info("EnrollMFA has finished", "user_id", userID)
+	return &models.MFAEnrollment{Secret: secret, URI: totp.URI(m.issuer, account, secret)}, nil
+}
+
+// Confirm enables MFA with the first code from the authenticator app and returns
+// the recovery codes. They are shown only this once.
+func (m *MFAService) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	m.logger.Info("ConfirmMFA is starting", "user_id", userID)
+
+	mfa, err := m.storage.MFA().Get(ctx, userID)
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFANotEnrolled
+	}
+	if mfa.Enabled {
+		return nil, storage.ErrMFAEnabled
+	}
+
+	step, ok := totp.Validate(mfa.Secret, code, time.Now(), 1)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	if err := m.storage.MFA().Enable(ctx, userID, step, hashes); err != nil {
+		m.logger.Error("failed to enable mfa", "error", err)
+		return nil, err
+	}
+
+	m.record(ctx, EventMFAEnabled, userID)
+	m.logger.Info("ConfirmMFA has finished", "user_id", userID)
+	return codes, nil
+}
+
+// Verify checks a TOTP code, or else a recovery code, of a user with MFA enabled.
+// Every code works only once.
+func (m *MFAService) Verify(ctx context.Context, userID, code, recoveryCode string) error {
+	m.logger.Info("VerifyMFA is starting", "user_id", userID)
+
+	mfa, err := m.storage.MFA().Get(ctx, userID)
+	if err != nil {
+		m.logger.Error(err.Error())
+		return err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return ErrMFANotEnrolled
+	}
+
+	if recoveryCode != "" {
+		ok, err := m.storage.MFA().UseRecoveryCode(ctx, userID, hashToken(normalizeRecoveryCode(recoveryCode)))
+		if err != nil {
+			m.logger.Error(err.Error())
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+		m.record(ctx, EventRecoveryCodeUsed, userID)
+		m.logger.Info("VerifyMFA has finished with a recovery code", "user_id", userID)
+		return nil
+	}
+
+	step, ok := totp.Validate(mfa.Secret, code, time.Now(), 1)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	if err := m.storage.MFA().UseStep(ctx, userID, step); err != nil {
+		if errors.Is(err, storage.ErrCodeReused) {
+			return ErrInvalidMFACode
+		}
+		m.logger.Error(err.Error())
+		return err
+	}
+
+	m.logger.Info("VerifyMFA has finished", "user_id", userID)
+	return nil
+}
+
+// Disable turns MFA off after checking the password and a current code. Users whose
+// role requires MFA cannot turn it off.
+func (m *MFAService) Disable(ctx context.Context, userID, password, code string) error {
+	m.logger.Info("DisableMFA is starting", "user_id", userID)
+
+	_, role, err := m.user(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if m.Required(role) {
+		return ErrMFARequired
+	}
+
+	_, hash, err := m.storage.User().GetUserByID(ctx, &pb.Id{UserId: userID})
+	if err != nil {
+		return errors.Wrap(err, "user not found")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return ErrWrongPassword
+	}
+	if err := m.Verify(ctx, userID, code, ""); err != nil {
+		return err
+	}
+
+	if err := m.storage.MFA().Disable(ctx, userID); err != nil {
+		m.logger.Error("failed to disable mfa", "error", err)
+		return err
+	}
+
+	m.record(ctx, EventMFADisabled, userID)
+	m.logger.Info("DisableMFA has finished", "user_id", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user after checking a
+// current code.
+func (m *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	m.logger.Info("RegenerateRecoveryCodes is starting", "user_id", userID)
+
+	if err := m.Verify(ctx, userID, code, ""); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	if err := m.storage.MFA().ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		m.logger.Error("failed to store recovery codes", "error", err)
+		return nil, err
+	}
+
+	m.record(ctx, EventRecoveryCodesRenewed, userID)
+	m.logger.Info("RegenerateRecoveryCodes has finished", "user_id", userID)
+	return codes, nil
+}
+
+// NewChallenge issues the token that finishes a login once the second factor, or
+// the enrollment, is done.
+func (m *MFAService) NewChallenge(a LoginAttempt, device, purpose string) (*models.MFAChallenge, error) {
+	token, err := tokens.GenerateChallengeToken(a.UserID, a.Email, a.Phone, device, purpose, m.challengeTTL)
+	if err != nil {
+		m.logger.Error(err.Error())
+		return nil, err
+	}
+	return &models.MFAChallenge{
+		Type:           purpose,
+		ChallengeToken: token,
+		ExpiresIn:      int(m.challengeTTL.Seconds()),
+	}, nil
+}
+
+// user returns the account name shown in authenticator apps, the email or else
+// the phone, and the role of the user.
+func (m *MFAService) user(ctx context.Context, userID string) (string, string, error) {
+	profile, err := m.storage.User().GetProfile(ctx, &pb.Id{UserId: userID})
+	if err != nil {
+		er := errors.Wrap(err, "user not found")
+		m.logger.Error(er.Error())
+		return "", "", er
+	}
+	account := profile.Email
+	if account == "" {
+		account = profile.PhoneNumber
+	}
+	return account, profile.Role, nil
+}
+
+func (m *MFAService) record(ctx context.Context, eventType, userID string) {
+	if err := m.storage.SecurityEvent().Save(ctx, &pb.SecurityEvent{Type: eventType, UserId: userID}); err != nil {
+		m.logger.Error("failed to record security event", "type", eventType, "error", err)
+	}
+}
+
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// newRecoveryCodes returns codes like "k7m2p-x9qrt" and their hashes.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate recovery codes")
+		}
+		for j := range b {
+			b[j] = recoveryAlphabet[int(b[j])%len(recoveryAlphabet)]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
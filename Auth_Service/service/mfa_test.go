@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	require.Len(t, hashes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		require.Regexp(t, "^["+recoveryAlphabet+"]{5}-["+recoveryAlphabet+"]{5}$", code)
+		require.Equal(t, hashToken(normalizeRecoveryCode(code)), hashes[i])
+		require.False(t, seen[code], "recovery codes are unique")
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code, normalized string
+	}{
+		{"k7m2p-x9qrt", "k7m2px9qrt"},
+		{"K7M2P-X9QRT", "k7m2px9qrt"},
+		{"k7m2p x9qrt", "k7m2px9qrt"},
+		{"k7m2px9qrt", "k7m2px9qrt"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.normalized, normalizeRecoveryCode(tt.code), tt.code)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/pkg/oidc"
+	"Auth/storage"
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown sign-in provider")
+	ErrSignInFailed    = errors.New("sign-in with the provider failed")
+	ErrNoEmail         = errors.New("the provider did not share a verified email")
+	ErrEmailInUse      = errors.New("an account with this email already exists, log in and link the provider instead")
+	ErrLastLoginMethod = errors.New("cannot unlink the only way to log in, set a password or verify a phone first")
+)
+
+// OIDCResult is the outcome of a provider callback.
+type OIDCResult struct {
+	UserID string
+	Email  string
+	Device string
+	// Linked is set when the callback finished linking to a logged-in user rather than a login.
+	Linked bool
+	// Created is set when the login created a new account.
+	Created bool
+}
+
+type OIDCService struct {
+	storage  storage.IStorage
+	logger   *slog.Logger
+	clients  map[string]*oidc.Client
+	stateTTL time.Duration
+}
+
+func NewOIDCService(s storage.IStorage, cfg *config.Config) *OIDCService {
+	clients := map[string]*oidc.Client{}
+	for name, p := range cfg.OIDC_PROVIDERS {
+		clients[name] = oidc.NewClient(oidc.Config{
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		})
+	}
+
+	return &OIDCService{
+		storage:  s,
+		logger:   logger.NewLogger(),
+		clients:  clients,
+		stateTTL: cfg.OIDC_STATE_TTL,
+	}
+}
+
+// Providers returns the names of the configured providers.
+func (o *OIDCService) Providers() []string {
+	names := make([]string, 0, len(o.clients))
+	for name := range o.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start begins a sign-in at the provider and returns where to send the user. With
+// a userID the provider gets linked to that user instead of logging in.
+func (o *OIDCService) Start(ctx context.Context, provider, userID, device, loginHint string) (*models.OIDCStart, error) {
+	o.logger.Info("StartOIDC is starting", "provider", provider)
+
+	client, ok := o.clients[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	state, err := oidc.NewState()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.NewState()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := client.AuthCodeURL(ctx, state, nonce, verifier, loginHint)
+	if err != nil {
+		er := errors.Wrap(err, "failed to build authorization url")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	err = o.storage.OIDCState().Save(ctx, &models.OIDCState{
+		StateHash:    hashToken(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		UserID:       userID,
+		Device:       device,
+		ExpiresAt:    time.Now().Add(o.stateTTL),
+	})
+	if err != nil {
+		o.logger.Error("failed to save sign-in state", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("StartOIDC has finished", "provider", provider)
+	return &models.OIDCStart{AuthorizationURL: authURL, State: state}, nil
+}
+
+// Callback finishes a sign-in with the code the provider returned. It links the
+// provider when the sign-in was started for linking. Otherwise it finds the user
+// the provider account is linked to, or creates a customer for it; an existing
+// account with the same email is never taken over, its owner has to link instead.
+func (o *OIDCService) Callback(ctx context.Context, provider, code, state string) (*OIDCResult, error) {
+	o.logger.Info("OIDCCallback is starting", "provider", provider)
+
+	client, ok := o.clients[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	st, err := o.storage.OIDCState().Take(ctx, hashToken(state), provider)
+	if err != nil {
+		o.logger.Error("failed to get sign-in state", "error", err)
+		return nil, err
+	}
+
+	claims, err := client.Exchange(ctx, code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		o.logger.Error("failed to finish sign-in with provider", "provider", provider, "error", err)
+		return nil, errors.Wrap(ErrSignInFailed, err.Error())
+	}
+
+	if st.UserID != "" {
+		if err := o.storage.Identity().Link(ctx, st.UserID, provider, claims.Subject, claims.Email); err != nil {
+			o.logger.Error("failed to link identity", "error", err)
+			return nil, err
+		}
+		o.logger.Info("OIDCCallback has finished linking", "user_id", st.UserID, "provider", provider)
+		return &OIDCResult{UserID: st.UserID, Linked: true}, nil
+	}
+
+	userID, err := o.storage.Identity().Find(ctx, provider, claims.Subject)
+	if err != nil {
+		o.logger.Error("failed to find identity", "error", err)
+		return nil, err
+	}
+	if userID != "" {
+		profile, err := o.storage.User().GetProfile(ctx, &pb.Id{UserId: userID})
+		if err != nil {
+			er := errors.Wrap(err, "user not found")
+			o.logger.Error(er.Error())
+			return nil, er
+		}
+		o.logger.Info("OIDCCallback has finished", "user_id", userID, "provider", provider)
+		return &OIDCResult{UserID: userID, Email: profile.Email, Device: st.Device}, nil
+	}
+
+	user, err := o.register(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	o.logger.Info("OIDCCallback has finished with a new user", "user_id", user.Id, "provider", provider)
+	return &OIDCResult{UserID: user.Id, Email: user.Email, Device: st.Device, Created: true}, nil
+}
+
+// register creates a customer without a password for the provider account.
+func (o *OIDCService) register(ctx context.Context, provider string, claims *oidc.Claims) (*pb.UserResponse, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrNoEmail
+	}
+	if _, _, err := o.storage.User().GetUserByEmail(ctx, claims.Email); err == nil {
+		return nil, ErrEmailInUse
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+	if firstName == "" {
+		firstName, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	user, err := o.storage.User().Register(ctx, &pb.RegisterRequest{
+		Email:     claims.Email,
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      "customer",
+	})
+	if err != nil {
+		er := errors.Wrap(err, "failed to register user")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+	if err := o.storage.User().MarkEmailVerified(ctx, user.Id); err != nil {
+		o.logger.Error("failed to mark email as verified", "user_id", user.Id, "error", err)
+	}
+	if err := o.storage.Identity().Link(ctx, user.Id, provider, claims.Subject, claims.Email); err != nil {
+		o.logger.Error("failed to link identity", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (o *OIDCService) Identities(ctx context.Context, userID string) ([]models.Identity, error) {
+	o.logger.Info("ListIdentities is starting", "user_id", userID)
+
+	identities, err := o.storage.Identity().List(ctx, userID)
+	if err != nil {
+		o.logger.Error("failed to list identities", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("ListIdentities has finished", "user_id", userID)
+	return identities, nil
+}
+
+// Unlink removes the provider from the user unless it is the only way left to log in.
+func (o *OIDCService) Unlink(ctx context.Context, userID, provider string) error {
+	o.logger.Info("UnlinkIdentity is starting", "user_id", userID, "provider", provider)
+
+	identities, err := o.storage.Identity().List(ctx, userID)
+	if err != nil {
+		o.logger.Error("failed to list identities", "error", err)
+		return err
+	}
+	if len(identities) == 1 && identities[0].Provider == provider {
+		_, hash, err := o.storage.User().GetUserByID(ctx, &pb.Id{UserId: userID})
+		if err != nil {
+			return errors.Wrap(err, "user not found")
+		}
+		profile, err := o.storage.User().GetProfile(ctx, &pb.Id{UserId: userID})
+		if err != nil {
+			return errors.Wrap(err, "user not found")
+		}
+		if hash == "" && !profile.PhoneVerified {
+			return ErrLastLoginMethod
+		}
+	}
+
+	if err := o.storage.Identity().Unlink(ctx, userID, provider); err != nil {
+		o.logger.Error("failed to unlink identity", "error", err)
+		return err
+	}
+
+	o.logger.Info("UnlinkIdentity has finished", "user_id", userID, "provider", provider)
+	return nil
+}
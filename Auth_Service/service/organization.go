@@ -0,0 +1,354 @@
+package service
+
+import (
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/storage"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidOrgRole = errors.New("invalid organization role, expected owner, manager or driver")
+	// ErrOrgPermission is returned when the member's role does not allow the action.
+	ErrOrgPermission    = errors.New("organization role does not allow this action")
+	ErrOrgNameRequired  = errors.New("organization name is required")
+	ErrInvalidEmail     = errors.New("invalid email")
+	ErrEmailNotVerified = errors.New("email must be verified to accept the invitation")
+)
+
+// orgManagers may invite and remove members and see the bookings of the organization.
+var orgManagers = []string{models.OrgOwner, models.OrgManager}
+
+type OrganizationService struct {
+	pb.UnimplementedOrganizationServer
+	storage   storage.IStorage
+	mailer    mailer.Mailer
+	logger    *slog.Logger
+	ttl       time.Duration
+	inviteURL string
+}
+
+func NewOrganizationService(s storage.IStorage, m mailer.Mailer, cfg *config.Config) *OrganizationService {
+	return &OrganizationService{
+		storage:   s,
+		mailer:    m,
+		logger:    logger.NewLogger(),
+		ttl:       cfg.INVITE_TOKEN_TTL,
+		inviteURL: cfg.INVITE_URL,
+	}
+}
+
+// CreateOrganization creates an organization owned by the requesting user.
+func (o *OrganizationService) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.OrganizationResponse, error) {
+	o.logger.Info("CreateOrganization is starting", "owner_id", req.OwnerId)
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, ErrOrgNameRequired
+	}
+	if req.BillingEmail != "" {
+		if _, err := mail.ParseAddress(req.BillingEmail); err != nil {
+			return nil, ErrInvalidEmail
+		}
+	}
+
+	org, err := o.storage.Organization().Create(ctx, req)
+	if err != nil {
+		er := errors.Wrap(err, "failed to create organization")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	o.logger.Info("CreateOrganization has finished", "organization_id", org.Id)
+	return org, nil
+}
+
+// GetOrganization returns an organization to one of its members.
+func (o *OrganizationService) GetOrganization(ctx context.Context, req *pb.OrganizationRequest) (*pb.OrganizationResponse, error) {
+	o.logger.Info("GetOrganization is starting", "organization_id", req.OrganizationId)
+
+	member, err := o.member(ctx, req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	org, err := o.storage.Organization().Get(ctx, req.OrganizationId)
+	if err != nil {
+		o.logger.Error("failed to get organization", "error", err)
+		return nil, err
+	}
+	org.Role = member.Role
+
+	o.logger.Info("GetOrganization has finished")
+	return org, nil
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+func (o *OrganizationService) ListOrganizations(ctx context.Context, req *pb.Id) (*pb.OrganizationResponses, error) {
+	o.logger.Info("ListOrganizations is starting", "user_id", req.UserId)
+
+	resp, err := o.storage.Organization().ListByUser(ctx, req.UserId)
+	if err != nil {
+		er := errors.Wrap(err, "failed to list organizations")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	o.logger.Info("ListOrganizations has finished")
+	return resp, nil
+}
+
+// GetMembership returns the role of the user in the organization, or ErrNotOrgMember.
+// Other services use it to act on behalf of an organization.
+func (o *OrganizationService) GetMembership(ctx context.Context, req *pb.OrganizationRequest) (*pb.OrganizationMember, error) {
+	o.logger.Info("GetMembership is starting", "organization_id", req.OrganizationId, "user_id", req.UserId)
+	return o.member(ctx, req.OrganizationId, req.UserId)
+}
+
+// ListMembers returns the members of the organization to one of them.
+func (o *OrganizationService) ListMembers(ctx context.Context, req *pb.OrganizationRequest) (*pb.OrganizationMembers, error) {
+	o.logger.Info("ListMembers is starting", "organization_id", req.OrganizationId)
+
+	if _, err := o.member(ctx, req.OrganizationId, req.UserId); err != nil {
+		return nil, err
+	}
+	resp, err := o.storage.Organization().ListMembers(ctx, req.OrganizationId)
+	if err != nil {
+		er := errors.Wrap(err, "failed to list members")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	o.logger.Info("ListMembers has finished")
+	return resp, nil
+}
+
+// UpdateMemberRole changes the role of a member. Only owners can change roles.
+func (o *OrganizationService) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleRequest) (*pb.OrganizationMember, error) {
+	o.logger.Info("UpdateMemberRole is starting", "organization_id", req.OrganizationId, "user_id", req.UserId)
+
+	if !validOrgRole(req.Role) {
+		return nil, ErrInvalidOrgRole
+	}
+	if _, err := o.authorize(ctx, req.OrganizationId, req.ActorId, models.OrgOwner); err != nil {
+		return nil, err
+	}
+
+	member, err := o.storage.Organization().UpdateMemberRole(ctx, req.OrganizationId, req.UserId, req.Role)
+	if err != nil {
+		o.logger.Error("failed to update member role", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("UpdateMemberRole has finished", "role", member.Role)
+	return member, nil
+}
+
+// RemoveMember removes a member. Members may leave on their own, owners may remove
+// anyone and managers may remove drivers.
+func (o *OrganizationService) RemoveMember(ctx context.Context, req *pb.MemberRequest) (*pb.Void, error) {
+	o.logger.Info("RemoveMember is starting", "organization_id", req.OrganizationId, "user_id", req.UserId)
+
+	if req.ActorId != req.UserId {
+		actor, err := o.authorize(ctx, req.OrganizationId, req.ActorId, orgManagers...)
+		if err != nil {
+			return nil, err
+		}
+		if actor.Role == models.OrgManager {
+			target, err := o.member(ctx, req.OrganizationId, req.UserId)
+			if err != nil {
+				return nil, err
+			}
+			if target.Role != models.OrgDriver {
+				return nil, ErrOrgPermission
+			}
+		}
+	}
+
+	if err := o.storage.Organization().RemoveMember(ctx, req.OrganizationId, req.UserId); err != nil {
+		o.logger.Error("failed to remove member", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("RemoveMember has finished")
+	return &pb.Void{}, nil
+}
+
+// InviteMember emails a single-use invitation to join the organization. Owners may
+// invite with any role, managers may invite managers and drivers.
+func (o *OrganizationService) InviteMember(ctx context.Context, req *pb.InviteMemberRequest) (*pb.Invitation, error) {
+	o.logger.Info("InviteMember is starting", "organization_id", req.OrganizationId)
+
+	if !validOrgRole(req.Role) {
+		return nil, ErrInvalidOrgRole
+	}
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	email := strings.ToLower(addr.Address)
+
+	actor, err := o.authorize(ctx, req.OrganizationId, req.ActorId, orgManagers...)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role == models.OrgOwner && actor.Role != models.OrgOwner {
+		return nil, ErrOrgPermission
+	}
+
+	member, err := o.storage.Organization().IsMemberEmail(ctx, req.OrganizationId, email)
+	if err != nil {
+		o.logger.Error("failed to check members", "error", err)
+		return nil, err
+	}
+	if member {
+		return nil, storage.ErrAlreadyOrgMember
+	}
+
+	org, err := o.storage.Organization().Get(ctx, req.OrganizationId)
+	if err != nil {
+		o.logger.Error("failed to get organization", "error", err)
+		return nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "failed to generate invitation token")
+	}
+	token := hex.EncodeToString(b)
+
+	inv, err := o.storage.Organization().CreateInvitation(ctx, &models.Invitation{
+		OrganizationID: req.OrganizationId,
+		Email:          email,
+		Role:           req.Role,
+		TokenHash:      hashToken(token),
+		InvitedBy:      req.ActorId,
+		ExpiresAt:      time.Now().Add(o.ttl),
+	})
+	if err != nil {
+		er := errors.Wrap(err, "failed to store invitation")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	link := o.inviteURL + "?" + url.Values{"token": {token}}.Encode()
+	body := fmt.Sprintf("You were invited to join %s as %s.\n\nSign in with this email address and open %s to accept, or use this token: %s\n\nThe invitation expires in %s.",
+		org.Name, req.Role, link, token, o.ttl)
+	if err := o.mailer.Send(ctx, email, "Join "+org.Name, body); err != nil {
+		er := errors.Wrap(err, "failed to send invitation email")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	o.logger.Info("InviteMember has finished", "invitation_id", inv.Id)
+	return inv, nil
+}
+
+// ListInvitations returns the pending invitations of the organization to its owners and managers.
+func (o *OrganizationService) ListInvitations(ctx context.Context, req *pb.OrganizationRequest) (*pb.Invitations, error) {
+	o.logger.Info("ListInvitations is starting", "organization_id", req.OrganizationId)
+
+	if _, err := o.authorize(ctx, req.OrganizationId, req.UserId, orgManagers...); err != nil {
+		return nil, err
+	}
+	resp, err := o.storage.Organization().ListInvitations(ctx, req.OrganizationId)
+	if err != nil {
+		er := errors.Wrap(err, "failed to list invitations")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+
+	o.logger.Info("ListInvitations has finished")
+	return resp, nil
+}
+
+// RevokeInvitation cancels an invitation that was not accepted yet.
+func (o *OrganizationService) RevokeInvitation(ctx context.Context, req *pb.InvitationRequest) (*pb.Void, error) {
+	o.logger.Info("RevokeInvitation is starting", "organization_id", req.OrganizationId, "invitation_id", req.InvitationId)
+
+	if _, err := o.authorize(ctx, req.OrganizationId, req.ActorId, orgManagers...); err != nil {
+		return nil, err
+	}
+	if err := o.storage.Organization().RevokeInvitation(ctx, req.OrganizationId, req.InvitationId); err != nil {
+		o.logger.Error("failed to revoke invitation", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("RevokeInvitation has finished")
+	return &pb.Void{}, nil
+}
+
+// AcceptInvitation adds the user to the organization. The invitation must have been
+// sent to the user's email, and the email must be verified, so that a leaked token
+// is useless to anyone else.
+func (o *OrganizationService) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.OrganizationMember, error) {
+	o.logger.Info("AcceptInvitation is starting", "user_id", req.UserId)
+
+	profile, err := o.storage.User().GetProfile(ctx, &pb.Id{UserId: req.UserId})
+	if err != nil {
+		er := errors.Wrap(err, "user not found")
+		o.logger.Error(er.Error())
+		return nil, er
+	}
+	verified, err := o.storage.User().IsEmailVerified(ctx, req.UserId)
+	if err != nil {
+		o.logger.Error("failed to check email verification", "error", err)
+		return nil, err
+	}
+	if profile.Email == "" || !verified {
+		return nil, ErrEmailNotVerified
+	}
+
+	orgID, err := o.storage.Organization().AcceptInvitation(ctx, hashToken(req.Token), req.UserId, profile.Email)
+	if err != nil {
+		o.logger.Error("failed to accept invitation", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("AcceptInvitation has finished", "organization_id", orgID)
+	return o.storage.Organization().GetMember(ctx, orgID, req.UserId)
+}
+
+// member returns the membership of the user, making sure the organization exists.
+func (o *OrganizationService) member(ctx context.Context, orgID, userID string) (*pb.OrganizationMember, error) {
+	member, err := o.storage.Organization().GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotOrgMember) {
+			if _, getErr := o.storage.Organization().Get(ctx, orgID); getErr != nil {
+				return nil, getErr
+			}
+		}
+		o.logger.Error("failed to get membership", "error", err)
+		return nil, err
+	}
+	return member, nil
+}
+
+// authorize returns the membership of the user if its role is one of roles.
+func (o *OrganizationService) authorize(ctx context.Context, orgID, userID string, roles ...string) (*pb.OrganizationMember, error) {
+	member, err := o.member(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(roles, member.Role) {
+		return nil, ErrOrgPermission
+	}
+	return member, nil
+}
+
+func validOrgRole(role string) bool {
+	return role == models.OrgOwner || role == models.OrgManager || role == models.OrgDriver
+}
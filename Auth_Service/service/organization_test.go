@@ -0,0 +1,219 @@
+package service
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/storage"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// orgStorage holds a single organization, org1, whose members are kept by user ID.
+// Other storages are left nil.
+type orgStorage struct {
+	storage.IStorage
+	storage.IOrganizationStorage
+	storage.IUserStorage
+	roles       map[string]string
+	emails      map[string]string
+	verified    bool
+	removed     []string
+	invitations []*models.Invitation
+}
+
+func newOrgStorage() *orgStorage {
+	return &orgStorage{
+		roles: map[string]string{
+			"owner1":   models.OrgOwner,
+			"manager1": models.OrgManager,
+			"manager2": models.OrgManager,
+			"driver1":  models.OrgDriver,
+		},
+		emails:   map[string]string{"driver1": "driver@example.com", "user1": "user@example.com"},
+		verified: true,
+	}
+}
+
+func (s *orgStorage) Organization() storage.IOrganizationStorage { return s }
+
+func (s *orgStorage) User() storage.IUserStorage { return s }
+
+func (s *orgStorage) Get(ctx context.Context, id string) (*pb.OrganizationResponse, error) {
+	if id != "org1" {
+		return nil, storage.ErrOrganizationNotFound
+	}
+	return &pb.OrganizationResponse{Id: id, Name: "Fleet"}, nil
+}
+
+func (s *orgStorage) GetMember(ctx context.Context, orgID, userID string) (*pb.OrganizationMember, error) {
+	role, ok := s.roles[userID]
+	if orgID != "org1" || !ok {
+		return nil, storage.ErrNotOrgMember
+	}
+	return &pb.OrganizationMember{OrganizationId: orgID, UserId: userID, Role: role}, nil
+}
+
+func (s *orgStorage) RemoveMember(ctx context.Context, orgID, userID string) error {
+	s.removed = append(s.removed, userID)
+	return nil
+}
+
+func (s *orgStorage) IsMemberEmail(ctx context.Context, orgID, email string) (bool, error) {
+	for id, e := range s.emails {
+		if e == email {
+			_, ok := s.roles[id]
+			return ok, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *orgStorage) CreateInvitation(ctx context.Context, inv *models.Invitation) (*pb.Invitation, error) {
+	s.invitations = append(s.invitations, inv)
+	return &pb.Invitation{Id: "invitation1", OrganizationId: inv.OrganizationID, Email: inv.Email, Role: inv.Role}, nil
+}
+
+func (s *orgStorage) AcceptInvitation(ctx context.Context, tokenHash, userID, email string) (string, error) {
+	s.roles[userID] = models.OrgDriver
+	return "org1", nil
+}
+
+func (s *orgStorage) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileResponse, error) {
+	return &pb.GetProfileResponse{Email: s.emails[id.UserId]}, nil
+}
+
+func (s *orgStorage) IsEmailVerified(ctx context.Context, id string) (bool, error) {
+	return s.verified, nil
+}
+
+func newOrganizationService(s *orgStorage, m mailer.Mailer) *OrganizationService {
+	return &OrganizationService{
+		storage:   s,
+		mailer:    m,
+		logger:    logger.NewLogger(),
+		ttl:       72 * time.Hour,
+		inviteURL: "http://localhost/invitations/accept",
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		actor  string
+		member string
+		orgID  string
+		err    error
+	}{
+		{name: "OwnerRemovesManager", actor: "owner1", member: "manager1"},
+		{name: "ManagerRemovesDriver", actor: "manager1", member: "driver1"},
+		{name: "DriverLeaves", actor: "driver1", member: "driver1"},
+		{name: "ManagerRemovesManager", actor: "manager1", member: "manager2", err: ErrOrgPermission},
+		{name: "ManagerRemovesOwner", actor: "manager1", member: "owner1", err: ErrOrgPermission},
+		{name: "DriverRemovesDriver", actor: "driver1", member: "manager1", err: ErrOrgPermission},
+		{name: "NotMember", actor: "user1", member: "driver1", err: storage.ErrNotOrgMember},
+		{name: "UnknownOrganization", actor: "owner1", member: "driver1", orgID: "org2", err: storage.ErrOrganizationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOrgStorage()
+			orgID := "org1"
+			if tt.orgID != "" {
+				orgID = tt.orgID
+			}
+
+			_, err := newOrganizationService(s, mailer.NewMemoryMailer()).RemoveMember(context.Background(),
+				&pb.MemberRequest{OrganizationId: orgID, UserId: tt.member, ActorId: tt.actor})
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+				require.Empty(t, s.removed)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{tt.member}, s.removed)
+		})
+	}
+}
+
+func TestInviteMember(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor string
+		email string
+		role  string
+		err   error
+	}{
+		{name: "OwnerInvitesOwner", actor: "owner1", email: "new@example.com", role: models.OrgOwner},
+		{name: "ManagerInvitesDriver", actor: "manager1", email: "New@Example.com", role: models.OrgDriver},
+		{name: "ManagerInvitesOwner", actor: "manager1", email: "new@example.com", role: models.OrgOwner, err: ErrOrgPermission},
+		{name: "DriverInvites", actor: "driver1", email: "new@example.com", role: models.OrgDriver, err: ErrOrgPermission},
+		{name: "AlreadyMember", actor: "owner1", email: "driver@example.com", role: models.OrgDriver, err: storage.ErrAlreadyOrgMember},
+		{name: "InvalidRole", actor: "owner1", email: "new@example.com", role: "admin", err: ErrInvalidOrgRole},
+		{name: "InvalidEmail", actor: "owner1", email: "new", role: models.OrgDriver, err: ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOrgStorage()
+			outbox := mailer.NewMemoryMailer()
+
+			_, err := newOrganizationService(s, outbox).InviteMember(context.Background(),
+				&pb.InviteMemberRequest{OrganizationId: "org1", ActorId: tt.actor, Email: tt.email, Role: tt.role})
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+				require.Empty(t, s.invitations)
+				require.Empty(t, outbox.Messages())
+				return
+			}
+			require.NoError(t, err)
+
+			messages := outbox.Messages()
+			require.Len(t, messages, 1)
+			require.Equal(t, "new@example.com", messages[0].To)
+			token := regexp.MustCompile(`[0-9a-f]{64}`).FindString(messages[0].Body)
+			require.NotEmpty(t, token)
+
+			require.Len(t, s.invitations, 1)
+			require.Equal(t, "new@example.com", s.invitations[0].Email)
+			require.Equal(t, tt.role, s.invitations[0].Role)
+			require.Equal(t, hashToken(token), s.invitations[0].TokenHash, "only the hash of the token is stored")
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		verified bool
+		err      error
+	}{
+		{name: "Accepted", userID: "user1", verified: true},
+		{name: "EmailNotVerified", userID: "user1", err: ErrEmailNotVerified},
+		{name: "NoEmail", userID: "user2", verified: true, err: ErrEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOrgStorage()
+			s.verified = tt.verified
+
+			member, err := newOrganizationService(s, mailer.NewMemoryMailer()).AcceptInvitation(context.Background(),
+				&pb.AcceptInvitationRequest{UserId: tt.userID, Token: "token"})
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+				require.NotContains(t, s.roles, tt.userID)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, models.OrgDriver, member.Role)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"Auth/api/tokens"
+	"Auth/config"
+	pb "Auth/genproto/users"
+	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/storage"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the shortest password accepted on reset and change.
+const MinPasswordLength = 8
+
+var (
+	ErrWeakPassword  = errors.Errorf("password must be at least %d characters long", MinPasswordLength)
+	ErrWrongPassword = errors.New("old password is incorrect")
+)
+
+type PasswordService struct {
+	storage  storage.IStorage
+	mailer   mailer.Mailer
+	logger   *slog.Logger
+	ttl      time.Duration
+	resetURL string
+}
+
+func NewPasswordService(s storage.IStorage, m mailer.Mailer, cfg *config.Config) *PasswordService {
+	return &PasswordService{
+		storage:  s,
+		mailer:   m,
+		logger:   logger.NewLogger(),
+		ttl:      cfg.RESET_TOKEN_TTL,
+		resetURL: cfg.RESET_URL,
+	}
+}
+
+// RequestReset emails a single-use reset link to the account with the given email.
+// Unknown emails are ignored silently so that registered addresses cannot be probed.
+func (p *PasswordService) RequestReset(ctx context.Context, email string) error {
+	p.logger.Info("RequestPasswordReset is starting")
+
+	id, _, err := p.storage.User().GetUserByEmail(ctx, email)
+	if err != nil {
+		p.logger.Info("password reset requested for unknown email")
+		return nil
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return errors.Wrap(err, "failed to generate reset token")
+	}
+	token := hex.EncodeToString(b)
+
+	if err := p.storage.PasswordReset().Create(ctx, id, hashToken(token), time.Now().Add(p.ttl)); err != nil {
+		er := errors.Wrap(err, "failed to store reset token")
+		p.logger.Error(er.Error())
+		return er
+	}
+
+	link := p.resetURL + "?" + url.Values{"token": {token}}.Encode()
+	body := fmt.Sprintf("Someone asked to reset the password of your account.\n\nOpen %s to choose a new one, or use this token: %s\n\nThe link expires in %s. If it was not you, ignore this email.",
+		link, token, p.ttl)
+	if err := p.mailer.Send(ctx, email, "Reset your password", body); err != nil {
+		er := errors.Wrap(err, "failed to send reset email")
+		p.logger.Error(er.Error())
+		return er
+	}
+
+	p.logger.Info("RequestPasswordReset has finished", "user_id", id)
+	return nil
+}
+
+// Reset sets a new password with a reset token and signs the user out everywhere.
+func (p *PasswordService) Reset(ctx context.Context, token, password string) error {
+	p.logger.Info("ResetPassword is starting")
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	id, err := p.storage.PasswordReset().Reset(ctx, hashToken(token), hash)
+	if err != nil {
+		p.logger.Error("failed to reset password", "error", err)
+		return err
+	}
+
+	p.signOutEverywhere(ctx, id)
+	p.logger.Info("ResetPassword has finished", "user_id", id)
+	return nil
+}
+
+// Change replaces the password of a logged-in user after checking the old one,
+// and signs the user out everywhere.
+func (p *PasswordService) Change(ctx context.Context, userID, oldPassword, newPassword string) error {
+	p.logger.Info("ChangePassword is starting", "user_id", userID)
+
+	_, current, err := p.storage.User().GetUserByID(ctx, &pb.Id{UserId: userID})
+	if err != nil {
+		er := errors.Wrap(err, "user not found")
+		p.logger.Error(er.Error())
+		return er
+	}
+	if bcrypt.CompareHashAndPassword([]byte(current), []byte(oldPassword)) != nil {
+		return ErrWrongPassword
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := p.storage.User().UpdatePassword(ctx, userID, hash); err != nil {
+		er := errors.Wrap(err, "failed to update password")
+		p.logger.Error(er.Error())
+		return er
+	}
+
+	p.signOutEverywhere(ctx, userID)
+	p.logger.Info("ChangePassword has finished", "user_id", userID)
+	return nil
+}
+
+// signOutEverywhere revokes every refresh and access token of the user, so that
+// whoever knew the old password loses access.
+func (p *PasswordService) signOutEverywhere(ctx context.Context, userID string) {
+	if _, err := p.storage.Session().RevokeAll(ctx, userID); err != nil {
+		p.logger.Error("failed to revoke sessions", "user_id", userID, "error", err)
+	}
+	if err := p.storage.Revocation().RevokeUser(ctx, userID, time.Now(), tokens.AccessTokenTTL); err != nil {
+		p.logger.Error("failed to revoke access tokens", "user_id", userID, "error", err)
+	}
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrWeakPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash password")
+	}
+	return string(hash), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
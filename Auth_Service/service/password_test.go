@@ -0,0 +1,39 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPassword(t *testing.T) {
+	tests := []struct {
+		password string
+		ok       bool
+	}{
+		{"", false},
+		{"1234567", false},
+		{"12345678", true},
+		{strings.Repeat("p", 64), true},
+	}
+
+	for _, tt := range tests {
+		hash, err := hashPassword(tt.password)
+		if !tt.ok {
+			require.True(t, errors.Is(err, ErrWeakPassword), tt.password)
+			continue
+		}
+		require.NoError(t, err, tt.password)
+		require.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte(tt.password)))
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	require.Equal(t, hashToken("token"), hashToken("token"))
+	require.NotEqual(t, hashToken("token"), hashToken("other"))
+	require.Len(t, hashToken("token"), 64)
+	require.NotContains(t, hashToken("token"), "token", "only the hash of a reset token is stored")
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"Auth/config"
+	"Auth/pkg/logger"
+	"Auth/pkg/sms"
+	"Auth/storage"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidPhone = errors.New("phone number must be in international format, e.g. +998901234567")
+
+	phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+)
+
+// TooManyCodesError is returned when a phone or IP asks for codes too often.
+type TooManyCodesError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyCodesError) Error() string {
+	return "too many codes requested, try again later"
+}
+
+type PhoneService struct {
+	storage        storage.IStorage
+	sender         sms.Sender
+	logger         *slog.Logger
+	ttl            time.Duration
+	resendInterval time.Duration
+	maxPerHour     int
+	ipMaxPerHour   int
+}
+
+func NewPhoneService(s storage.IStorage, sender sms.Sender, cfg *config.Config) *PhoneService {
+	return &PhoneService{
+		storage:        s,
+		sender:         sender,
+		logger:         logger.NewLogger(),
+		ttl:            cfg.PHONE_CODE_TTL,
+		resendInterval: cfg.PHONE_RESEND_INTERVAL,
+		maxPerHour:     cfg.PHONE_MAX_CODES_PER_HOUR,
+		ipMaxPerHour:   cfg.PHONE_IP_MAX_CODES_PER_HOUR,
+	}
+}
+
+// NormalizePhone strips spaces, dashes and brackets and checks that the number is
+// in E.164 format.
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+	if !phonePattern.MatchString(phone) {
+		return "", ErrInvalidPhone
+	}
+	return phone, nil
+}
+
+// SendCode texts a one-time code to the phone. A phone gets one code per resend
+// interval and a limited number per hour, and so does the requesting IP.
+func (p *PhoneService) SendCode(ctx context.Context, phone, ip string) error {
+	p.logger.Info("SendPhoneCode is starting")
+
+	last, byPhone, fromIP, err := p.storage.PhoneOTP().Sent(ctx, phone, ip, time.Now().Add(-time.Hour))
+	if err != nil {
+		er := errors.Wrap(err, "failed to check sent codes")
+		p.logger.Error(er.Error())
+		return er
+	}
+	if wait := time.Until(last.Add(p.resendInterval)); wait > 0 {
+		return &TooManyCodesError{RetryAfter: wait}
+	}
+	if byPhone >= p.maxPerHour || (ip != "" && fromIP >= p.ipMaxPerHour) {
+		p.logger.Warn("phone code limit reached", "phone", phone, "ip", ip)
+		return &TooManyCodesError{RetryAfter: time.Hour}
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return errors.Wrap(err, "failed to generate phone code")
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	if err := p.storage.PhoneOTP().Create(ctx, phone, hashCode(phone, code), ip, time.Now().Add(p.ttl)); err != nil {
+		er := errors.Wrap(err, "failed to store phone code")
+		p.logger.Error(er.Error())
+		return er
+	}
+
+	msg := fmt.Sprintf("Your car wash login code is %s. It expires in %s.", code, p.ttl)
+	if err := p.sender.Send(ctx, phone, msg); err != nil {
+		er := errors.Wrap(err, "failed to send phone code")
+		p.logger.Error(er.Error())
+		return er
+	}
+
+	p.logger.Info("SendPhoneCode has finished")
+	return nil
+}
+
+// Login checks the code and returns the user who verified the phone. When there is
+// none, a customer account is created for the phone and created is true.
+func (p *PhoneService) Login(ctx context.Context, phone, code, firstName, lastName string) (string, bool, error) {
+	p.logger.Info("PhoneLogin is starting")
+
+	if err := p.storage.PhoneOTP().Verify(ctx, phone, hashCode(phone, code)); err != nil {
+		p.logger.Error("failed to verify phone code", "error", err)
+		return "", false, err
+	}
+
+	id, err := p.storage.User().GetUserByPhone(ctx, phone)
+	if err == nil {
+		p.logger.Info("PhoneLogin has finished", "user_id", id)
+		return id, false, nil
+	}
+
+	user, err := p.storage.User().RegisterByPhone(ctx, phone, firstName, lastName)
+	if err != nil {
+		er := errors.Wrap(err, "failed to register user by phone")
+		p.logger.Error(er.Error())
+		return "", false, er
+	}
+
+	p.logger.Info("PhoneLogin has finished with a new user", "user_id", user.Id)
+	return user.Id, true, nil
+}
+
+// Verify checks the code and marks the phone as verified on the user's profile.
+func (p *PhoneService) Verify(ctx context.Context, userID, phone, code string) error {
+	p.logger.Info("VerifyPhone is starting", "user_id", userID)
+
+	if err := p.storage.PhoneOTP().Verify(ctx, phone, hashCode(phone, code)); err != nil {
+		p.logger.Error("failed to verify phone code", "error", err)
+		return err
+	}
+	if err := p.storage.User().SetPhoneVerified(ctx, userID, phone); err != nil {
+		p.logger.Error("failed to mark phone as verified", "error", err)
+		return err
+	}
+
+	p.logger.Info("VerifyPhone has finished", "user_id", userID)
+	return nil
+}
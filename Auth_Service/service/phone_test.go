@@ -0,0 +1,119 @@
+package service
+
+import (
+	"Auth/pkg/logger"
+	"Auth/storage"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// phoneStorage keeps phone codes in memory. Other storages are left nil.
+type phoneStorage struct {
+	storage.IStorage
+	last            time.Time
+	byPhone, fromIP int
+	created         []string
+}
+
+func (s *phoneStorage) PhoneOTP() storage.IPhoneOTPStorage { return s }
+
+func (s *phoneStorage) Create(ctx context.Context, phone, codeHash, ip string, expiresAt time.Time) error {
+	s.created = append(s.created, codeHash)
+	return nil
+}
+
+func (s *phoneStorage) Sent(ctx context.Context, phone, ip string, since time.Time) (time.Time, int, int, error) {
+	return s.last, s.byPhone, s.fromIP, nil
+}
+
+func (s *phoneStorage) Verify(ctx context.Context, phone, codeHash string) error {
+	return nil
+}
+
+// smsOutbox records the messages it is asked to send.
+type smsOutbox struct {
+	messages []string
+}
+
+func (o *smsOutbox) Send(ctx context.Context, phone, message string) error {
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, normalized string
+	}{
+		{"+998901234567", "+998901234567"},
+		{" +998 (90) 123-45-67 ", "+998901234567"},
+		{"+14155552671", "+14155552671"},
+		{"998901234567", ""},
+		{"+0998901234567", ""},
+		{"+1234567", ""},
+		{"+1234567890123456", ""},
+		{"+99890abc4567", ""},
+	}
+
+	for _, tt := range tests {
+		phone, err := NormalizePhone(tt.phone)
+		if tt.normalized == "" {
+			require.True(t, errors.Is(err, ErrInvalidPhone), tt.phone)
+			continue
+		}
+		require.NoError(t, err, tt.phone)
+		require.Equal(t, tt.normalized, phone)
+	}
+}
+
+func TestSendCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		storage    phoneStorage
+		ip         string
+		retryAfter time.Duration
+	}{
+		{name: "FirstCode", ip: "10.0.0.1"},
+		{name: "AfterResendInterval", storage: phoneStorage{last: time.Now().Add(-2 * time.Minute), byPhone: 1, fromIP: 1}, ip: "10.0.0.1"},
+		{name: "TooSoon", storage: phoneStorage{last: time.Now().Add(-10 * time.Second), byPhone: 1}, retryAfter: 50 * time.Second},
+		{name: "PhoneLimit", storage: phoneStorage{byPhone: 5}, retryAfter: time.Hour},
+		{name: "IPLimit", storage: phoneStorage{fromIP: 20}, ip: "10.0.0.1", retryAfter: time.Hour},
+		{name: "NoIP", storage: phoneStorage{fromIP: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.storage
+			outbox := &smsOutbox{}
+			p := &PhoneService{
+				storage:        &s,
+				sender:         outbox,
+				logger:         logger.NewLogger(),
+				ttl:            5 * time.Minute,
+				resendInterval: time.Minute,
+				maxPerHour:     5,
+				ipMaxPerHour:   20,
+			}
+
+			err := p.SendCode(context.Background(), "+998901234567", tt.ip)
+			if tt.retryAfter > 0 {
+				var tooMany *TooManyCodesError
+				require.True(t, errors.As(err, &tooMany))
+				require.InDelta(t, tt.retryAfter, tooMany.RetryAfter, float64(time.Second))
+				require.Empty(t, s.created)
+				require.Empty(t, outbox.messages)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, outbox.messages, 1)
+
+			code := regexp.MustCompile(`\d{6}`).FindString(outbox.messages[0])
+			require.NotEmpty(t, code)
+			require.Equal(t, []string{hashCode("+998901234567", code)}, s.created, "only the hash of the code is stored")
+		})
+	}
+}
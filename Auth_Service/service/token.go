@@ -0,0 +1,68 @@
+package service
+
+import (
+	"Auth/pkg/logger"
+	"Auth/storage"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TokenService struct {
+	storage storage.IStorage
+	logger  *slog.Logger
+}
+
+func NewTokenService(s storage.IStorage) *TokenService {
+	return &TokenService{
+		storage: s,
+		logger:  logger.NewLogger(),
+	}
+}
+
+// DeleteExpired removes refresh tokens that can no longer be used, then the sessions
+// left without tokens and unfinished social sign-ins. Revoked tokens are kept until they expire so that their
+// reuse can still be detected.
+func (r *TokenService) DeleteExpired(ctx context.Context) {
+	n, err := r.storage.Token().DeleteExpired(ctx)
+	if err != nil {
+		r.logger.Error("failed to delete expired refresh tokens", "error", err)
+		return
+	}
+	if n > 0 {
+		r.logger.Info("Expired refresh tokens deleted", "count", n)
+	}
+
+	n, err = r.storage.Session().DeleteStale(ctx)
+	if err != nil {
+		r.logger.Error("failed to delete stale sessions", "error", err)
+		return
+	}
+	if n > 0 {
+		r.logger.Info("Stale sessions deleted", "count", n)
+	}
+
+	n, err = r.storage.OIDCState().DeleteExpired(ctx)
+	if err != nil {
+		r.logger.Error("failed to delete expired sign-in states", "error", err)
+		return
+	}
+	if n > 0 {
+		r.logger.Info("Expired sign-in states deleted", "count", n)
+	}
+}
+
+// RunCleanup deletes expired refresh tokens every interval until ctx is done.
+func (r *TokenService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.DeleteExpired(ctx)
+		}
+	}
+}
@@ -104,3 +104,27 @@ func (r *UserService) GetUserByID(ctx context.Context, id *pb.Id) (string, strin
 	r.logger.Info("GetUserById has finished")
 	return email, password, nil
 }
+
+func (r *UserService) IsEmailVerified(ctx context.Context, id string) (bool, error) {
+	r.logger.Info("IsEmailVerified is starting")
+	verified, err := r.storage.User().IsEmailVerified(ctx, id)
+	if err != nil {
+		er := errors.Wrap(err, "failed to get email verification state")
+		r.logger.Error(er.Error())
+		return false, er
+	}
+	r.logger.Info("IsEmailVerified has finished")
+	return verified, nil
+}
+
+// CheckToken tells whether an access token has been revoked before its expiry.
+func (r *UserService) CheckToken(ctx context.Context, req *pb.CheckTokenRequest) (*pb.CheckTokenResponse, error) {
+	revoked, reason, err := r.storage.Revocation().IsRevoked(ctx, req.Jti, req.SessionId, req.UserId, req.IssuedAt)
+	if err != nil {
+		er := errors.Wrap(err, "failed to check token")
+		r.logger.Error(er.Error())
+		return nil, er
+	}
+
+	return &pb.CheckTokenResponse{Revoked: revoked, Reason: reason}, nil
+}
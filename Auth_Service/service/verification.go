@@ -0,0 +1,124 @@
+package service
+
+import (
+	"Auth/config"
+	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/storage"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// resendInterval is how long a user has to wait before another code is emailed.
+const resendInterval = time.Minute
+
+var ErrResendTooSoon = errors.New("verification code was sent recently, try again later")
+
+type VerificationService struct {
+	storage   storage.IStorage
+	mailer    mailer.Mailer
+	logger    *slog.Logger
+	ttl       time.Duration
+	verifyURL string
+}
+
+func NewVerificationService(s storage.IStorage, m mailer.Mailer, cfg *config.Config) *VerificationService {
+	return &VerificationService{
+		storage:   s,
+		mailer:    m,
+		logger:    logger.NewLogger(),
+		ttl:       cfg.EMAIL_CODE_TTL,
+		verifyURL: cfg.VERIFY_URL,
+	}
+}
+
+// SendCode creates a one-time code for the user and emails it together with a verification link.
+func (v *VerificationService) SendCode(ctx context.Context, userID, email string) error {
+	v.logger.Info("SendVerificationCode is starting", "user_id", userID)
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return errors.Wrap(err, "failed to generate verification code")
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	err = v.storage.Verification().Create(ctx, userID, hashCode(userID, code), time.Now().Add(v.ttl))
+	if err != nil {
+		er := errors.Wrap(err, "failed to store verification code")
+		v.logger.Error(er.Error())
+		return er
+	}
+
+	link := v.verifyURL + "?" + url.Values{"email": {email}, "code": {code}}.Encode()
+	body := fmt.Sprintf("Your verification code is %s.\n\nYou can also confirm your email by opening %s\n\nThe code expires in %s.",
+		code, link, v.ttl)
+	if err := v.mailer.Send(ctx, email, "Confirm your email", body); err != nil {
+		er := errors.Wrap(err, "failed to send verification email")
+		v.logger.Error(er.Error())
+		return er
+	}
+
+	v.logger.Info("SendVerificationCode has finished", "user_id", userID)
+	return nil
+}
+
+// Verify marks the email as verified if the code is the user's active one.
+func (v *VerificationService) Verify(ctx context.Context, email, code string) error {
+	v.logger.Info("VerifyEmail is starting")
+
+	id, _, err := v.storage.User().GetUserByEmail(ctx, email)
+	if err != nil {
+		v.logger.Error("failed to get user by email", "error", err)
+		return errors.New("invalid or expired code")
+	}
+
+	if err := v.storage.Verification().Verify(ctx, id, hashCode(id, code)); err != nil {
+		v.logger.Error("failed to verify email", "user_id", id, "error", err)
+		return err
+	}
+
+	v.logger.Info("VerifyEmail has finished", "user_id", id)
+	return nil
+}
+
+// Resend emails a new code unless the account does not exist, is already verified
+// or got a code less than resendInterval ago. The first two cases are not reported
+// so that the endpoint cannot be used to find registered emails.
+func (v *VerificationService) Resend(ctx context.Context, email string) error {
+	v.logger.Info("ResendVerificationCode is starting")
+
+	id, _, err := v.storage.User().GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+	verified, err := v.storage.User().IsEmailVerified(ctx, id)
+	if err != nil || verified {
+		return nil
+	}
+
+	sentAt, err := v.storage.Verification().LastSentAt(ctx, id)
+	if err != nil {
+		er := errors.Wrap(err, "failed to get last verification code")
+		v.logger.Error(er.Error())
+		return er
+	}
+	if time.Since(sentAt) < resendInterval {
+		return ErrResendTooSoon
+	}
+
+	return v.SendCode(ctx, id, email)
+}
+
+func hashCode(userID, code string) string {
+	sum := sha256.Sum256([]byte(userID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"Auth/pkg/logger"
+	"Auth/pkg/mailer"
+	"Auth/storage"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// verificationStorage knows a single user, user1 with user@example.com, and keeps
+// their verification code in memory. Other storages are left nil.
+type verificationStorage struct {
+	storage.IStorage
+	storage.IUserStorage
+	verified bool
+	lastSent time.Time
+	codeHash string
+}
+
+func (s *verificationStorage) User() storage.IUserStorage { return s }
+
+func (s *verificationStorage) Verification() storage.IVerificationStorage { return s }
+
+func (s *verificationStorage) GetUserByEmail(ctx context.Context, email string) (string, string, error) {
+	if email != "user@example.com" {
+		return "", "", errors.New("user not found")
+	}
+	return "user1", "bcrypt", nil
+}
+
+func (s *verificationStorage) IsEmailVerified(ctx context.Context, id string) (bool, error) {
+	return s.verified, nil
+}
+
+func (s *verificationStorage) Create(ctx context.Context, userID, codeHash string, expiresAt time.Time) error {
+	s.codeHash = codeHash
+	s.lastSent = time.Now()
+	return nil
+}
+
+func (s *verificationStorage) LastSentAt(ctx context.Context, userID string) (time.Time, error) {
+	return s.lastSent, nil
+}
+
+func (s *verificationStorage) Verify(ctx context.Context, userID, codeHash string) error {
+	if s.codeHash == "" || codeHash != s.codeHash {
+		return errors.New("invalid or expired code")
+	}
+	s.verified = true
+	return nil
+}
+
+func TestResendVerificationCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		storage verificationStorage
+		sent    bool
+		err     error
+	}{
+		{name: "FirstCode", email: "user@example.com", sent: true},
+		{name: "AfterResendInterval", email: "user@example.com", storage: verificationStorage{lastSent: time.Now().Add(-2 * time.Minute)}, sent: true},
+		{name: "TooSoon", email: "user@example.com", storage: verificationStorage{lastSent: time.Now().Add(-10 * time.Second)}, err: ErrResendTooSoon},
+		{name: "AlreadyVerified", email: "user@example.com", storage: verificationStorage{verified: true}},
+		{name: "UnknownEmail", email: "other@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := tt.storage
+			outbox := mailer.NewMemoryMailer()
+			v := &VerificationService{
+				storage:   &s,
+				mailer:    outbox,
+				logger:    logger.NewLogger(),
+				ttl:       15 * time.Minute,
+				verifyURL: "http://localhost/verify",
+			}
+
+			err := v.Resend(ctx, tt.email)
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tt.sent {
+				require.Empty(t, outbox.Messages())
+				return
+			}
+
+			messages := outbox.Messages()
+			require.Len(t, messages, 1)
+			require.Equal(t, tt.email, messages[0].To)
+			code := regexp.MustCompile(`\d{6}`).FindString(messages[0].Body)
+			require.NotEmpty(t, code)
+			require.Contains(t, messages[0].Body, "http://localhost/verify?code="+code)
+			require.Equal(t, hashCode("user1", code), s.codeHash, "only the hash of the code is stored")
+
+			require.EqualError(t, v.Verify(ctx, "other@example.com", code), "invalid or expired code")
+			require.NoError(t, v.Verify(ctx, tt.email, code))
+			require.True(t, s.verified)
+		})
+	}
+}
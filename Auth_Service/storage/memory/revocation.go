@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevocationRepo keeps revocations in process memory. It backs the Redis store
+// when Redis is unavailable, so revocations still apply on this instance.
+type RevocationRepo struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	value     int64
+	expiresAt time.Time
+}
+
+func NewRevocationRepo() *RevocationRepo {
+	return &RevocationRepo{entries: make(map[string]entry)}
+}
+
+func (r *RevocationRepo) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	r.set("jti:"+jti, 1, ttl)
+	return nil
+}
+
+func (r *RevocationRepo) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	r.set("sid:"+sessionID, 1, ttl)
+	return nil
+}
+
+func (r *RevocationRepo) RevokeUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	r.set("user:"+userID, at.Unix(), ttl)
+	return nil
+}
+
+func (r *RevocationRepo) IsRevoked(ctx context.Context, jti, sessionID, userID string, issuedAt int64) (bool, string, error) {
+	if jti != "" && r.get("jti:"+jti) > 0 {
+		return true, "token revoked", nil
+	}
+	if sessionID != "" && r.get("sid:"+sessionID) > 0 {
+		return true, "session revoked", nil
+	}
+	if cutoff := r.get("user:" + userID); cutoff > 0 && issuedAt < cutoff {
+		return true, "user tokens revoked", nil
+	}
+	return false, "", nil
+}
+
+func (r *RevocationRepo) set(key string, value int64, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range r.entries {
+		if now.After(e.expiresAt) {
+			delete(r.entries, k)
+		}
+	}
+	if old, ok := r.entries[key]; ok && old.value > value {
+		value = old.value
+	}
+	r.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+func (r *RevocationRepo) get(key string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return 0
+	}
+	return e.value
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	cutoff := time.Now()
+
+	r := NewRevocationRepo()
+	require.NoError(t, r.RevokeToken(ctx, "jti1", time.Minute))
+	require.NoError(t, r.RevokeSession(ctx, "session1", time.Minute))
+	require.NoError(t, r.RevokeUser(ctx, "user1", cutoff, time.Minute))
+	require.NoError(t, r.RevokeToken(ctx, "expired", -time.Second))
+
+	tests := []struct {
+		name      string
+		jti       string
+		sessionID string
+		userID    string
+		issuedAt  int64
+		reason    string
+	}{
+		{name: "Token", jti: "jti1", userID: "user2", reason: "token revoked"},
+		{name: "Session", jti: "jti2", sessionID: "session1", userID: "user2", reason: "session revoked"},
+		{name: "IssuedBeforeUserCutoff", jti: "jti2", userID: "user1", issuedAt: cutoff.Unix() - 1, reason: "user tokens revoked"},
+		{name: "IssuedAfterUserCutoff", jti: "jti2", userID: "user1", issuedAt: cutoff.Unix()},
+		{name: "Expired", jti: "expired", userID: "user2"},
+		{name: "Active", jti: "jti2", sessionID: "session2", userID: "user2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, reason, err := r.IsRevoked(ctx, tt.jti, tt.sessionID, tt.userID, tt.issuedAt)
+			require.NoError(t, err)
+			require.Equal(t, tt.reason != "", revoked)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestRevokeUserKeepsLatestCutoff(t *testing.T) {
+	ctx := context.Background()
+	later := time.Now()
+
+	r := NewRevocationRepo()
+	require.NoError(t, r.RevokeUser(ctx, "user1", later, time.Minute))
+	require.NoError(t, r.RevokeUser(ctx, "user1", later.Add(-time.Hour), time.Minute))
+
+	revoked, _, err := r.IsRevoked(ctx, "", "", "user1", later.Unix()-1)
+	require.NoError(t, err)
+	require.True(t, revoked, "an older cutoff must not lift a newer one")
+}
@@ -22,7 +22,7 @@ func NewAdminRepo(db *sql.DB) storage.IAdminStorage {
 // GetProfile fetches the user profile by user ID
 func (a *AdminRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileResponse, error) {
 	query := `
-		SELECT id, email, first_name, last_name, phone_number, role, created_at
+		SELECT id, coalesce(email, ''), first_name, last_name, coalesce(phone_number, ''), role, created_at, phone_verified
 		FROM Users
 		WHERE id = $1`
 
@@ -35,6 +35,7 @@ func (a *AdminRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileRe
 		&user.PhoneNumber,
 		&user.Role,
 		&user.CreatedAt,
+		&user.PhoneVerified,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -48,7 +49,7 @@ func (a *AdminRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileRe
 func (a *AdminRepo) GetUserByEmail(ctx context.Context, email string) (string, string, error) {
 	query := `
 	SELECT
-		id, password_hash
+		id, coalesce(password_hash, '')
 	FROM
 		users
 	WHERE
@@ -69,14 +70,24 @@ func (a *AdminRepo) GetUserByEmail(ctx context.Context, email string) (string, s
 
 // UpdateProfile updates the user profile
 func (a *AdminRepo) UpdateProfileA(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserResponse, error) {
+	var known bool
+	err := a.DB.QueryRowContext(ctx, `select exists(select 1 from roles where name = $1)`, req.Role).Scan(&known)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check role")
+	}
+	if !known {
+		return nil, storage.ErrUnknownRole
+	}
+
 	query := `
 		UPDATE Users
-		SET first_name = $1, last_name = $2, phone_number = $3, role = $4, updated_at = $5
+		SET first_name = $1, last_name = $2, phone_number = $3, role = $4, updated_at = $5,
+			phone_verified = phone_verified and phone_number is not distinct from $3
 		WHERE id = $6
-		RETURNING id, email, first_name, last_name, phone_number, role, created_at`
+		RETURNING id, coalesce(email, ''), first_name, last_name, coalesce(phone_number, ''), role, created_at`
 
 	var user pb.UserResponse
-	err := a.DB.QueryRowContext(ctx, query, req.FirstName, req.LastName, req.PhoneNumber, req.Role, time.Now(), req.UserId).
+	err = a.DB.QueryRowContext(ctx, query, req.FirstName, req.LastName, req.PhoneNumber, req.Role, time.Now(), req.UserId).
 		Scan(
 			&user.Id,
 			&user.Email,
@@ -122,7 +133,7 @@ func (a *AdminRepo) Delete(ctx context.Context, id *pb.Id) error {
 
 // FetchUsers retrieves users based on filters like role, and supports pagination.
 func (a *AdminRepo) FetchUsers(ctx context.Context, req *pb.Filter) (*pb.UserResponses, error) {
-	query := `SELECT id, email, first_name, last_name, phone_number, role, created_at FROM Users WHERE 1=1`
+	query := `SELECT id, coalesce(email, ''), first_name, last_name, coalesce(phone_number, ''), role, created_at FROM Users WHERE 1=1`
 	args := []interface{}{}
 	argIndex := 1
 
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"Auth/models"
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type IdentityRepo struct {
+	DB *sql.DB
+}
+
+func NewIdentityRepo(db *sql.DB) *IdentityRepo {
+	return &IdentityRepo{DB: db}
+}
+
+// Link connects the provider account to the user
+func (i *IdentityRepo) Link(ctx context.Context, userID, provider, subject, email string) error {
+	query := `
+	insert into
+		user_identities (user_id, provider, subject, email)
+	values
+		($1, $2, $3, nullif($4, ''))
+	`
+
+	if _, err := i.DB.ExecContext(ctx, query, userID, provider, subject, email); err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrIdentityTaken
+		}
+		return errors.Wrap(err, "identity storage failure")
+	}
+	return nil
+}
+
+// Find returns the user the provider account is linked to, or "" when there is none
+func (i *IdentityRepo) Find(ctx context.Context, provider, subject string) (string, error) {
+	query := `
+	select
+		i.user_id
+	from
+		user_identities i
+	join
+		users u on u.id = i.user_id
+	where
+		i.provider = $1 and i.subject = $2 and u.deleted_at = 0
+	`
+
+	var userID string
+	err := i.DB.QueryRowContext(ctx, query, provider, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "identity retrieval failure")
+	}
+	return userID, nil
+}
+
+// List returns the provider accounts linked to the user
+func (i *IdentityRepo) List(ctx context.Context, userID string) ([]models.Identity, error) {
+	query := `
+	select
+		provider, subject, coalesce(email, ''), created_at
+	from
+		user_identities
+	where
+		user_id = $1
+	order by
+		created_at
+	`
+
+	rows, err := i.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "identity retrieval failure")
+	}
+	defer rows.Close()
+
+	identities := []models.Identity{}
+	for rows.Next() {
+		var (
+			identity  models.Identity
+			createdAt time.Time
+		)
+		if err := rows.Scan(&identity.Provider, &identity.Subject, &identity.Email, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "identity scan failure")
+		}
+		identity.CreatedAt = createdAt.Format(time.RFC3339)
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "identity retrieval failure")
+	}
+
+	return identities, nil
+}
+
+// Unlink removes the user's account at the provider
+func (i *IdentityRepo) Unlink(ctx context.Context, userID, provider string) error {
+	query := `
+	delete from
+		user_identities
+	where
+		user_id = $1 and provider = $2
+	`
+
+	res, err := i.DB.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return errors.Wrap(err, "identity deletion failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return storage.ErrIdentityNotFound
+	}
+	return nil
+}
+
+type OIDCStateRepo struct {
+	DB *sql.DB
+}
+
+func NewOIDCStateRepo(db *sql.DB) *OIDCStateRepo {
+	return &OIDCStateRepo{DB: db}
+}
+
+// Save stores a pending sign-in
+func (o *OIDCStateRepo) Save(ctx context.Context, state *models.OIDCState) error {
+	query := `
+	insert into
+		oidc_states (state_hash, provider, nonce, code_verifier, user_id, device, expires_at)
+	values
+		($1, $2, $3, $4, nullif($5, '')::uuid, $6, $7)
+	`
+
+	_, err := o.DB.ExecContext(ctx, query, state.StateHash, state.Provider, state.Nonce, state.CodeVerifier,
+		state.UserID, state.Device, state.ExpiresAt)
+	if err != nil {
+		return errors.Wrap(err, "oidc state storage failure")
+	}
+	return nil
+}
+
+// Take consumes a pending sign-in of the provider, so that every state works once
+func (o *OIDCStateRepo) Take(ctx context.Context, stateHash, provider string) (*models.OIDCState, error) {
+	query := `
+	delete from
+		oidc_states
+	where
+		state_hash = $1 and provider = $2 and expires_at > now()
+	returning
+		nonce, code_verifier, coalesce(user_id::text, ''), coalesce(device, ''), expires_at
+	`
+
+	state := models.OIDCState{StateHash: stateHash, Provider: provider}
+	err := o.DB.QueryRowContext(ctx, query, stateHash, provider).
+		Scan(&state.Nonce, &state.CodeVerifier, &state.UserID, &state.Device, &state.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrInvalidState
+		}
+		return nil, errors.Wrap(err, "oidc state retrieval failure")
+	}
+	return &state, nil
+}
+
+// DeleteExpired removes sign-ins that were never finished
+func (o *OIDCStateRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := o.DB.ExecContext(ctx, `delete from oidc_states where expires_at <= now()`)
+	if err != nil {
+		return 0, errors.Wrap(err, "oidc state deletion failure")
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"Auth/models"
+	"Auth/storage"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type MFARepo struct {
+	DB *sql.DB
+}
+
+func NewMFARepo(db *sql.DB) *MFARepo {
+	return &MFARepo{DB: db}
+}
+
+// Get returns the MFA enrollment of the user, or nil when there is none
+func (m *MFARepo) Get(ctx context.Context, userID string) (*models.MFA, error) {
+	query := `
+	select
+		secret, enabled, last_used_step
+	from
+		user_mfa
+	where
+		user_id = $1
+	`
+
+	mfa := models.MFA{UserID: userID}
+	err := m.DB.QueryRowContext(ctx, query, userID).Scan(&mfa.Secret, &mfa.Enabled, &mfa.LastUsedStep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "mfa retrieval failure")
+	}
+
+	return &mfa, nil
+}
+
+// SavePending stores a new secret that is not enabled yet, replacing an earlier
+// pending one. It fails when MFA is already enabled.
+func (m *MFARepo) SavePending(ctx context.Context, userID, secret string) error {
+	query := `
+	insert into
+		user_mfa (user_id, secret)
+	values
+		($1, $2)
+	on conflict (user_id) do update set
+		secret = excluded.secret, last_used_step = 0, created_at = now()
+	where
+		user_mfa.enabled = false
+	`
+
+	res, err := m.DB.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return errors.Wrap(err, "mfa storage failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return storage.ErrMFAEnabled
+	}
+
+	return nil
+}
+
+// Enable turns on MFA of the user, marks step as used and replaces the recovery codes
+func (m *MFARepo) Enable(ctx context.Context, userID string, step int64, codeHashes []string) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	update
+		user_mfa
+	set
+		enabled = true, enabled_at = now(), last_used_step = $2
+	where
+		user_id = $1 and enabled = false
+	`
+	res, err := tx.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return errors.Wrap(err, "mfa update failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return storage.ErrMFAEnabled
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// UseStep records that the code of step was used. It fails with ErrCodeReused when
+// the step, or a later one, was used already.
+func (m *MFARepo) UseStep(ctx context.Context, userID string, step int64) error {
+	query := `
+	update
+		user_mfa
+	set
+		last_used_step = $2
+	where
+		user_id = $1 and last_used_step < $2
+	`
+
+	res, err := m.DB.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return errors.Wrap(err, "mfa update failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return storage.ErrCodeReused
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes drops the user's recovery codes and stores new ones
+func (m *MFARepo) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes an unused recovery code. It reports false when the
+// code does not exist or was used already.
+func (m *MFARepo) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	query := `
+	update
+		mfa_recovery_codes
+	set
+		used_at = now()
+	where
+		user_id = $1 and code_hash = $2 and used_at is null
+	`
+
+	res, err := m.DB.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, errors.Wrap(err, "recovery code update failure")
+	}
+	n, _ := res.RowsAffected()
+
+	return n > 0, nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes the user has
+func (m *MFARepo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	query := `
+	select
+		count(*)
+	from
+		mfa_recovery_codes
+	where
+		user_id = $1 and used_at is null
+	`
+
+	var count int
+	if err := m.DB.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "recovery code retrieval failure")
+	}
+
+	return count, nil
+}
+
+// Disable removes the MFA enrollment and recovery codes of the user
+func (m *MFARepo) Disable(ctx context.Context, userID string) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `delete from mfa_recovery_codes where user_id = $1`, userID); err != nil {
+		return errors.Wrap(err, "recovery code deletion failure")
+	}
+	if _, err := tx.ExecContext(ctx, `delete from user_mfa where user_id = $1`, userID); err != nil {
+		return errors.Wrap(err, "mfa deletion failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `delete from mfa_recovery_codes where user_id = $1`, userID); err != nil {
+		return errors.Wrap(err, "recovery code deletion failure")
+	}
+
+	query := `
+	insert into
+		mfa_recovery_codes (user_id, code_hash)
+	select
+		$1, unnest($2::text[])
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, pq.Array(codeHashes)); err != nil {
+		return errors.Wrap(err, "recovery code storage failure")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"Auth/storage"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseStep(t *testing.T) {
+	query := regexp.QuoteMeta(`user_id = $1 and last_used_step < $2`)
+
+	tests := []struct {
+		name     string
+		affected int64
+		err      error
+	}{
+		{name: "NewStep", affected: 1},
+		{name: "UsedStep", affected: 0, err: storage.ErrCodeReused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(query).WithArgs("user1", int64(42)).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			err = NewMFARepo(db).UseStep(context.Background(), "user1", 42)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package postgres
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type OrganizationRepo struct {
+	DB *sql.DB
+}
+
+func NewOrganizationRepo(db *sql.DB) *OrganizationRepo {
+	return &OrganizationRepo{DB: db}
+}
+
+// Create stores the organization and makes the creating user its owner
+func (o *OrganizationRepo) Create(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.OrganizationResponse, error) {
+	tx, err := o.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "organization storage failure")
+	}
+	defer tx.Rollback()
+
+	query := `
+	insert into
+		organizations (name, billing_email, created_by)
+	values
+		($1, nullif($2, ''), $3)
+	returning
+		id, created_at
+	`
+
+	org := pb.OrganizationResponse{
+		Name:         req.Name,
+		BillingEmail: req.BillingEmail,
+		CreatedBy:    req.OwnerId,
+		Role:         models.OrgOwner,
+	}
+	var createdAt time.Time
+	if err := tx.QueryRowContext(ctx, query, req.Name, req.BillingEmail, req.OwnerId).Scan(&org.Id, &createdAt); err != nil {
+		return nil, errors.Wrap(err, "organization storage failure")
+	}
+	org.CreatedAt = createdAt.Format(time.RFC3339)
+
+	_, err = tx.ExecContext(ctx, `insert into organization_members (organization_id, user_id, role) values ($1, $2, $3)`,
+		org.Id, req.OwnerId, models.OrgOwner)
+	if err != nil {
+		return nil, errors.Wrap(err, "organization member storage failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "organization storage failure")
+	}
+	return &org, nil
+}
+
+// Get returns the organization with the given ID
+func (o *OrganizationRepo) Get(ctx context.Context, id string) (*pb.OrganizationResponse, error) {
+	query := `
+	select
+		id, name, coalesce(billing_email, ''), created_by, created_at
+	from
+		organizations
+	where
+		id = $1
+	`
+
+	var (
+		org       pb.OrganizationResponse
+		createdAt time.Time
+	)
+	err := o.DB.QueryRowContext(ctx, query, id).Scan(&org.Id, &org.Name, &org.BillingEmail, &org.CreatedBy, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrOrganizationNotFound
+		}
+		return nil, errors.Wrap(err, "organization retrieval failure")
+	}
+	org.CreatedAt = createdAt.Format(time.RFC3339)
+	return &org, nil
+}
+
+// ListByUser returns the organizations the user is a member of, with the user's role
+func (o *OrganizationRepo) ListByUser(ctx context.Context, userID string) (*pb.OrganizationResponses, error) {
+	query := `
+	select
+		o.id, o.name, coalesce(o.billing_email, ''), o.created_by, o.created_at, m.role
+	from
+		organizations o
+	join
+		organization_members m on m.organization_id = o.id
+	where
+		m.user_id = $1
+	order by
+		o.name
+	`
+
+	rows, err := o.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "organization retrieval failure")
+	}
+	defer rows.Close()
+
+	resp := &pb.OrganizationResponses{}
+	for rows.Next() {
+		var (
+			org       pb.OrganizationResponse
+			createdAt time.Time
+		)
+		if err := rows.Scan(&org.Id, &org.Name, &org.BillingEmail, &org.CreatedBy, &createdAt, &org.Role); err != nil {
+			return nil, errors.Wrap(err, "organization scan failure")
+		}
+		org.CreatedAt = createdAt.Format(time.RFC3339)
+		resp.Organizations = append(resp.Organizations, &org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "organization retrieval failure")
+	}
+	return resp, nil
+}
+
+const memberColumns = `
+		m.organization_id, m.user_id, coalesce(u.email, ''), u.first_name, u.last_name, m.role, m.joined_at
+	from
+		organization_members m
+	join
+		users u on u.id = m.user_id and u.deleted_at = 0
+`
+
+// GetMember returns the membership of the user in the organization
+func (o *OrganizationRepo) GetMember(ctx context.Context, orgID, userID string) (*pb.OrganizationMember, error) {
+	query := `select` + memberColumns + `where m.organization_id = $1 and m.user_id = $2`
+
+	member, err := scanMember(o.DB.QueryRowContext(ctx, query, orgID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotOrgMember
+		}
+		return nil, errors.Wrap(err, "organization member retrieval failure")
+	}
+	return member, nil
+}
+
+// ListMembers returns the members of the organization, owners first
+func (o *OrganizationRepo) ListMembers(ctx context.Context, orgID string) (*pb.OrganizationMembers, error) {
+	query := `select` + memberColumns + `
+	where
+		m.organization_id = $1
+	order by
+		array_position(array['owner', 'manager', 'driver']::varchar[], m.role), m.joined_at
+	`
+
+	rows, err := o.DB.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, errors.Wrap(err, "organization member retrieval failure")
+	}
+	defer rows.Close()
+
+	resp := &pb.OrganizationMembers{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "organization member scan failure")
+		}
+		resp.Members = append(resp.Members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "organization member retrieval failure")
+	}
+	return resp, nil
+}
+
+// keepsOwner is true unless the change leaves the organization without an owner.
+// $1 is the organization and $2 the member being demoted or removed.
+const keepsOwner = `
+	(role <> 'owner' or exists(
+		select 1 from organization_members
+		where organization_id = $1 and role = 'owner' and user_id <> $2
+	))
+`
+
+// UpdateMemberRole changes the role of a member. The last owner cannot be demoted.
+func (o *OrganizationRepo) UpdateMemberRole(ctx context.Context, orgID, userID, role string) (*pb.OrganizationMember, error) {
+	query := `
+	update
+		organization_members
+	set
+		role = $3
+	where
+		organization_id = $1 and user_id = $2 and ($3 = 'owner' or` + keepsOwner + `)
+	`
+
+	res, err := o.DB.ExecContext(ctx, query, orgID, userID, role)
+	if err != nil {
+		return nil, errors.Wrap(err, "organization member update failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, o.unchanged(ctx, orgID, userID)
+	}
+	return o.GetMember(ctx, orgID, userID)
+}
+
+// RemoveMember removes the user from the organization. The last owner cannot leave.
+func (o *OrganizationRepo) RemoveMember(ctx context.Context, orgID, userID string) error {
+	query := `
+	delete from
+		organization_members
+	where
+		organization_id = $1 and user_id = $2 and` + keepsOwner
+
+	res, err := o.DB.ExecContext(ctx, query, orgID, userID)
+	if err != nil {
+		return errors.Wrap(err, "organization member deletion failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return o.unchanged(ctx, orgID, userID)
+	}
+	return nil
+}
+
+// unchanged explains why an update or removal of a member matched no row
+func (o *OrganizationRepo) unchanged(ctx context.Context, orgID, userID string) error {
+	var exists bool
+	query := `select exists(select 1 from organization_members where organization_id = $1 and user_id = $2)`
+	if err := o.DB.QueryRowContext(ctx, query, orgID, userID).Scan(&exists); err != nil {
+		return errors.Wrap(err, "organization member retrieval failure")
+	}
+	if !exists {
+		return storage.ErrNotOrgMember
+	}
+	return storage.ErrLastOwner
+}
+
+// IsMemberEmail reports whether a user with the email is a member of the organization
+func (o *OrganizationRepo) IsMemberEmail(ctx context.Context, orgID, email string) (bool, error) {
+	query := `
+	select exists(
+		select 1 from organization_members m
+		join users u on u.id = m.user_id and u.deleted_at = 0
+		where m.organization_id = $1 and lower(u.email) = lower($2)
+	)
+	`
+
+	var exists bool
+	if err := o.DB.QueryRowContext(ctx, query, orgID, email).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "organization member retrieval failure")
+	}
+	return exists, nil
+}
+
+// CreateInvitation stores an invitation. Only the hash of its token is kept.
+func (o *OrganizationRepo) CreateInvitation(ctx context.Context, inv *models.Invitation) (*pb.Invitation, error) {
+	query := `
+	insert into
+		organization_invitations (organization_id, email, role, token_hash, invited_by, expires_at)
+	values
+		($1, $2, $3, $4, $5, $6)
+	returning
+		id, created_at
+	`
+
+	resp := pb.Invitation{
+		OrganizationId: inv.OrganizationID,
+		Email:          inv.Email,
+		Role:           inv.Role,
+		InvitedBy:      inv.InvitedBy,
+		ExpiresAt:      inv.ExpiresAt.Format(time.RFC3339),
+	}
+	var createdAt time.Time
+	err := o.DB.QueryRowContext(ctx, query, inv.OrganizationID, inv.Email, inv.Role, inv.TokenHash, inv.InvitedBy, inv.ExpiresAt).
+		Scan(&resp.Id, &createdAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "invitation storage failure")
+	}
+	resp.CreatedAt = createdAt.Format(time.RFC3339)
+	return &resp, nil
+}
+
+// ListInvitations returns the invitations of the organization that can still be accepted
+func (o *OrganizationRepo) ListInvitations(ctx context.Context, orgID string) (*pb.Invitations, error) {
+	query := `
+	select
+		id, organization_id, email, role, invited_by, expires_at, created_at
+	from
+		organization_invitations
+	where
+		organization_id = $1 and accepted_at is null and expires_at > now()
+	order by
+		created_at desc
+	`
+
+	rows, err := o.DB.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invitation retrieval failure")
+	}
+	defer rows.Close()
+
+	resp := &pb.Invitations{}
+	for rows.Next() {
+		var (
+			inv                  pb.Invitation
+			expiresAt, createdAt time.Time
+		)
+		if err := rows.Scan(&inv.Id, &inv.OrganizationId, &inv.Email, &inv.Role, &inv.InvitedBy, &expiresAt, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "invitation scan failure")
+		}
+		inv.ExpiresAt = expiresAt.Format(time.RFC3339)
+		inv.CreatedAt = createdAt.Format(time.RFC3339)
+		resp.Invitations = append(resp.Invitations, &inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "invitation retrieval failure")
+	}
+	return resp, nil
+}
+
+// RevokeInvitation deletes an invitation that was not accepted yet
+func (o *OrganizationRepo) RevokeInvitation(ctx context.Context, orgID, id string) error {
+	query := `
+	delete from
+		organization_invitations
+	where
+		id = $1 and organization_id = $2 and accepted_at is null
+	`
+
+	res, err := o.DB.ExecContext(ctx, query, id, orgID)
+	if err != nil {
+		return errors.Wrap(err, "invitation deletion failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return storage.ErrInvalidInvitation
+	}
+	return nil
+}
+
+// AcceptInvitation adds the user to the organization of the invitation and marks it
+// accepted. The invitation must have been sent to email. It returns the organization ID.
+func (o *OrganizationRepo) AcceptInvitation(ctx context.Context, tokenHash, userID, email string) (string, error) {
+	tx, err := o.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "invitation storage failure")
+	}
+	defer tx.Rollback()
+
+	query := `
+	select
+		id, organization_id, email, role
+	from
+		organization_invitations
+	where
+		token_hash = $1 and accepted_at is null and expires_at > now()
+	for update
+	`
+
+	var id, orgID, invited, role string
+	if err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&id, &orgID, &invited, &role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", storage.ErrInvalidInvitation
+		}
+		return "", errors.Wrap(err, "invitation retrieval failure")
+	}
+	if !strings.EqualFold(invited, email) {
+		return "", storage.ErrInvitationEmail
+	}
+
+	_, err = tx.ExecContext(ctx, `insert into organization_members (organization_id, user_id, role) values ($1, $2, $3)`,
+		orgID, userID, role)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return "", storage.ErrAlreadyOrgMember
+		}
+		return "", errors.Wrap(err, "organization member storage failure")
+	}
+
+	if _, err := tx.ExecContext(ctx, `update organization_invitations set accepted_at = now() where id = $1`, id); err != nil {
+		return "", errors.Wrap(err, "invitation update failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", errors.Wrap(err, "invitation storage failure")
+	}
+	return orgID, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMember(row rowScanner) (*pb.OrganizationMember, error) {
+	var (
+		member   pb.OrganizationMember
+		joinedAt time.Time
+	)
+	err := row.Scan(&member.OrganizationId, &member.UserId, &member.Email, &member.FirstName, &member.LastName, &member.Role, &joinedAt)
+	if err != nil {
+		return nil, err
+	}
+	member.JoinedAt = joinedAt.Format(time.RFC3339)
+	return &member, nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	removeMemberQuery     = regexp.QuoteMeta(`organization_id = $1 and user_id = $2 and`)
+	memberExistsQuery     = regexp.QuoteMeta(`select exists(select 1 from organization_members where organization_id = $1 and user_id = $2)`)
+	openInvitationQuery   = regexp.QuoteMeta(`token_hash = $1 and accepted_at is null and expires_at > now()`)
+	addMemberQuery        = regexp.QuoteMeta(`insert into organization_members (organization_id, user_id, role) values ($1, $2, $3)`)
+	acceptInvitationQuery = regexp.QuoteMeta(`update organization_invitations set accepted_at = now() where id = $1`)
+	invitationColumns     = []string{"id", "organization_id", "email", "role"}
+)
+
+func TestRemoveOrganizationMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		err    error
+	}{
+		{
+			name: "Removed",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(removeMemberQuery).WithArgs("org1", "user1").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "LastOwner",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(removeMemberQuery).WithArgs("org1", "user1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(memberExistsQuery).WithArgs("org1", "user1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			err: storage.ErrLastOwner,
+		},
+		{
+			name: "NotMember",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(removeMemberQuery).WithArgs("org1", "user1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(memberExistsQuery).WithArgs("org1", "user1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			err: storage.ErrNotOrgMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			err = NewOrganizationRepo(db).RemoveMember(context.Background(), "org1", "user1")
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAcceptOrganizationInvitation(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		err    error
+	}{
+		{
+			name: "Accepted",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(openInvitationQuery).WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(invitationColumns).AddRow("invitation1", "org1", "User@Example.com", "driver"))
+				mock.ExpectExec(addMemberQuery).WithArgs("org1", "user1", "driver").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(acceptInvitationQuery).WithArgs("invitation1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "OtherEmail",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(openInvitationQuery).WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(invitationColumns).AddRow("invitation1", "org1", "other@example.com", "driver"))
+				mock.ExpectRollback()
+			},
+			err: storage.ErrInvitationEmail,
+		},
+		{
+			name: "AcceptedOrExpired",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(openInvitationQuery).WithArgs("hash").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			err: storage.ErrInvalidInvitation,
+		},
+		{
+			name: "AlreadyMember",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(openInvitationQuery).WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(invitationColumns).AddRow("invitation1", "org1", "user@example.com", "driver"))
+				mock.ExpectExec(addMemberQuery).WithArgs("org1", "user1", "driver").WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			err: storage.ErrAlreadyOrgMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			orgID, err := NewOrganizationRepo(db).AcceptInvitation(context.Background(), "hash", "user1", "user@example.com")
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "org1", orgID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type PasswordResetRepo struct {
+	DB *sql.DB
+}
+
+func NewPasswordResetRepo(db *sql.DB) *PasswordResetRepo {
+	return &PasswordResetRepo{DB: db}
+}
+
+// Create stores a new reset token for the user
+func (p *PasswordResetRepo) Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	query := `
+	insert into
+		password_resets (user_id, token_hash, expires_at)
+	values
+		($1, $2, $3)
+	`
+
+	_, err := p.DB.ExecContext(ctx, query, userID, tokenHash, expiresAt)
+	if err != nil {
+		return errors.Wrap(err, "reset token storage failure")
+	}
+
+	return nil
+}
+
+// Reset consumes the reset token, sets the new password hash of its owner and
+// invalidates the owner's other reset tokens. It returns the owner's ID.
+func (p *PasswordResetRepo) Reset(ctx context.Context, tokenHash, passwordHash string) (string, error) {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var userID string
+	query := `
+	update
+		password_resets
+	set
+		used_at = now()
+	where
+		token_hash = $1 and used_at is null and expires_at > now()
+	returning user_id
+	`
+	err = tx.QueryRowContext(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.New("invalid or expired reset token")
+		}
+		return "", errors.Wrap(err, "reset token retrieval failure")
+	}
+
+	query = `
+	update
+		password_resets
+	set
+		used_at = now()
+	where
+		user_id = $1 and used_at is null
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return "", errors.Wrap(err, "reset token update failure")
+	}
+
+	query = `
+	update
+		users
+	set
+		password_hash = $2, updated_at = now()
+	where
+		id = $1 and deleted_at = 0
+	`
+	res, err := tx.ExecContext(ctx, query, userID, passwordHash)
+	if err != nil {
+		return "", errors.Wrap(err, "password update failure")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", errors.New("user not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	useResetQuery     = regexp.QuoteMeta(`token_hash = $1 and used_at is null and expires_at > now()`)
+	useAllResetsQuery = regexp.QuoteMeta(`user_id = $1 and used_at is null`)
+	setPasswordQuery  = regexp.QuoteMeta(`password_hash = $2, updated_at = now()`)
+)
+
+func TestPasswordReset(t *testing.T) {
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		errText string
+	}{
+		{
+			name: "Reset",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useResetQuery).WithArgs("hash").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user1"))
+				mock.ExpectExec(useAllResetsQuery).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(setPasswordQuery).WithArgs("user1", "bcrypt").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "UsedOrExpired",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useResetQuery).WithArgs("hash").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			errText: "invalid or expired reset token",
+		},
+		{
+			name: "DeletedUser",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useResetQuery).WithArgs("hash").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user1"))
+				mock.ExpectExec(useAllResetsQuery).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(setPasswordQuery).WithArgs("user1", "bcrypt").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			errText: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			userID, err := NewPasswordResetRepo(db).Reset(context.Background(), "hash", "bcrypt")
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "user1", userID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
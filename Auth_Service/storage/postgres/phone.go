@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type PhoneOTPRepo struct {
+	DB *sql.DB
+}
+
+func NewPhoneOTPRepo(db *sql.DB) *PhoneOTPRepo {
+	return &PhoneOTPRepo{DB: db}
+}
+
+// Create stores a new login code for the phone and expires the previous unused ones.
+// Old codes are kept so that they still count against the sending limits.
+func (p *PhoneOTPRepo) Create(ctx context.Context, phone, codeHash, ip string, expiresAt time.Time) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	update
+		phone_otps
+	set
+		expires_at = now()
+	where
+		phone = $1 and used_at is null and expires_at > now()
+	`
+	if _, err := tx.ExecContext(ctx, query, phone); err != nil {
+		return errors.Wrap(err, "phone code update failure")
+	}
+
+	query = `
+	insert into
+		phone_otps (phone, code_hash, ip, expires_at)
+	values
+		($1, $2, nullif($3, ''), $4)
+	`
+	if _, err := tx.ExecContext(ctx, query, phone, codeHash, ip, expiresAt); err != nil {
+		return errors.Wrap(err, "phone code storage failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// Sent returns when the latest code was sent to the phone and how many codes were
+// sent to the phone and requested from the IP since the given time.
+func (p *PhoneOTPRepo) Sent(ctx context.Context, phone, ip string, since time.Time) (time.Time, int, int, error) {
+	query := `
+	select
+		(select max(created_at) from phone_otps where phone = $1),
+		(select count(*) from phone_otps where phone = $1 and created_at > $3),
+		(select count(*) from phone_otps where ip = $2 and created_at > $3)
+	`
+
+	var (
+		last            sql.NullTime
+		byPhone, fromIP int
+	)
+	if err := p.DB.QueryRowContext(ctx, query, phone, ip, since).Scan(&last, &byPhone, &fromIP); err != nil {
+		return time.Time{}, 0, 0, errors.Wrap(err, "phone code retrieval failure")
+	}
+	return last.Time, byPhone, fromIP, nil
+}
+
+// Verify consumes the phone's active code if it matches. Every wrong guess counts
+// against the code, which stops working after MaxVerificationAttempts.
+func (p *PhoneOTPRepo) Verify(ctx context.Context, phone, codeHash string) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var id, hash string
+	query := `
+	select
+		id, code_hash
+	from
+		phone_otps
+	where
+		phone = $1 and used_at is null and expires_at > now() and attempts < $2
+	order by
+		created_at desc
+	limit 1
+	for update
+	`
+	err = tx.QueryRowContext(ctx, query, phone, MaxVerificationAttempts).Scan(&id, &hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrInvalidCode
+		}
+		return errors.Wrap(err, "phone code retrieval failure")
+	}
+
+	if hash != codeHash {
+		query = `
+		update
+			phone_otps
+		set
+			attempts = attempts + 1
+		where
+			id = $1
+		`
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return errors.Wrap(err, "phone code attempt update failure")
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "failed to commit transaction")
+		}
+		return storage.ErrInvalidCode
+	}
+
+	query = `
+	update
+		phone_otps
+	set
+		used_at = now()
+	where
+		id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return errors.Wrap(err, "phone code update failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPhoneCode(t *testing.T) {
+	selectQuery := regexp.QuoteMeta(`phone = $1 and used_at is null and expires_at > now() and attempts < $2`)
+	attemptQuery := regexp.QuoteMeta(`attempts = attempts + 1`)
+	useQuery := regexp.QuoteMeta(`used_at = now()`)
+
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		err    error
+	}{
+		{
+			name: "Match",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).WithArgs("+998901234567", MaxVerificationAttempts).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code_hash"}).AddRow("otp1", "hash"))
+				mock.ExpectExec(useQuery).WithArgs("otp1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "WrongCodeCountsAttempt",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).WithArgs("+998901234567", MaxVerificationAttempts).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code_hash"}).AddRow("otp1", "other"))
+				mock.ExpectExec(attemptQuery).WithArgs("otp1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			err: storage.ErrInvalidCode,
+		},
+		{
+			name: "NoActiveCode",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).WithArgs("+998901234567", MaxVerificationAttempts).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			err: storage.ErrInvalidCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			err = NewPhoneOTPRepo(db).Verify(context.Background(), "+998901234567", "hash")
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
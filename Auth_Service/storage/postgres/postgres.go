@@ -2,7 +2,10 @@ package postgres
 
 import (
 	"Auth/config"
+	"Auth/pkg/logger"
 	"Auth/storage"
+	"Auth/storage/memory"
+	Redis "Auth/storage/redis"
 	"database/sql"
 	"fmt"
 
@@ -10,7 +13,8 @@ import (
 )
 
 type Postgres struct {
-	db *sql.DB
+	db          *sql.DB
+	revocations storage.IRevocationStorage
 }
 
 func ConnectDB() (storage.IStorage, error) {
@@ -26,7 +30,9 @@ func ConnectDB() (storage.IStorage, error) {
 	if err = db.Ping(); err != nil {
 		return nil, err
 	}
-	return Postgres{db: db}, nil
+	revocations := Redis.NewRevocationRepo(Redis.ConnectRedis(cfg), memory.NewRevocationRepo(), logger.NewLogger())
+
+	return Postgres{db: db, revocations: revocations}, nil
 }
 
 func (p Postgres) Close() {
@@ -37,6 +43,10 @@ func (p Postgres) Admin() storage.IAdminStorage {
 	return NewAdminRepo(p.db)
 }
 
+func (p Postgres) Organization() storage.IOrganizationStorage {
+	return NewOrganizationRepo(p.db)
+}
+
 func (p Postgres) User() storage.IUserStorage {
 	return NewUserRepo(p.db)
 }
@@ -44,3 +54,43 @@ func (p Postgres) User() storage.IUserStorage {
 func (p Postgres) Token() storage.ITokenStorage {
 	return NewTokenRepo(p.db)
 }
+
+func (p Postgres) Session() storage.ISessionStorage {
+	return NewSessionRepo(p.db)
+}
+
+func (p Postgres) Revocation() storage.IRevocationStorage {
+	return p.revocations
+}
+
+func (p Postgres) Verification() storage.IVerificationStorage {
+	return NewVerificationRepo(p.db)
+}
+
+func (p Postgres) PasswordReset() storage.IPasswordResetStorage {
+	return NewPasswordResetRepo(p.db)
+}
+
+func (p Postgres) LoginFailure() storage.ILoginFailureStorage {
+	return NewLoginFailureRepo(p.db)
+}
+
+func (p Postgres) SecurityEvent() storage.ISecurityEventStorage {
+	return NewSecurityEventRepo(p.db)
+}
+
+func (p Postgres) MFA() storage.IMFAStorage {
+	return NewMFARepo(p.db)
+}
+
+func (p Postgres) PhoneOTP() storage.IPhoneOTPStorage {
+	return NewPhoneOTPRepo(p.db)
+}
+
+func (p Postgres) Identity() storage.IIdentityStorage {
+	return NewIdentityRepo(p.db)
+}
+
+func (p Postgres) OIDCState() storage.IOIDCStateStorage {
+	return NewOIDCStateRepo(p.db)
+}
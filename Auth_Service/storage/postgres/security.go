@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	pb "Auth/genproto/users"
+	"Auth/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type LoginFailureRepo struct {
+	DB *sql.DB
+}
+
+func NewLoginFailureRepo(db *sql.DB) *LoginFailureRepo {
+	return &LoginFailureRepo{DB: db}
+}
+
+// Get returns the failure counter of the key, or nil when there is none
+func (l *LoginFailureRepo) Get(ctx context.Context, key string) (*models.LoginFailure, error) {
+	query := `
+	select
+		failures, last_failure_at, locked_until
+	from
+		login_failures
+	where
+		key = $1
+	`
+
+	failure := models.LoginFailure{Key: key}
+	var lockedUntil sql.NullTime
+	err := l.DB.QueryRowContext(ctx, query, key).Scan(&failure.Failures, &failure.LastFailureAt, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "login failure retrieval failure")
+	}
+	failure.LockedUntil = lockedUntil.Time
+
+	return &failure, nil
+}
+
+// RecordFailure adds a failed attempt to the key. The count starts over when the
+// previous failure is older than window and no lock is active.
+func (l *LoginFailureRepo) RecordFailure(ctx context.Context, key string, window time.Duration) (*models.LoginFailure, error) {
+	query := `
+	insert into
+		login_failures (key, failures, last_failure_at)
+	values
+		($1, 1, now())
+	on conflict (key) do update set
+		failures = case
+			when login_failures.last_failure_at < now() - make_interval(secs => $2)
+				and (login_failures.locked_until is null or login_failures.locked_until < now())
+			then 1
+			else login_failures.failures + 1
+		end,
+		locked_until = case
+			when login_failures.locked_until < now() then null
+			else login_failures.locked_until
+		end,
+		last_failure_at = now()
+	returning failures, last_failure_at, locked_until
+	`
+
+	failure := models.LoginFailure{Key: key}
+	var lockedUntil sql.NullTime
+	err := l.DB.QueryRowContext(ctx, query, key, window.Seconds()).Scan(&failure.Failures, &failure.LastFailureAt, &lockedUntil)
+	if err != nil {
+		return nil, errors.Wrap(err, "login failure storage failure")
+	}
+	failure.LockedUntil = lockedUntil.Time
+
+	return &failure, nil
+}
+
+// Lock blocks logins for the key until the given time
+func (l *LoginFailureRepo) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+	update
+		login_failures
+	set
+		locked_until = $2
+	where
+		key = $1
+	`
+
+	if _, err := l.DB.ExecContext(ctx, query, key, until); err != nil {
+		return errors.Wrap(err, "login lock failure")
+	}
+	return nil
+}
+
+// Reset forgets the failures of the key
+func (l *LoginFailureRepo) Reset(ctx context.Context, key string) error {
+	query := `
+	delete from
+		login_failures
+	where
+		key = $1
+	`
+
+	if _, err := l.DB.ExecContext(ctx, query, key); err != nil {
+		return errors.Wrap(err, "login failure reset failure")
+	}
+	return nil
+}
+
+type SecurityEventRepo struct {
+	DB *sql.DB
+}
+
+func NewSecurityEventRepo(db *sql.DB) *SecurityEventRepo {
+	return &SecurityEventRepo{DB: db}
+}
+
+// Save records a security event
+func (s *SecurityEventRepo) Save(ctx context.Context, event *pb.SecurityEvent) error {
+	query := `
+	insert into
+		security_events (type, user_id, email, ip, user_agent, details)
+	values
+		($1, nullif($2, '')::uuid, $3, $4, $5, $6)
+	`
+
+	_, err := s.DB.ExecContext(ctx, query, event.Type, event.UserId, event.Email, event.Ip, event.UserAgent, event.Details)
+	if err != nil {
+		return errors.Wrap(err, "security event storage failure")
+	}
+	return nil
+}
+
+// List returns security events matching the filter, newest first
+func (s *SecurityEventRepo) List(ctx context.Context, req *pb.SecurityEventFilter) (*pb.SecurityEvents, error) {
+	query := `
+	SELECT
+		id, type, coalesce(user_id::text, ''), coalesce(email, ''), coalesce(ip, ''),
+		coalesce(user_agent, ''), coalesce(details, ''), created_at
+	FROM
+		security_events
+	WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+
+	filters := []struct {
+		column, value string
+	}{
+		{"type", req.Type},
+		{"user_id::text", req.UserId},
+		{"email", req.Email},
+		{"ip", req.Ip},
+	}
+	for _, f := range filters {
+		if f.value != "" {
+			query += fmt.Sprintf(" AND %s = $%d", f.column, argIndex)
+			args = append(args, f.value)
+			argIndex++
+		}
+	}
+
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "security event retrieval failure")
+	}
+	defer rows.Close()
+
+	events := []*pb.SecurityEvent{}
+	for rows.Next() {
+		var (
+			event     pb.SecurityEvent
+			createdAt time.Time
+		)
+		err := rows.Scan(&event.Id, &event.Type, &event.UserId, &event.Email, &event.Ip, &event.UserAgent, &event.Details, &createdAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "security event scan failure")
+		}
+		event.CreatedAt = createdAt.Format(time.RFC3339)
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "security event retrieval failure")
+	}
+
+	return &pb.SecurityEvents{Events: events}, nil
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"Auth/models"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type SessionRepo struct {
+	DB *sql.DB
+}
+
+func NewSessionRepo(db *sql.DB) *SessionRepo {
+	return &SessionRepo{DB: db}
+}
+
+// Create saves a new session together with the first refresh token of its family.
+// The session ID is the family ID of the refresh tokens issued for it. Both rows are
+// written in one transaction, so a login never leaves a token without its session.
+func (s *SessionRepo) Create(ctx context.Context, session *models.Session, token *models.RefreshTokenDetails) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	insert into
+		sessions (id, user_id, device, user_agent, ip)
+	values
+		($1, $2, $3, $4, $5)
+	`
+	_, err = tx.ExecContext(ctx, query, session.ID, session.UserID, session.Device, session.UserAgent, session.IP)
+	if err != nil {
+		return errors.Wrap(err, "session storage failure")
+	}
+
+	query = `
+	insert into
+		refresh_tokens (user_id, token, expires_at, family_id)
+	values
+		($1, $2, $3, $4)
+	`
+	_, err = tx.ExecContext(ctx, query, token.UserID, token.Token, token.Expiry, session.ID)
+	if err != nil {
+		return errors.Wrap(err, "refresh token storage failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// Touch records that the session was used just now from the given IP
+func (s *SessionRepo) Touch(ctx context.Context, id, ip string) error {
+	query := `
+	update
+		sessions
+	set
+		last_used_at = now(), ip = $2
+	where
+		id = $1
+	`
+
+	_, err := s.DB.ExecContext(ctx, query, id, ip)
+	if err != nil {
+		return errors.Wrap(err, "session update failure")
+	}
+
+	return nil
+}
+
+// List returns the active sessions of the user, most recently used first
+func (s *SessionRepo) List(ctx context.Context, userID string) ([]models.Session, error) {
+	query := `
+	select
+		id, user_id, coalesce(device, ''), coalesce(user_agent, ''), coalesce(ip, ''), created_at, last_used_at
+	from
+		sessions
+	where
+		user_id = $1 and revoked_at is null
+	order by
+		last_used_at desc
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "session retrieval failure")
+	}
+	defer rows.Close()
+
+	var sessions []models.Session
+	for rows.Next() {
+		var (
+			session               models.Session
+			createdAt, lastUsedAt time.Time
+		)
+		err := rows.Scan(&session.ID, &session.UserID, &session.Device, &session.UserAgent, &session.IP, &createdAt, &lastUsedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "session scan failure")
+		}
+		session.CreatedAt = createdAt.Format(time.RFC3339)
+		session.LastUsedAt = lastUsedAt.Format(time.RFC3339)
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// Revoke ends one session of the user and revokes its refresh tokens
+func (s *SessionRepo) Revoke(ctx context.Context, userID, id string) error {
+	ids, err := s.revoke(ctx, `user_id = $1 and id = $2`, userID, id)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.New("session not found")
+	}
+
+	return nil
+}
+
+// RevokeOthers ends every session of the user except keepID and returns the IDs of the ended sessions
+func (s *SessionRepo) RevokeOthers(ctx context.Context, userID, keepID string) ([]string, error) {
+	return s.revoke(ctx, `user_id = $1 and id <> $2`, userID, keepID)
+}
+
+// RevokeAll ends every session of the user and returns the IDs of the ended sessions
+func (s *SessionRepo) RevokeAll(ctx context.Context, userID string) ([]string, error) {
+	return s.revoke(ctx, `user_id = $1`, userID)
+}
+
+// DeleteStale removes sessions that have no refresh tokens left
+func (s *SessionRepo) DeleteStale(ctx context.Context) (int64, error) {
+	query := `
+	delete from
+		sessions
+	where
+		not exists (select 1 from refresh_tokens where family_id = sessions.id)
+	`
+
+	res, err := s.DB.ExecContext(ctx, query)
+	if err != nil {
+		return 0, errors.Wrap(err, "stale session deletion failure")
+	}
+
+	return res.RowsAffected()
+}
+
+func (s *SessionRepo) revoke(ctx context.Context, where string, args ...interface{}) ([]string, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	update
+		sessions
+	set
+		revoked_at = now()
+	where
+		revoked_at is null and ` + where + `
+	returning id
+	`
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "session revocation failure")
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "session scan failure")
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "session revocation failure")
+	}
+
+	if len(ids) > 0 {
+		query = `
+		update
+			refresh_tokens
+		set
+			revoked_at = now()
+		where
+			family_id = any($1::uuid[]) and revoked_at is null
+		`
+		if _, err := tx.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+			return nil, errors.Wrap(err, "refresh token revocation failure")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return ids, nil
+}
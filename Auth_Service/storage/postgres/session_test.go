@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	revokeSessionsQuery = regexp.QuoteMeta(`update
+		sessions
+	set
+		revoked_at = now()`)
+	revokeSessionTokensQuery = regexp.QuoteMeta(`family_id = any($1::uuid[]) and revoked_at is null`)
+)
+
+func TestRevokeSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(r *SessionRepo) ([]string, error)
+		args    []driver.Value
+		revoked []string
+		errText string
+	}{
+		{
+			name: "Revoke",
+			call: func(r *SessionRepo) ([]string, error) {
+				return nil, r.Revoke(context.Background(), "user1", "session1")
+			},
+			args:    []driver.Value{"user1", "session1"},
+			revoked: []string{"session1"},
+		},
+		{
+			name: "RevokeUnknown",
+			call: func(r *SessionRepo) ([]string, error) {
+				return nil, r.Revoke(context.Background(), "user1", "session1")
+			},
+			args:    []driver.Value{"user1", "session1"},
+			errText: "session not found",
+		},
+		{
+			name: "RevokeOthers",
+			call: func(r *SessionRepo) ([]string, error) {
+				return r.RevokeOthers(context.Background(), "user1", "session1")
+			},
+			args:    []driver.Value{"user1", "session1"},
+			revoked: []string{"session2", "session3"},
+		},
+		{
+			name: "RevokeAll",
+			call: func(r *SessionRepo) ([]string, error) {
+				return r.RevokeAll(context.Background(), "user1")
+			},
+			args:    []driver.Value{"user1"},
+			revoked: []string{"session1", "session2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id"})
+			for _, id := range tt.revoked {
+				rows.AddRow(id)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery(revokeSessionsQuery).WithArgs(tt.args...).WillReturnRows(rows)
+			if len(tt.revoked) > 0 {
+				mock.ExpectExec(revokeSessionTokensQuery).WithArgs(pq.Array(tt.revoked)).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(tt.revoked))))
+			}
+			mock.ExpectCommit()
+
+			ids, err := tt.call(NewSessionRepo(db))
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+			} else {
+				require.NoError(t, err)
+			}
+			if ids != nil {
+				require.Equal(t, tt.revoked, ids)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeSessionsRollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(revokeSessionsQuery).WithArgs("user1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("session1"))
+	mock.ExpectExec(revokeSessionTokensQuery).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err = NewSessionRepo(db).RevokeAll(context.Background(), "user1")
+	require.ErrorContains(t, err, "refresh token revocation failure")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,9 +2,9 @@ package postgres
 
 import (
 	"Auth/models"
+	"Auth/storage"
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/pkg/errors"
 )
@@ -21,12 +21,12 @@ func NewTokenRepo(db *sql.DB) *TokenRepo {
 func (t *TokenRepo) Store(ctx context.Context, token *models.RefreshTokenDetails) error {
 	query := `
 	insert into
-		refresh_tokens (user_id, token, expires_at)
+		refresh_tokens (user_id, token, expires_at, family_id)
 	values
-		($1, $2, $3)
+		($1, $2, $3, $4)
 	`
 
-	_, err := t.DB.ExecContext(ctx, query, token.UserID, token.Token, token.Expiry)
+	_, err := t.DB.ExecContext(ctx, query, token.UserID, token.Token, token.Expiry, token.FamilyID)
 	if err != nil {
 		return errors.Wrap(err, "refresh token storage failure")
 	}
@@ -34,53 +34,171 @@ func (t *TokenRepo) Store(ctx context.Context, token *models.RefreshTokenDetails
 	return nil
 }
 
-// Delete removes a refresh token from the database based on the user's email
-func (t *TokenRepo) Delete(ctx context.Context, email string) error {
-	admin := AdminRepo{DB: t.DB}
-	fmt.Println("sdfasjfasdfhasuidfoiafdasfhgasdfsdgfyasudf")
-	id, _, err := admin.GetUserByEmail(ctx, email)
-	fmt.Println("assalom")
+// Validate checks if a given refresh token exists in the database, is not revoked and has not expired
+func (t *TokenRepo) Validate(ctx context.Context, token string) (bool, error) {
+	var n int
+	query := `
+	select
+		1
+	from
+		refresh_tokens
+	where
+		token = $1 and revoked_at is null and expires_at > now()
+	`
+
+	err := t.DB.QueryRowContext(ctx, query, token).Scan(&n)
 	if err != nil {
-		return errors.Wrap(err, "user not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, errors.New("token not found")
+		}
+		return false, errors.Wrap(err, "token retrieval failure")
 	}
 
+	return true, nil
+}
+
+// GetFamily returns the owner and the family ID of an active refresh token
+func (t *TokenRepo) GetFamily(ctx context.Context, token string) (string, string, error) {
+	var userID, familyID string
 	query := `
-	delete from
+	select
+		user_id, family_id
+	from
 		refresh_tokens
 	where
-		user_id = $1`
-	fmt.Println("salom")
-	res, err := t.DB.ExecContext(ctx, query, id)
-	if err != nil {
-		return errors.Wrap(err, "refresh token deletion failure")
-	}
+		token = $1 and revoked_at is null and expires_at > now()
+	`
 
-	if n, _ := res.RowsAffected(); n == 0 {
-		return errors.New("token not found")
+	err := t.DB.QueryRowContext(ctx, query, token).Scan(&userID, &familyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", errors.New("token not found")
+		}
+		return "", "", errors.Wrap(err, "token retrieval failure")
 	}
 
-	return nil
+	return userID, familyID, nil
 }
 
-// Validate checks if a given refresh token exists in the database and is valid
-func (t *TokenRepo) Validate(ctx context.Context, token string) (bool, error) {
-	var n int
+// Rotate revokes the old refresh token and stores next in the same family. If the
+// old token was already rotated, the token has been used twice: the whole family
+// is revoked and storage.ErrTokenReused is returned. Either way next.FamilyID is set
+// to the family of the old token.
+func (t *TokenRepo) Rotate(ctx context.Context, old string, next *models.RefreshTokenDetails) error {
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var (
+		id, userID, familyID string
+		revokedAt            sql.NullTime
+		replacedBy           sql.NullString
+		expired              bool
+	)
 	query := `
 	select
-		1
+		id, user_id, family_id, revoked_at, replaced_by, expires_at <= now()
 	from
 		refresh_tokens
 	where
 		token = $1
+	for update
 	`
-
-	err := t.DB.QueryRowContext(ctx, query, token).Scan(&n)
+	err = tx.QueryRowContext(ctx, query, old).Scan(&id, &userID, &familyID, &revokedAt, &replacedBy, &expired)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, errors.New("token not found")
+			return errors.New("token not found")
 		}
-		return false, errors.Wrap(err, "token retrieval failure")
+		return errors.Wrap(err, "token retrieval failure")
 	}
 
-	return true, nil
+	if revokedAt.Valid && replacedBy.Valid {
+		if _, err := tx.ExecContext(ctx, revokeFamilyQuery, familyID); err != nil {
+			return errors.Wrap(err, "token family revocation failure")
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "failed to commit transaction")
+		}
+		next.FamilyID = familyID
+		return storage.ErrTokenReused
+	}
+	if revokedAt.Valid {
+		return errors.New("token revoked")
+	}
+	if expired {
+		return errors.New("token expired")
+	}
+	if next.UserID != userID {
+		return errors.New("token does not belong to user")
+	}
+
+	var nextID string
+	query = `
+	insert into
+		refresh_tokens (user_id, token, expires_at, family_id)
+	values
+		($1, $2, $3, $4)
+	returning id
+	`
+	err = tx.QueryRowContext(ctx, query, next.UserID, next.Token, next.Expiry, familyID).Scan(&nextID)
+	if err != nil {
+		return errors.Wrap(err, "refresh token storage failure")
+	}
+
+	query = `
+	update
+		refresh_tokens
+	set
+		revoked_at = now(), replaced_by = $2
+	where
+		id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, id, nextID); err != nil {
+		return errors.Wrap(err, "refresh token revocation failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	next.FamilyID = familyID
+
+	return nil
+}
+
+const revokeFamilyQuery = `
+	update
+		refresh_tokens
+	set
+		revoked_at = now()
+	where
+		family_id = $1 and revoked_at is null
+	`
+
+// RevokeFamily revokes every still active refresh token of the given family
+func (t *TokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := t.DB.ExecContext(ctx, revokeFamilyQuery, familyID)
+	if err != nil {
+		return errors.Wrap(err, "token family revocation failure")
+	}
+
+	return nil
+}
+
+// DeleteExpired removes refresh tokens whose expiry has passed and returns how many were removed
+func (t *TokenRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `
+	delete from
+		refresh_tokens
+	where
+		expires_at <= now()
+	`
+
+	res, err := t.DB.ExecContext(ctx, query)
+	if err != nil {
+		return 0, errors.Wrap(err, "expired token deletion failure")
+	}
+
+	return res.RowsAffected()
 }
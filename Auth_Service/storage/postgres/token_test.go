@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"Auth/models"
+	"Auth/storage"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	selectTokenQuery = regexp.QuoteMeta(`from
+		refresh_tokens
+	where
+		token = $1
+	for update`)
+	insertTokenQuery = regexp.QuoteMeta(`insert into
+		refresh_tokens (user_id, token, expires_at, family_id)`)
+	replaceTokenQuery = regexp.QuoteMeta(`revoked_at = now(), replaced_by = $2`)
+	revokeFamilyExpr  = regexp.QuoteMeta(`family_id = $1 and revoked_at is null`)
+)
+
+func tokenRow(revokedAt, replacedBy interface{}, expired bool) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "user_id", "family_id", "revoked_at", "replaced_by", "expired"}).
+		AddRow("token1", "user1", "family1", revokedAt, replacedBy, expired)
+}
+
+func TestRotate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		userID  string
+		expect  func(mock sqlmock.Sqlmock)
+		err     error
+		errText string
+	}{
+		{
+			name:   "Rotated",
+			userID: "user1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnRows(tokenRow(nil, nil, false))
+				mock.ExpectQuery(insertTokenQuery).WithArgs("user1", "new", sqlmock.AnyArg(), "family1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("token2"))
+				mock.ExpectExec(replaceTokenQuery).WithArgs("token1", "token2").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "ReuseRevokesFamily",
+			userID: "user1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnRows(tokenRow(now, "token2", false))
+				mock.ExpectExec(revokeFamilyExpr).WithArgs("family1").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			err: storage.ErrTokenReused,
+		},
+		{
+			name:   "Revoked",
+			userID: "user1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnRows(tokenRow(now, nil, false))
+				mock.ExpectRollback()
+			},
+			errText: "token revoked",
+		},
+		{
+			name:   "Expired",
+			userID: "user1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnRows(tokenRow(nil, nil, true))
+				mock.ExpectRollback()
+			},
+			errText: "token expired",
+		},
+		{
+			name:   "OtherUser",
+			userID: "user2",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnRows(tokenRow(nil, nil, false))
+				mock.ExpectRollback()
+			},
+			errText: "token does not belong to user",
+		},
+		{
+			name:   "NotFound",
+			userID: "user1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectTokenQuery).WithArgs("old").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			errText: "token not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			next := &models.RefreshTokenDetails{UserID: tt.userID, Token: "new", Expiry: now.Add(time.Hour).Format(time.RFC3339)}
+			err = NewTokenRepo(db).Rotate(context.Background(), "old", next)
+			switch {
+			case tt.err != nil:
+				require.ErrorIs(t, err, tt.err)
+				require.Equal(t, "family1", next.FamilyID, "the revoked family is reported")
+			case tt.errText != "":
+				require.EqualError(t, err, tt.errText)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, "family1", next.FamilyID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeFamily(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(revokeFamilyExpr).WithArgs("family1").WillReturnResult(sqlmock.NewResult(0, 3))
+	require.NoError(t, NewTokenRepo(db).RevokeFamily(context.Background(), "family1"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
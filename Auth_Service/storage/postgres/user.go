@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -31,6 +32,9 @@ func (u *UserRepo) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.U
 	err := u.DB.QueryRowContext(ctx, query, req.Email, req.Password, req.FirstName, req.LastName, req.PhoneNumber, req.Role).
 		Scan(&user.Id, &user.CreatedAt)
 	if err != nil {
+		if isForeignKeyViolation(err, "users_role_fkey") {
+			return nil, storage.ErrUnknownRole
+		}
 		return nil, err
 	}
 
@@ -81,7 +85,7 @@ func (u *UserRepo) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRe
 // GetProfile fetches the user profile by user ID
 func (u *UserRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileResponse, error) {
 	query := `
-		SELECT id, email, first_name, last_name, phone_number, role, created_at
+		SELECT id, coalesce(email, ''), first_name, last_name, coalesce(phone_number, ''), role, created_at, phone_verified
 		FROM Users
 		WHERE id = $1`
 	var user pb.GetProfileResponse
@@ -94,6 +98,7 @@ func (u *UserRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileRes
 		&user.PhoneNumber,
 		&user.Role,
 		&user.CreatedAt,
+		&user.PhoneVerified,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -107,7 +112,7 @@ func (u *UserRepo) GetProfile(ctx context.Context, id *pb.Id) (*pb.GetProfileRes
 func (u *UserRepo) GetUserByEmail(ctx context.Context, email string) (string, string, error) {
 	query := `
 	SELECT
-		id, password_hash
+		id, coalesce(password_hash, '')
 	FROM
 		users
 	WHERE
@@ -128,9 +133,10 @@ func (u *UserRepo) GetUserByEmail(ctx context.Context, email string) (string, st
 func (u *UserRepo) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequestU) (*pb.UserResponseU, error) {
 	query := `
 		UPDATE Users
-		SET first_name = $1, last_name = $2, phone_number = $3, updated_at = $4
+		SET first_name = $1, last_name = $2, phone_number = $3, updated_at = $4,
+			phone_verified = phone_verified and phone_number is not distinct from $3
 		WHERE id = $5
-		RETURNING id, email, first_name, last_name, phone_number, created_at`
+		RETURNING id, coalesce(email, ''), first_name, last_name, coalesce(phone_number, ''), created_at`
 
 	var user pb.UserResponseU
 	err := u.DB.QueryRowContext(ctx, query, req.FirstName, req.LastName, req.PhoneNumber, time.Now(), req.UserId).
@@ -172,7 +178,7 @@ func (u *UserRepo) GetRole(ctx context.Context, email string) (string, error) {
 func (u *UserRepo) GetUserByID(ctx context.Context, id *pb.Id) (string, string, error) {
 	query := `
 	SELECT
-		email, password_hash
+		coalesce(email, ''), coalesce(password_hash, '')
 	FROM
 		users
 	WHERE
@@ -187,3 +193,144 @@ func (u *UserRepo) GetUserByID(ctx context.Context, id *pb.Id) (string, string,
 
 	return email, passwordHash, nil
 }
+
+// IsEmailVerified tells whether the user confirmed their email address
+func (u *UserRepo) IsEmailVerified(ctx context.Context, id string) (bool, error) {
+	query := `
+	SELECT
+		email_verified
+	FROM
+		users
+	WHERE
+		id = $1 and deleted_at = 0`
+
+	var verified bool
+	err := u.DB.QueryRowContext(ctx, query, id).Scan(&verified)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, errors.New("user not found")
+		}
+		return false, err
+	}
+
+	return verified, nil
+}
+
+// UpdatePassword replaces the password hash of the user
+func (u *UserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	query := `
+	UPDATE
+		users
+	SET
+		password_hash = $2, updated_at = now()
+	WHERE
+		id = $1 and deleted_at = 0`
+
+	res, err := u.DB.ExecContext(ctx, query, id, passwordHash)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// GetUserByPhone returns the ID of the live user who verified the phone number
+func (u *UserRepo) GetUserByPhone(ctx context.Context, phone string) (string, error) {
+	query := `
+	SELECT
+		id
+	FROM
+		users
+	WHERE
+		phone_number = $1 and phone_verified and deleted_at = 0`
+
+	var id string
+	err := u.DB.QueryRowContext(ctx, query, phone).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.New("user not found")
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+// RegisterByPhone creates a customer who signed up with a verified phone number
+// and has no email or password
+func (u *UserRepo) RegisterByPhone(ctx context.Context, phone, firstName, lastName string) (*pb.UserResponse, error) {
+	query := `
+		INSERT INTO Users (first_name, last_name, phone_number, phone_verified, role)
+		VALUES ($1, $2, $3, true, 'customer')
+		RETURNING id, created_at`
+
+	user := pb.UserResponse{
+		FirstName:   firstName,
+		LastName:    lastName,
+		PhoneNumber: phone,
+		Role:        "customer",
+	}
+	err := u.DB.QueryRowContext(ctx, query, firstName, lastName, phone).Scan(&user.Id, &user.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, storage.ErrPhoneTaken
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetPhoneVerified sets the phone number of the user and marks it as verified
+func (u *UserRepo) SetPhoneVerified(ctx context.Context, id, phone string) error {
+	query := `
+	UPDATE
+		users
+	SET
+		phone_number = $2, phone_verified = true, updated_at = now()
+	WHERE
+		id = $1 and deleted_at = 0`
+
+	res, err := u.DB.ExecContext(ctx, query, id, phone)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrPhoneTaken
+		}
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// MarkEmailVerified marks the email of the user as verified, e.g. when an identity
+// provider vouched for it
+func (u *UserRepo) MarkEmailVerified(ctx context.Context, id string) error {
+	query := `
+	UPDATE
+		users
+	SET
+		email_verified = true, updated_at = now()
+	WHERE
+		id = $1 and deleted_at = 0`
+
+	if _, err := u.DB.ExecContext(ctx, query, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == constraint
+}
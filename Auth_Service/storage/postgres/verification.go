@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxVerificationAttempts is how many wrong codes invalidate a verification code.
+const MaxVerificationAttempts = 5
+
+type VerificationRepo struct {
+	DB *sql.DB
+}
+
+func NewVerificationRepo(db *sql.DB) *VerificationRepo {
+	return &VerificationRepo{DB: db}
+}
+
+// Create stores a new verification code for the user and drops the previous unused ones
+func (v *VerificationRepo) Create(ctx context.Context, userID, codeHash string, expiresAt time.Time) error {
+	tx, err := v.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	delete from
+		email_verifications
+	where
+		user_id = $1 and used_at is null
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return errors.Wrap(err, "verification code deletion failure")
+	}
+
+	query = `
+	insert into
+		email_verifications (user_id, code_hash, expires_at)
+	values
+		($1, $2, $3)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, codeHash, expiresAt); err != nil {
+		return errors.Wrap(err, "verification code storage failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// LastSentAt returns when the latest verification code of the user was created
+func (v *VerificationRepo) LastSentAt(ctx context.Context, userID string) (time.Time, error) {
+	var sentAt sql.NullTime
+	query := `
+	select
+		max(created_at)
+	from
+		email_verifications
+	where
+		user_id = $1
+	`
+
+	if err := v.DB.QueryRowContext(ctx, query, userID).Scan(&sentAt); err != nil {
+		return time.Time{}, errors.Wrap(err, "verification code retrieval failure")
+	}
+	return sentAt.Time, nil
+}
+
+// Verify consumes the user's active code if it matches and marks the email as verified.
+// Every wrong guess counts against the code, which stops working after MaxVerificationAttempts.
+func (v *VerificationRepo) Verify(ctx context.Context, userID, codeHash string) error {
+	tx, err := v.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var (
+		id, hash string
+		attempts int
+	)
+	query := `
+	select
+		id, code_hash, attempts
+	from
+		email_verifications
+	where
+		user_id = $1 and used_at is null and expires_at > now() and attempts < $2
+	order by
+		created_at desc
+	limit 1
+	for update
+	`
+	err = tx.QueryRowContext(ctx, query, userID, MaxVerificationAttempts).Scan(&id, &hash, &attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("invalid or expired code")
+		}
+		return errors.Wrap(err, "verification code retrieval failure")
+	}
+
+	if hash != codeHash {
+		query = `
+		update
+			email_verifications
+		set
+			attempts = attempts + 1
+		where
+			id = $1
+		`
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return errors.Wrap(err, "verification attempt update failure")
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "failed to commit transaction")
+		}
+		return errors.New("invalid or expired code")
+	}
+
+	query = `
+	update
+		email_verifications
+	set
+		used_at = now()
+	where
+		id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return errors.Wrap(err, "verification code update failure")
+	}
+
+	query = `
+	update
+		users
+	set
+		email_verified = true, updated_at = now()
+	where
+		id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return errors.Wrap(err, "user update failure")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	activeCodeQuery   = regexp.QuoteMeta(`user_id = $1 and used_at is null and expires_at > now() and attempts < $2`)
+	countAttemptQuery = regexp.QuoteMeta(`attempts = attempts + 1`)
+	useCodeQuery      = regexp.QuoteMeta(`used_at = now()`)
+	markVerifiedQuery = regexp.QuoteMeta(`email_verified = true, updated_at = now()`)
+	activeCodeColumns = []string{"id", "code_hash", "attempts"}
+)
+
+func TestVerifyEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		errText string
+	}{
+		{
+			name: "Verified",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(activeCodeQuery).WithArgs("user1", MaxVerificationAttempts).
+					WillReturnRows(sqlmock.NewRows(activeCodeColumns).AddRow("code1", "hash", 0))
+				mock.ExpectExec(useCodeQuery).WithArgs("code1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(markVerifiedQuery).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "WrongCode",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(activeCodeQuery).WithArgs("user1", MaxVerificationAttempts).
+					WillReturnRows(sqlmock.NewRows(activeCodeColumns).AddRow("code1", "other", 2))
+				mock.ExpectExec(countAttemptQuery).WithArgs("code1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			errText: "invalid or expired code",
+		},
+		{
+			name: "UsedExpiredOrExhausted",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(activeCodeQuery).WithArgs("user1", MaxVerificationAttempts).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			errText: "invalid or expired code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			err = NewVerificationRepo(db).Verify(context.Background(), "user1", "hash")
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package Redis
+
+import (
+	"Auth/config"
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectRedis returns a client for the configured Redis, or nil when it cannot be reached.
+func ConnectRedis(cfg *config.Config) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.REDIS_ADDR,
+		Password: cfg.REDIS_PASSWORD,
+		DB:       cfg.REDIS_DB,
+	})
+	_, err := client.Ping(context.Background()).Result()
+	if err != nil {
+		return nil
+	}
+	return client
+}
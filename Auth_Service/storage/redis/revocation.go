@@ -0,0 +1,85 @@
+package Redis
+
+import (
+	"Auth/storage"
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const revokedPrefix = "revoked:"
+
+// RevocationRepo stores access-token revocations in Redis so that every Auth
+// instance sees them. Every revocation is also written to the fallback store,
+// which answers on its own whenever Redis cannot be reached.
+type RevocationRepo struct {
+	client   *redis.Client
+	fallback storage.IRevocationStorage
+	logger   *slog.Logger
+}
+
+// NewRevocationRepo returns a Redis-backed store. A nil client makes it use the
+// fallback only.
+func NewRevocationRepo(client *redis.Client, fallback storage.IRevocationStorage, logger *slog.Logger) *RevocationRepo {
+	return &RevocationRepo{client: client, fallback: fallback, logger: logger}
+}
+
+func (r *RevocationRepo) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	r.fallback.RevokeToken(ctx, jti, ttl)
+	return r.set(ctx, "jti:"+jti, 1, ttl)
+}
+
+func (r *RevocationRepo) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	r.fallback.RevokeSession(ctx, sessionID, ttl)
+	return r.set(ctx, "sid:"+sessionID, 1, ttl)
+}
+
+// RevokeUser revokes every token of the user issued before at.
+func (r *RevocationRepo) RevokeUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	r.fallback.RevokeUser(ctx, userID, at, ttl)
+	return r.set(ctx, "user:"+userID, at.Unix(), ttl)
+}
+
+func (r *RevocationRepo) IsRevoked(ctx context.Context, jti, sessionID, userID string, issuedAt int64) (bool, string, error) {
+	if r.client == nil {
+		return r.fallback.IsRevoked(ctx, jti, sessionID, userID, issuedAt)
+	}
+
+	keys := []string{revokedPrefix + "jti:" + jti, revokedPrefix + "sid:" + sessionID, revokedPrefix + "user:" + userID}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Error("failed to read revocations from redis, using in-memory store", "error", err)
+		return r.fallback.IsRevoked(ctx, jti, sessionID, userID, issuedAt)
+	}
+
+	if jti != "" && values[0] != nil {
+		return true, "token revoked", nil
+	}
+	if sessionID != "" && values[1] != nil {
+		return true, "session revoked", nil
+	}
+	if s, ok := values[2].(string); ok {
+		cutoff, _ := strconv.ParseInt(s, 10, 64)
+		if issuedAt < cutoff {
+			return true, "user tokens revoked", nil
+		}
+	}
+
+	return r.fallback.IsRevoked(ctx, jti, sessionID, userID, issuedAt)
+}
+
+func (r *RevocationRepo) set(ctx context.Context, key string, value int64, ttl time.Duration) error {
+	if r.client == nil {
+		return nil
+	}
+
+	if err := r.client.Set(ctx, revokedPrefix+key, value, ttl).Err(); err != nil {
+		r.logger.Error("failed to store revocation in redis, kept in memory only", "key", key, "error", err)
+		return errors.Wrap(err, "revocation storage failure")
+	}
+	return nil
+}
@@ -4,10 +4,61 @@ import (
 	pb "Auth/genproto/users"
 	"Auth/models"
 	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrTokenReused is returned when a refresh token that was already rotated is
+// presented again. The whole token family is revoked when this happens.
+var ErrTokenReused = errors.New("refresh token reuse detected")
+
+var (
+	// ErrMFAEnabled is returned when enrolling or confirming MFA that is already enabled.
+	ErrMFAEnabled = errors.New("mfa is already enabled")
+	// ErrCodeReused is returned when a TOTP code of an already used time step is presented.
+	ErrCodeReused = errors.New("code was already used")
+	// ErrInvalidCode is returned for a wrong, used or expired one-time code.
+	ErrInvalidCode = errors.New("invalid or expired code")
+	// ErrPhoneTaken is returned when the phone number is verified on another account.
+	ErrPhoneTaken = errors.New("phone number belongs to another account")
+	// ErrIdentityTaken is returned when the provider account is linked to another
+	// user, or the user already linked an account of the provider.
+	ErrIdentityTaken    = errors.New("identity is already linked")
+	ErrIdentityNotFound = errors.New("identity not found")
+	// ErrInvalidState is returned for an unknown, used or expired sign-in state.
+	ErrInvalidState = errors.New("invalid or expired sign-in state")
+	// ErrUnknownRole is returned when a user is given a role that does not exist.
+	ErrUnknownRole = errors.New("unknown role")
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNotOrgMember         = errors.New("user is not a member of the organization")
+	ErrAlreadyOrgMember     = errors.New("user is already a member of the organization")
+	// ErrLastOwner is returned when the only owner of an organization would be
+	// demoted or removed.
+	ErrLastOwner = errors.New("organization must keep at least one owner")
+	// ErrInvalidInvitation is returned for an unknown, accepted or expired invitation.
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	// ErrInvitationEmail is returned when the invitation was sent to another address
+	// than the verified email of the accepting user.
+	ErrInvitationEmail = errors.New("invitation was sent to another email")
 )
 
 type IStorage interface {
 	Token() ITokenStorage
+	Session() ISessionStorage
+	Revocation() IRevocationStorage
+	Verification() IVerificationStorage
+	PasswordReset() IPasswordResetStorage
+	LoginFailure() ILoginFailureStorage
+	SecurityEvent() ISecurityEventStorage
+	MFA() IMFAStorage
+	PhoneOTP() IPhoneOTPStorage
+	Identity() IIdentityStorage
+	OIDCState() IOIDCStateStorage
+	Organization() IOrganizationStorage
 	Admin() IAdminStorage
 	User() IUserStorage
 	Close()
@@ -21,6 +72,12 @@ type IUserStorage interface {
 	GetRole(ctx context.Context, email string) (string, error)
 	GetUserByID(ctx context.Context, id *pb.Id) (string, string, error)
 	UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequestU) (*pb.UserResponseU, error)
+	IsEmailVerified(ctx context.Context, id string) (bool, error)
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	GetUserByPhone(ctx context.Context, phone string) (string, error)
+	RegisterByPhone(ctx context.Context, phone, firstName, lastName string) (*pb.UserResponse, error)
+	SetPhoneVerified(ctx context.Context, id, phone string) error
+	MarkEmailVerified(ctx context.Context, id string) error
 }
 
 type IAdminStorage interface {
@@ -33,6 +90,96 @@ type IAdminStorage interface {
 
 type ITokenStorage interface {
 	Store(ctx context.Context, token *models.RefreshTokenDetails) error
-	Delete(ctx context.Context, email string) error
 	Validate(ctx context.Context, token string) (bool, error)
+	GetFamily(ctx context.Context, token string) (string, string, error)
+	Rotate(ctx context.Context, old string, next *models.RefreshTokenDetails) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type ISessionStorage interface {
+	Create(ctx context.Context, session *models.Session, token *models.RefreshTokenDetails) error
+	Touch(ctx context.Context, id, ip string) error
+	List(ctx context.Context, userID string) ([]models.Session, error)
+	Revoke(ctx context.Context, userID, id string) error
+	RevokeOthers(ctx context.Context, userID, keepID string) ([]string, error)
+	RevokeAll(ctx context.Context, userID string) ([]string, error)
+	DeleteStale(ctx context.Context) (int64, error)
+}
+
+// IRevocationStorage keeps access tokens that must be rejected before they expire.
+// Entries live for ttl, which should be at least the access token lifetime.
+type IRevocationStorage interface {
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+	RevokeUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error
+	IsRevoked(ctx context.Context, jti, sessionID, userID string, issuedAt int64) (bool, string, error)
+}
+
+type IVerificationStorage interface {
+	Create(ctx context.Context, userID, codeHash string, expiresAt time.Time) error
+	LastSentAt(ctx context.Context, userID string) (time.Time, error)
+	Verify(ctx context.Context, userID, codeHash string) error
+}
+
+type IPasswordResetStorage interface {
+	Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	Reset(ctx context.Context, tokenHash, passwordHash string) (string, error)
+}
+
+type ILoginFailureStorage interface {
+	Get(ctx context.Context, key string) (*models.LoginFailure, error)
+	RecordFailure(ctx context.Context, key string, window time.Duration) (*models.LoginFailure, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type ISecurityEventStorage interface {
+	Save(ctx context.Context, event *pb.SecurityEvent) error
+	List(ctx context.Context, req *pb.SecurityEventFilter) (*pb.SecurityEvents, error)
+}
+
+type IMFAStorage interface {
+	Get(ctx context.Context, userID string) (*models.MFA, error)
+	SavePending(ctx context.Context, userID, secret string) error
+	Enable(ctx context.Context, userID string, step int64, codeHashes []string) error
+	UseStep(ctx context.Context, userID string, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+	Disable(ctx context.Context, userID string) error
+}
+
+type IPhoneOTPStorage interface {
+	Create(ctx context.Context, phone, codeHash, ip string, expiresAt time.Time) error
+	Sent(ctx context.Context, phone, ip string, since time.Time) (time.Time, int, int, error)
+	Verify(ctx context.Context, phone, codeHash string) error
+}
+
+type IIdentityStorage interface {
+	Link(ctx context.Context, userID, provider, subject, email string) error
+	Find(ctx context.Context, provider, subject string) (string, error)
+	List(ctx context.Context, userID string) ([]models.Identity, error)
+	Unlink(ctx context.Context, userID, provider string) error
+}
+
+type IOIDCStateStorage interface {
+	Save(ctx context.Context, state *models.OIDCState) error
+	Take(ctx context.Context, stateHash, provider string) (*models.OIDCState, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type IOrganizationStorage interface {
+	Create(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.OrganizationResponse, error)
+	Get(ctx context.Context, id string) (*pb.OrganizationResponse, error)
+	ListByUser(ctx context.Context, userID string) (*pb.OrganizationResponses, error)
+	GetMember(ctx context.Context, orgID, userID string) (*pb.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID string) (*pb.OrganizationMembers, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID, role string) (*pb.OrganizationMember, error)
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	IsMemberEmail(ctx context.Context, orgID, email string) (bool, error)
+	CreateInvitation(ctx context.Context, inv *models.Invitation) (*pb.Invitation, error)
+	ListInvitations(ctx context.Context, orgID string) (*pb.Invitations, error)
+	RevokeInvitation(ctx context.Context, orgID, id string) error
+	AcceptInvitation(ctx context.Context, tokenHash, userID, email string) (string, error)
 }
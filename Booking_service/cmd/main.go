@@ -12,7 +12,10 @@ import (
 
 	//"product-service/pkg"
 	messagebroker "Booking_Service/messagebroker"
+	"Booking_Service/pkg/blob"
 	"Booking_Service/pkg/logger"
+	"Booking_Service/pkg/payment"
+	"Booking_Service/pkg/quote"
 	"Booking_Service/service"
 	mongodb "Booking_Service/storage/mongodb"
 
@@ -33,13 +36,25 @@ func main() {
 	}
 	defer lis.Close()
 	loggers := logger.NewLogger()
-	b := service.NewBookingService(db, loggers)
+	quotes := quote.NewSigner(cfg.QUOTE_SECRET, cfg.QUOTE_TTL)
+	// The payment provider is shared by both servers: the mock keeps its transactions in memory.
+	payments, err := payment.New(cfg, loggers)
+	if err != nil {
+		log.Fatalf("error while opening payment provider: %v", err)
+	}
+	p := service.NewPaymentService(db, payments, loggers)
+	b := service.NewBookingService(db, quotes, p, loggers)
 	s := service.NewManagementService(db, loggers)
-	p := service.NewPaymentService(db, loggers)
 	r := service.NewReviewService(db, loggers)
-	pr := service.NewProviderService(db, loggers)
+	blobs, err := blob.New(cfg)
+	if err != nil {
+		log.Fatalf("error while opening blob store: %v", err)
+	}
+	pr := service.NewProviderService(db, blobs, cfg.MAX_DOCUMENT_SIZE, loggers)
 	sr := service.NewSearchService(db, loggers)
 	n := service.NewNotificationService(db)
+	o := service.NewOperationService(db, loggers)
+	pc := service.NewPricingService(db, loggers)
 	server := grpc.NewServer()
 
 	pb.RegisterBookingServiceServer(server, b)
@@ -49,6 +64,8 @@ func main() {
 	pb.RegisterProviderServiceServer(server, pr)
 	pb.RegisterSearchingServiceServer(server, sr)
 	pb.RegisterNotificationsServer(server, n)
+	pb.RegisterOperationServiceServer(server, o)
+	pb.RegisterPricingServiceServer(server, pc)
 
 	conn, err := amqp.Dial(cfg.RABBIT)
 	if err != nil {
@@ -64,14 +81,18 @@ func main() {
 	}
 	defer ch.Close()
 
-	servis := grpc.NewServer()
+	retry := messagebroker.RetryPolicy{MaxAttempts: cfg.MAX_ATTEMPTS, BaseDelay: cfg.RETRY_BASE_DELAY}
+	d := service.NewDeadLetterService(db, messagebroker.NewRequeuer(ch), loggers)
+	pb.RegisterDeadLetterServiceServer(server, d)
 
-	bService := service.NewBookingService(db, loggers)
-	pb.RegisterBookingServiceServer(servis, bService)
+	servis := grpc.NewServer()
 
-	pService := service.NewPaymentService(db, loggers)
+	pService := service.NewPaymentService(db, payments, loggers)
 	pb.RegisterPaymentServiceServer(servis, pService)
 
+	bService := service.NewBookingService(db, quotes, pService, loggers)
+	pb.RegisterBookingServiceServer(servis, bService)
+
 	rService := service.NewReviewService(db, loggers)
 	pb.RegisterReviewServiceServer(servis, rService)
 
@@ -103,6 +124,15 @@ func main() {
 		log.Println(err)
 	}
 
+	for _, q := range []amqp.Queue{notQueue, crtQueue, delQueue, payQueue, revQueue} {
+		if q.Name == "" {
+			continue
+		}
+		if err := messagebroker.DeclareRetryTopology(ch, q.Name, retry); err != nil {
+			log.Println(err)
+		}
+	}
+
 	notMsg, err := getMessageQueue(ch, notQueue)
 	if err != nil {
 		log.Println(err)
@@ -128,8 +158,9 @@ func main() {
 		log.Println(err)
 	}
 
-	res := messagebroker.New(bService, pService, rService, nService, ch, loggers, crtMsg, delMsg, payMsg, revMsg, notMsg, &sync.WaitGroup{}, 6)
+	res := messagebroker.New(bService, pService, rService, nService, o, d, ch, loggers, crtMsg, delMsg, payMsg, revMsg, notMsg, &sync.WaitGroup{}, 6, retry)
 	go res.StartToConsume(context.Background())
+	go bService.RunAcceptanceTimeout(context.Background(), cfg.ACCEPT_TIMEOUT, cfg.ACCEPT_CHECK_INTERVAL)
 
 	log.Printf("Service is listening on port %s...\n", cfg.BOOKING_SERVICE_PORT)
 	if err := server.Serve(lis); err != nil {
@@ -143,6 +174,8 @@ func failOnError(err error, msg string) {
 	}
 }
 
+// getQueue declares a work queue. Messages the consumer rejects go to the queue's
+// dead-letter exchange.
 func getQueue(ch *amqp.Channel, queueName string) (amqp.Queue, error) {
 	return ch.QueueDeclare(
 		queueName,
@@ -150,7 +183,7 @@ func getQueue(ch *amqp.Channel, queueName string) (amqp.Queue, error) {
 		false,
 		false,
 		false,
-		nil,
+		amqp.Table{"x-dead-letter-exchange": messagebroker.DeadLetterExchange(queueName)},
 	)
 }
 
@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ProviderService_RegisterProvider_FullMethodName = "/booking.ProviderService/RegisterProvider"
-	ProviderService_GetProvider_FullMethodName      = "/booking.ProviderService/GetProvider"
-	ProviderService_UpdateProvider_FullMethodName   = "/booking.ProviderService/UpdateProvider"
-	ProviderService_DeleteProvider_FullMethodName   = "/booking.ProviderService/DeleteProvider"
-	ProviderService_ListProviders_FullMethodName    = "/booking.ProviderService/ListProviders"
+	ProviderService_RegisterProvider_FullMethodName         = "/booking.ProviderService/RegisterProvider"
+	ProviderService_GetProvider_FullMethodName              = "/booking.ProviderService/GetProvider"
+	ProviderService_UpdateProvider_FullMethodName           = "/booking.ProviderService/UpdateProvider"
+	ProviderService_DeleteProvider_FullMethodName           = "/booking.ProviderService/DeleteProvider"
+	ProviderService_ListProviders_FullMethodName            = "/booking.ProviderService/ListProviders"
+	ProviderService_UploadProviderDocument_FullMethodName   = "/booking.ProviderService/UploadProviderDocument"
+	ProviderService_GetProviderDocument_FullMethodName      = "/booking.ProviderService/GetProviderDocument"
+	ProviderService_ListProviderApplications_FullMethodName = "/booking.ProviderService/ListProviderApplications"
+	ProviderService_ReviewProvider_FullMethodName           = "/booking.ProviderService/ReviewProvider"
 )
 
 // ProviderServiceClient is the client API for ProviderService service.
@@ -35,6 +39,10 @@ type ProviderServiceClient interface {
 	UpdateProvider(ctx context.Context, in *UpdateProviderRequest, opts ...grpc.CallOption) (*ProviderResponse, error)
 	DeleteProvider(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Void, error)
 	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	UploadProviderDocument(ctx context.Context, in *UploadProviderDocumentRequest, opts ...grpc.CallOption) (*ProviderDocument, error)
+	GetProviderDocument(ctx context.Context, in *ProviderDocumentRequest, opts ...grpc.CallOption) (*ProviderDocumentContent, error)
+	ListProviderApplications(ctx context.Context, in *ListProviderApplicationsRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	ReviewProvider(ctx context.Context, in *ReviewProviderRequest, opts ...grpc.CallOption) (*ProviderResponse, error)
 }
 
 type providerServiceClient struct {
@@ -95,6 +103,46 @@ func (c *providerServiceClient) ListProviders(ctx context.Context, in *ListProvi
 	return out, nil
 }
 
+func (c *providerServiceClient) UploadProviderDocument(ctx context.Context, in *UploadProviderDocumentRequest, opts ...grpc.CallOption) (*ProviderDocument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderDocument)
+	err := c.cc.Invoke(ctx, ProviderService_UploadProviderDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) GetProviderDocument(ctx context.Context, in *ProviderDocumentRequest, opts ...grpc.CallOption) (*ProviderDocumentContent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderDocumentContent)
+	err := c.cc.Invoke(ctx, ProviderService_GetProviderDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) ListProviderApplications(ctx context.Context, in *ListProviderApplicationsRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, ProviderService_ListProviderApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) ReviewProvider(ctx context.Context, in *ReviewProviderRequest, opts ...grpc.CallOption) (*ProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderResponse)
+	err := c.cc.Invoke(ctx, ProviderService_ReviewProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProviderServiceServer is the server API for ProviderService service.
 // All implementations must embed UnimplementedProviderServiceServer
 // for forward compatibility
@@ -104,6 +152,10 @@ type ProviderServiceServer interface {
 	UpdateProvider(context.Context, *UpdateProviderRequest) (*ProviderResponse, error)
 	DeleteProvider(context.Context, *IdRequest) (*Void, error)
 	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	UploadProviderDocument(context.Context, *UploadProviderDocumentRequest) (*ProviderDocument, error)
+	GetProviderDocument(context.Context, *ProviderDocumentRequest) (*ProviderDocumentContent, error)
+	ListProviderApplications(context.Context, *ListProviderApplicationsRequest) (*ListProvidersResponse, error)
+	ReviewProvider(context.Context, *ReviewProviderRequest) (*ProviderResponse, error)
 	mustEmbedUnimplementedProviderServiceServer()
 }
 
@@ -126,6 +178,18 @@ func (UnimplementedProviderServiceServer) DeleteProvider(context.Context, *IdReq
 func (UnimplementedProviderServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
 }
+func (UnimplementedProviderServiceServer) UploadProviderDocument(context.Context, *UploadProviderDocumentRequest) (*ProviderDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadProviderDocument not implemented")
+}
+func (UnimplementedProviderServiceServer) GetProviderDocument(context.Context, *ProviderDocumentRequest) (*ProviderDocumentContent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviderDocument not implemented")
+}
+func (UnimplementedProviderServiceServer) ListProviderApplications(context.Context, *ListProviderApplicationsRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviderApplications not implemented")
+}
+func (UnimplementedProviderServiceServer) ReviewProvider(context.Context, *ReviewProviderRequest) (*ProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewProvider not implemented")
+}
 func (UnimplementedProviderServiceServer) mustEmbedUnimplementedProviderServiceServer() {}
 
 // UnsafeProviderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -229,6 +293,78 @@ func _ProviderService_ListProviders_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProviderService_UploadProviderDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadProviderDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).UploadProviderDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_UploadProviderDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).UploadProviderDocument(ctx, req.(*UploadProviderDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_GetProviderDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProviderDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).GetProviderDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_GetProviderDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).GetProviderDocument(ctx, req.(*ProviderDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_ListProviderApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProviderApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).ListProviderApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_ListProviderApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).ListProviderApplications(ctx, req.(*ListProviderApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_ReviewProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).ReviewProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_ReviewProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).ReviewProvider(ctx, req.(*ReviewProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProviderService_ServiceDesc is the grpc.ServiceDesc for ProviderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -256,17 +392,45 @@ var ProviderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProviders",
 			Handler:    _ProviderService_ListProviders_Handler,
 		},
+		{
+			MethodName: "UploadProviderDocument",
+			Handler:    _ProviderService_UploadProviderDocument_Handler,
+		},
+		{
+			MethodName: "GetProviderDocument",
+			Handler:    _ProviderService_GetProviderDocument_Handler,
+		},
+		{
+			MethodName: "ListProviderApplications",
+			Handler:    _ProviderService_ListProviderApplications_Handler,
+		},
+		{
+			MethodName: "ReviewProvider",
+			Handler:    _ProviderService_ReviewProvider_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "booking.proto",
 }
 
 const (
-	BookingService_CreateBooking_FullMethodName = "/booking.BookingService/CreateBooking"
-	BookingService_GetBooking_FullMethodName    = "/booking.BookingService/GetBooking"
-	BookingService_UpdateBooking_FullMethodName = "/booking.BookingService/UpdateBooking"
-	BookingService_CancelBooking_FullMethodName = "/booking.BookingService/CancelBooking"
-	BookingService_ListBookings_FullMethodName  = "/booking.BookingService/ListBookings"
+	BookingService_CreateBooking_FullMethodName           = "/booking.BookingService/CreateBooking"
+	BookingService_GetBooking_FullMethodName              = "/booking.BookingService/GetBooking"
+	BookingService_UpdateBooking_FullMethodName           = "/booking.BookingService/UpdateBooking"
+	BookingService_CancelBooking_FullMethodName           = "/booking.BookingService/CancelBooking"
+	BookingService_ListBookings_FullMethodName            = "/booking.BookingService/ListBookings"
+	BookingService_ConfirmBooking_FullMethodName          = "/booking.BookingService/ConfirmBooking"
+	BookingService_RejectBooking_FullMethodName           = "/booking.BookingService/RejectBooking"
+	BookingService_MarkEnRoute_FullMethodName             = "/booking.BookingService/MarkEnRoute"
+	BookingService_StartBooking_FullMethodName            = "/booking.BookingService/StartBooking"
+	BookingService_CompleteBooking_FullMethodName         = "/booking.BookingService/CompleteBooking"
+	BookingService_MarkNoShow_FullMethodName              = "/booking.BookingService/MarkNoShow"
+	BookingService_ListIncomingBookings_FullMethodName    = "/booking.BookingService/ListIncomingBookings"
+	BookingService_AcceptBooking_FullMethodName           = "/booking.BookingService/AcceptBooking"
+	BookingService_DeclineBooking_FullMethodName          = "/booking.BookingService/DeclineBooking"
+	BookingService_GetAvailableSlots_FullMethodName       = "/booking.BookingService/GetAvailableSlots"
+	BookingService_QuoteBooking_FullMethodName            = "/booking.BookingService/QuoteBooking"
+	BookingService_OverrideCancellationFee_FullMethodName = "/booking.BookingService/OverrideCancellationFee"
 )
 
 // BookingServiceClient is the client API for BookingService service.
@@ -276,8 +440,20 @@ type BookingServiceClient interface {
 	CreateBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*BookingResponse, error)
 	GetBooking(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*BookingResponse, error)
 	UpdateBooking(ctx context.Context, in *UpdateBookingRequest, opts ...grpc.CallOption) (*BookingResponse, error)
-	CancelBooking(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	CancelBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
 	ListBookings(ctx context.Context, in *ListBookingsRequest, opts ...grpc.CallOption) (*ListBookingsResponse, error)
+	ConfirmBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	RejectBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	MarkEnRoute(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	StartBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	CompleteBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	MarkNoShow(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	ListIncomingBookings(ctx context.Context, in *ListIncomingBookingsRequest, opts ...grpc.CallOption) (*ListBookingsResponse, error)
+	AcceptBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	DeclineBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error)
+	GetAvailableSlots(ctx context.Context, in *AvailableSlotsRequest, opts ...grpc.CallOption) (*AvailableSlotsResponse, error)
+	QuoteBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*BookingQuote, error)
+	OverrideCancellationFee(ctx context.Context, in *CancellationFeeOverrideRequest, opts ...grpc.CallOption) (*BookingResponse, error)
 }
 
 type bookingServiceClient struct {
@@ -318,7 +494,7 @@ func (c *bookingServiceClient) UpdateBooking(ctx context.Context, in *UpdateBook
 	return out, nil
 }
 
-func (c *bookingServiceClient) CancelBooking(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+func (c *bookingServiceClient) CancelBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BookingResponse)
 	err := c.cc.Invoke(ctx, BookingService_CancelBooking_FullMethodName, in, out, cOpts...)
@@ -338,6 +514,126 @@ func (c *bookingServiceClient) ListBookings(ctx context.Context, in *ListBooking
 	return out, nil
 }
 
+func (c *bookingServiceClient) ConfirmBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_ConfirmBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) RejectBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_RejectBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) MarkEnRoute(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_MarkEnRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) StartBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_StartBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CompleteBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_CompleteBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) MarkNoShow(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_MarkNoShow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ListIncomingBookings(ctx context.Context, in *ListIncomingBookingsRequest, opts ...grpc.CallOption) (*ListBookingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBookingsResponse)
+	err := c.cc.Invoke(ctx, BookingService_ListIncomingBookings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) AcceptBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_AcceptBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) DeclineBooking(ctx context.Context, in *BookingTransitionRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_DeclineBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetAvailableSlots(ctx context.Context, in *AvailableSlotsRequest, opts ...grpc.CallOption) (*AvailableSlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AvailableSlotsResponse)
+	err := c.cc.Invoke(ctx, BookingService_GetAvailableSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) QuoteBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*BookingQuote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingQuote)
+	err := c.cc.Invoke(ctx, BookingService_QuoteBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) OverrideCancellationFee(ctx context.Context, in *CancellationFeeOverrideRequest, opts ...grpc.CallOption) (*BookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_OverrideCancellationFee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BookingServiceServer is the server API for BookingService service.
 // All implementations must embed UnimplementedBookingServiceServer
 // for forward compatibility
@@ -345,8 +641,20 @@ type BookingServiceServer interface {
 	CreateBooking(context.Context, *CreateBookingRequest) (*BookingResponse, error)
 	GetBooking(context.Context, *IdRequest) (*BookingResponse, error)
 	UpdateBooking(context.Context, *UpdateBookingRequest) (*BookingResponse, error)
-	CancelBooking(context.Context, *IdRequest) (*BookingResponse, error)
+	CancelBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
 	ListBookings(context.Context, *ListBookingsRequest) (*ListBookingsResponse, error)
+	ConfirmBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	RejectBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	MarkEnRoute(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	StartBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	CompleteBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	MarkNoShow(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	ListIncomingBookings(context.Context, *ListIncomingBookingsRequest) (*ListBookingsResponse, error)
+	AcceptBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	DeclineBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error)
+	GetAvailableSlots(context.Context, *AvailableSlotsRequest) (*AvailableSlotsResponse, error)
+	QuoteBooking(context.Context, *CreateBookingRequest) (*BookingQuote, error)
+	OverrideCancellationFee(context.Context, *CancellationFeeOverrideRequest) (*BookingResponse, error)
 	mustEmbedUnimplementedBookingServiceServer()
 }
 
@@ -363,12 +671,48 @@ func (UnimplementedBookingServiceServer) GetBooking(context.Context, *IdRequest)
 func (UnimplementedBookingServiceServer) UpdateBooking(context.Context, *UpdateBookingRequest) (*BookingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBooking not implemented")
 }
-func (UnimplementedBookingServiceServer) CancelBooking(context.Context, *IdRequest) (*BookingResponse, error) {
+func (UnimplementedBookingServiceServer) CancelBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelBooking not implemented")
 }
 func (UnimplementedBookingServiceServer) ListBookings(context.Context, *ListBookingsRequest) (*ListBookingsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBookings not implemented")
 }
+func (UnimplementedBookingServiceServer) ConfirmBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) RejectBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) MarkEnRoute(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkEnRoute not implemented")
+}
+func (UnimplementedBookingServiceServer) StartBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) CompleteBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) MarkNoShow(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNoShow not implemented")
+}
+func (UnimplementedBookingServiceServer) ListIncomingBookings(context.Context, *ListIncomingBookingsRequest) (*ListBookingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomingBookings not implemented")
+}
+func (UnimplementedBookingServiceServer) AcceptBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) DeclineBooking(context.Context, *BookingTransitionRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) GetAvailableSlots(context.Context, *AvailableSlotsRequest) (*AvailableSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableSlots not implemented")
+}
+func (UnimplementedBookingServiceServer) QuoteBooking(context.Context, *CreateBookingRequest) (*BookingQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) OverrideCancellationFee(context.Context, *CancellationFeeOverrideRequest) (*BookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OverrideCancellationFee not implemented")
+}
 func (UnimplementedBookingServiceServer) mustEmbedUnimplementedBookingServiceServer() {}
 
 // UnsafeBookingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -437,7 +781,7 @@ func _BookingService_UpdateBooking_Handler(srv interface{}, ctx context.Context,
 }
 
 func _BookingService_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IdRequest)
+	in := new(BookingTransitionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -449,7 +793,7 @@ func _BookingService_CancelBooking_Handler(srv interface{}, ctx context.Context,
 		FullMethod: BookingService_CancelBooking_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BookingServiceServer).CancelBooking(ctx, req.(*IdRequest))
+		return srv.(BookingServiceServer).CancelBooking(ctx, req.(*BookingTransitionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -472,52 +816,316 @@ func _BookingService_ListBookings_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// BookingService_ServiceDesc is the grpc.ServiceDesc for BookingService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BookingService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "booking.BookingService",
-	HandlerType: (*BookingServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBooking",
-			Handler:    _BookingService_CreateBooking_Handler,
-		},
-		{
-			MethodName: "GetBooking",
-			Handler:    _BookingService_GetBooking_Handler,
-		},
-		{
-			MethodName: "UpdateBooking",
-			Handler:    _BookingService_UpdateBooking_Handler,
-		},
-		{
-			MethodName: "CancelBooking",
-			Handler:    _BookingService_CancelBooking_Handler,
-		},
-		{
-			MethodName: "ListBookings",
-			Handler:    _BookingService_ListBookings_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "booking.proto",
+func _BookingService_ConfirmBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ConfirmBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ConfirmBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ConfirmBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-const (
-	ServiceManagementService_CreateService_FullMethodName   = "/booking.ServiceManagementService/CreateService"
-	ServiceManagementService_GetServiceByID_FullMethodName  = "/booking.ServiceManagementService/GetServiceByID"
-	ServiceManagementService_UpdateService_FullMethodName   = "/booking.ServiceManagementService/UpdateService"
-	ServiceManagementService_DeleteService_FullMethodName   = "/booking.ServiceManagementService/DeleteService"
-	ServiceManagementService_ListServices_FullMethodName    = "/booking.ServiceManagementService/ListServices"
-	ServiceManagementService_PopularServices_FullMethodName = "/booking.ServiceManagementService/PopularServices"
-)
-
-// ServiceManagementServiceClient is the client API for ServiceManagementService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type ServiceManagementServiceClient interface {
-	CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+func _BookingService_RejectBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).RejectBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_RejectBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).RejectBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_MarkEnRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).MarkEnRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_MarkEnRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).MarkEnRoute(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_StartBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).StartBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_StartBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).StartBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CompleteBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CompleteBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CompleteBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CompleteBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_MarkNoShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).MarkNoShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_MarkNoShow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).MarkNoShow(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ListIncomingBookings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomingBookingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ListIncomingBookings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ListIncomingBookings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ListIncomingBookings(ctx, req.(*ListIncomingBookingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_AcceptBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).AcceptBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_AcceptBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).AcceptBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_DeclineBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).DeclineBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_DeclineBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).DeclineBooking(ctx, req.(*BookingTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetAvailableSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AvailableSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetAvailableSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetAvailableSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetAvailableSlots(ctx, req.(*AvailableSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_QuoteBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).QuoteBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_QuoteBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).QuoteBooking(ctx, req.(*CreateBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_OverrideCancellationFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancellationFeeOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).OverrideCancellationFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_OverrideCancellationFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).OverrideCancellationFee(ctx, req.(*CancellationFeeOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookingService_ServiceDesc is the grpc.ServiceDesc for BookingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.BookingService",
+	HandlerType: (*BookingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBooking",
+			Handler:    _BookingService_CreateBooking_Handler,
+		},
+		{
+			MethodName: "GetBooking",
+			Handler:    _BookingService_GetBooking_Handler,
+		},
+		{
+			MethodName: "UpdateBooking",
+			Handler:    _BookingService_UpdateBooking_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _BookingService_CancelBooking_Handler,
+		},
+		{
+			MethodName: "ListBookings",
+			Handler:    _BookingService_ListBookings_Handler,
+		},
+		{
+			MethodName: "ConfirmBooking",
+			Handler:    _BookingService_ConfirmBooking_Handler,
+		},
+		{
+			MethodName: "RejectBooking",
+			Handler:    _BookingService_RejectBooking_Handler,
+		},
+		{
+			MethodName: "MarkEnRoute",
+			Handler:    _BookingService_MarkEnRoute_Handler,
+		},
+		{
+			MethodName: "StartBooking",
+			Handler:    _BookingService_StartBooking_Handler,
+		},
+		{
+			MethodName: "CompleteBooking",
+			Handler:    _BookingService_CompleteBooking_Handler,
+		},
+		{
+			MethodName: "MarkNoShow",
+			Handler:    _BookingService_MarkNoShow_Handler,
+		},
+		{
+			MethodName: "ListIncomingBookings",
+			Handler:    _BookingService_ListIncomingBookings_Handler,
+		},
+		{
+			MethodName: "AcceptBooking",
+			Handler:    _BookingService_AcceptBooking_Handler,
+		},
+		{
+			MethodName: "DeclineBooking",
+			Handler:    _BookingService_DeclineBooking_Handler,
+		},
+		{
+			MethodName: "GetAvailableSlots",
+			Handler:    _BookingService_GetAvailableSlots_Handler,
+		},
+		{
+			MethodName: "QuoteBooking",
+			Handler:    _BookingService_QuoteBooking_Handler,
+		},
+		{
+			MethodName: "OverrideCancellationFee",
+			Handler:    _BookingService_OverrideCancellationFee_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "booking.proto",
+}
+
+const (
+	ServiceManagementService_CreateService_FullMethodName   = "/booking.ServiceManagementService/CreateService"
+	ServiceManagementService_GetServiceByID_FullMethodName  = "/booking.ServiceManagementService/GetServiceByID"
+	ServiceManagementService_UpdateService_FullMethodName   = "/booking.ServiceManagementService/UpdateService"
+	ServiceManagementService_DeleteService_FullMethodName   = "/booking.ServiceManagementService/DeleteService"
+	ServiceManagementService_ListServices_FullMethodName    = "/booking.ServiceManagementService/ListServices"
+	ServiceManagementService_PopularServices_FullMethodName = "/booking.ServiceManagementService/PopularServices"
+)
+
+// ServiceManagementServiceClient is the client API for ServiceManagementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServiceManagementServiceClient interface {
+	CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	GetServiceByID(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	UpdateService(ctx context.Context, in *UpdateServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	DeleteService(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Void, error)
@@ -787,9 +1395,12 @@ var ServiceManagementService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	PaymentService_CreatePayment_FullMethodName = "/booking.PaymentService/CreatePayment"
-	PaymentService_GetPayment_FullMethodName    = "/booking.PaymentService/GetPayment"
-	PaymentService_ListPayments_FullMethodName  = "/booking.PaymentService/ListPayments"
+	PaymentService_CreatePayment_FullMethodName      = "/booking.PaymentService/CreatePayment"
+	PaymentService_GetPayment_FullMethodName         = "/booking.PaymentService/GetPayment"
+	PaymentService_ListPayments_FullMethodName       = "/booking.PaymentService/ListPayments"
+	PaymentService_HandlePaymentEvent_FullMethodName = "/booking.PaymentService/HandlePaymentEvent"
+	PaymentService_RefundPayment_FullMethodName      = "/booking.PaymentService/RefundPayment"
+	PaymentService_ListRefunds_FullMethodName        = "/booking.PaymentService/ListRefunds"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -799,6 +1410,9 @@ type PaymentServiceClient interface {
 	CreatePayment(ctx context.Context, in *CreatePaymentRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
 	GetPayment(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
 	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	HandlePaymentEvent(ctx context.Context, in *PaymentEvent, opts ...grpc.CallOption) (*PaymentResponse, error)
+	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+	ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -839,6 +1453,36 @@ func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPayment
 	return out, nil
 }
 
+func (c *paymentServiceClient) HandlePaymentEvent(ctx context.Context, in *PaymentEvent, opts ...grpc.CallOption) (*PaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_HandlePaymentEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefundsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListRefunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility
@@ -846,6 +1490,9 @@ type PaymentServiceServer interface {
 	CreatePayment(context.Context, *CreatePaymentRequest) (*PaymentResponse, error)
 	GetPayment(context.Context, *IdRequest) (*PaymentResponse, error)
 	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	HandlePaymentEvent(context.Context, *PaymentEvent) (*PaymentResponse, error)
+	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundResponse, error)
+	ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -862,6 +1509,15 @@ func (UnimplementedPaymentServiceServer) GetPayment(context.Context, *IdRequest)
 func (UnimplementedPaymentServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
 }
+func (UnimplementedPaymentServiceServer) HandlePaymentEvent(context.Context, *PaymentEvent) (*PaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentEvent not implemented")
+}
+func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefunds not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 
 // UnsafePaymentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -929,25 +1585,91 @@ func _PaymentService_ListPayments_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PaymentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "booking.PaymentService",
-	HandlerType: (*PaymentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreatePayment",
-			Handler:    _PaymentService_CreatePayment_Handler,
-		},
-		{
-			MethodName: "GetPayment",
-			Handler:    _PaymentService_GetPayment_Handler,
-		},
-		{
-			MethodName: "ListPayments",
+func _PaymentService_HandlePaymentEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).HandlePaymentEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_HandlePaymentEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).HandlePaymentEvent(ctx, req.(*PaymentEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, req.(*RefundPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListRefunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListRefunds(ctx, req.(*ListRefundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.PaymentService",
+	HandlerType: (*PaymentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePayment",
+			Handler:    _PaymentService_CreatePayment_Handler,
+		},
+		{
+			MethodName: "GetPayment",
+			Handler:    _PaymentService_GetPayment_Handler,
+		},
+		{
+			MethodName: "ListPayments",
 			Handler:    _PaymentService_ListPayments_Handler,
 		},
+		{
+			MethodName: "HandlePaymentEvent",
+			Handler:    _PaymentService_HandlePaymentEvent_Handler,
+		},
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentService_RefundPayment_Handler,
+		},
+		{
+			MethodName: "ListRefunds",
+			Handler:    _PaymentService_ListRefunds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "booking.proto",
@@ -1325,6 +2047,513 @@ var SearchingService_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "booking.proto",
 }
 
+const (
+	OperationService_GetOperation_FullMethodName = "/booking.OperationService/GetOperation"
+)
+
+// OperationServiceClient is the client API for OperationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OperationServiceClient interface {
+	GetOperation(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+}
+
+type operationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOperationServiceClient(cc grpc.ClientConnInterface) OperationServiceClient {
+	return &operationServiceClient{cc}
+}
+
+func (c *operationServiceClient) GetOperation(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, OperationService_GetOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperationServiceServer is the server API for OperationService service.
+// All implementations must embed UnimplementedOperationServiceServer
+// for forward compatibility
+type OperationServiceServer interface {
+	GetOperation(context.Context, *IdRequest) (*OperationResponse, error)
+	mustEmbedUnimplementedOperationServiceServer()
+}
+
+// UnimplementedOperationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOperationServiceServer struct {
+}
+
+func (UnimplementedOperationServiceServer) GetOperation(context.Context, *IdRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (UnimplementedOperationServiceServer) mustEmbedUnimplementedOperationServiceServer() {}
+
+// UnsafeOperationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OperationServiceServer will
+// result in compilation errors.
+type UnsafeOperationServiceServer interface {
+	mustEmbedUnimplementedOperationServiceServer()
+}
+
+func RegisterOperationServiceServer(s grpc.ServiceRegistrar, srv OperationServiceServer) {
+	s.RegisterService(&OperationService_ServiceDesc, srv)
+}
+
+func _OperationService_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperationServiceServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperationService_GetOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperationServiceServer).GetOperation(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OperationService_ServiceDesc is the grpc.ServiceDesc for OperationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OperationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.OperationService",
+	HandlerType: (*OperationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOperation",
+			Handler:    _OperationService_GetOperation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "booking.proto",
+}
+
+const (
+	DeadLetterService_ListDeadLetters_FullMethodName  = "/booking.DeadLetterService/ListDeadLetters"
+	DeadLetterService_GetDeadLetter_FullMethodName    = "/booking.DeadLetterService/GetDeadLetter"
+	DeadLetterService_ReplayDeadLetter_FullMethodName = "/booking.DeadLetterService/ReplayDeadLetter"
+	DeadLetterService_DeleteDeadLetter_FullMethodName = "/booking.DeadLetterService/DeleteDeadLetter"
+	DeadLetterService_PurgeDeadLetters_FullMethodName = "/booking.DeadLetterService/PurgeDeadLetters"
+)
+
+// DeadLetterServiceClient is the client API for DeadLetterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DeadLetterServiceClient interface {
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	GetDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Void, error)
+	PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error)
+}
+
+type deadLetterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeadLetterServiceClient(cc grpc.ClientConnInterface) DeadLetterServiceClient {
+	return &deadLetterServiceClient{cc}
+}
+
+func (c *deadLetterServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, DeadLetterService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deadLetterServiceClient) GetDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeadLetter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetter)
+	err := c.cc.Invoke(ctx, DeadLetterService_GetDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deadLetterServiceClient) ReplayDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeadLetter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetter)
+	err := c.cc.Invoke(ctx, DeadLetterService_ReplayDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deadLetterServiceClient) DeleteDeadLetter(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, DeadLetterService_DeleteDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deadLetterServiceClient) PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeadLettersResponse)
+	err := c.cc.Invoke(ctx, DeadLetterService_PurgeDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeadLetterServiceServer is the server API for DeadLetterService service.
+// All implementations must embed UnimplementedDeadLetterServiceServer
+// for forward compatibility
+type DeadLetterServiceServer interface {
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	GetDeadLetter(context.Context, *IdRequest) (*DeadLetter, error)
+	ReplayDeadLetter(context.Context, *IdRequest) (*DeadLetter, error)
+	DeleteDeadLetter(context.Context, *IdRequest) (*Void, error)
+	PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error)
+	mustEmbedUnimplementedDeadLetterServiceServer()
+}
+
+// UnimplementedDeadLetterServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDeadLetterServiceServer struct {
+}
+
+func (UnimplementedDeadLetterServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedDeadLetterServiceServer) GetDeadLetter(context.Context, *IdRequest) (*DeadLetter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetter not implemented")
+}
+func (UnimplementedDeadLetterServiceServer) ReplayDeadLetter(context.Context, *IdRequest) (*DeadLetter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedDeadLetterServiceServer) DeleteDeadLetter(context.Context, *IdRequest) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeadLetter not implemented")
+}
+func (UnimplementedDeadLetterServiceServer) PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeadLetters not implemented")
+}
+func (UnimplementedDeadLetterServiceServer) mustEmbedUnimplementedDeadLetterServiceServer() {}
+
+// UnsafeDeadLetterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeadLetterServiceServer will
+// result in compilation errors.
+type UnsafeDeadLetterServiceServer interface {
+	mustEmbedUnimplementedDeadLetterServiceServer()
+}
+
+func RegisterDeadLetterServiceServer(s grpc.ServiceRegistrar, srv DeadLetterServiceServer) {
+	s.RegisterService(&DeadLetterService_ServiceDesc, srv)
+}
+
+func _DeadLetterService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeadLetterServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeadLetterService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeadLetterServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeadLetterService_GetDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeadLetterServiceServer).GetDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeadLetterService_GetDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeadLetterServiceServer).GetDeadLetter(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeadLetterService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeadLetterServiceServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeadLetterService_ReplayDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeadLetterServiceServer).ReplayDeadLetter(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeadLetterService_DeleteDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeadLetterServiceServer).DeleteDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeadLetterService_DeleteDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeadLetterServiceServer).DeleteDeadLetter(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeadLetterService_PurgeDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeadLetterServiceServer).PurgeDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeadLetterService_PurgeDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeadLetterServiceServer).PurgeDeadLetters(ctx, req.(*PurgeDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeadLetterService_ServiceDesc is the grpc.ServiceDesc for DeadLetterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeadLetterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.DeadLetterService",
+	HandlerType: (*DeadLetterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _DeadLetterService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "GetDeadLetter",
+			Handler:    _DeadLetterService_GetDeadLetter_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _DeadLetterService_ReplayDeadLetter_Handler,
+		},
+		{
+			MethodName: "DeleteDeadLetter",
+			Handler:    _DeadLetterService_DeleteDeadLetter_Handler,
+		},
+		{
+			MethodName: "PurgeDeadLetters",
+			Handler:    _DeadLetterService_PurgeDeadLetters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "booking.proto",
+}
+
+const (
+	PricingService_GetPricingRules_FullMethodName    = "/booking.PricingService/GetPricingRules"
+	PricingService_SetPricingRules_FullMethodName    = "/booking.PricingService/SetPricingRules"
+	PricingService_DeletePricingRules_FullMethodName = "/booking.PricingService/DeletePricingRules"
+)
+
+// PricingServiceClient is the client API for PricingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PricingService manages the rules bookings are priced with. Rules without a
+// provider ID are the platform defaults; a provider's rules override them.
+type PricingServiceClient interface {
+	GetPricingRules(ctx context.Context, in *PricingRulesRequest, opts ...grpc.CallOption) (*PricingRules, error)
+	SetPricingRules(ctx context.Context, in *PricingRules, opts ...grpc.CallOption) (*PricingRules, error)
+	DeletePricingRules(ctx context.Context, in *PricingRulesRequest, opts ...grpc.CallOption) (*Void, error)
+}
+
+type pricingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingServiceClient(cc grpc.ClientConnInterface) PricingServiceClient {
+	return &pricingServiceClient{cc}
+}
+
+func (c *pricingServiceClient) GetPricingRules(ctx context.Context, in *PricingRulesRequest, opts ...grpc.CallOption) (*PricingRules, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PricingRules)
+	err := c.cc.Invoke(ctx, PricingService_GetPricingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SetPricingRules(ctx context.Context, in *PricingRules, opts ...grpc.CallOption) (*PricingRules, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PricingRules)
+	err := c.cc.Invoke(ctx, PricingService_SetPricingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) DeletePricingRules(ctx context.Context, in *PricingRulesRequest, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, PricingService_DeletePricingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServiceServer is the server API for PricingService service.
+// All implementations must embed UnimplementedPricingServiceServer
+// for forward compatibility
+//
+// PricingService manages the rules bookings are priced with. Rules without a
+// provider ID are the platform defaults; a provider's rules override them.
+type PricingServiceServer interface {
+	GetPricingRules(context.Context, *PricingRulesRequest) (*PricingRules, error)
+	SetPricingRules(context.Context, *PricingRules) (*PricingRules, error)
+	DeletePricingRules(context.Context, *PricingRulesRequest) (*Void, error)
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+// UnimplementedPricingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPricingServiceServer struct {
+}
+
+func (UnimplementedPricingServiceServer) GetPricingRules(context.Context, *PricingRulesRequest) (*PricingRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPricingRules not implemented")
+}
+func (UnimplementedPricingServiceServer) SetPricingRules(context.Context, *PricingRules) (*PricingRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPricingRules not implemented")
+}
+func (UnimplementedPricingServiceServer) DeletePricingRules(context.Context, *PricingRulesRequest) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePricingRules not implemented")
+}
+func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
+
+// UnsafePricingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServiceServer will
+// result in compilation errors.
+type UnsafePricingServiceServer interface {
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+func RegisterPricingServiceServer(s grpc.ServiceRegistrar, srv PricingServiceServer) {
+	s.RegisterService(&PricingService_ServiceDesc, srv)
+}
+
+func _PricingService_GetPricingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PricingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).GetPricingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_GetPricingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).GetPricingRules(ctx, req.(*PricingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SetPricingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PricingRules)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SetPricingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SetPricingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SetPricingRules(ctx, req.(*PricingRules))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_DeletePricingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PricingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).DeletePricingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_DeletePricingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).DeletePricingRules(ctx, req.(*PricingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PricingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.PricingService",
+	HandlerType: (*PricingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPricingRules",
+			Handler:    _PricingService_GetPricingRules_Handler,
+		},
+		{
+			MethodName: "SetPricingRules",
+			Handler:    _PricingService_SetPricingRules_Handler,
+		},
+		{
+			MethodName: "DeletePricingRules",
+			Handler:    _PricingService_DeletePricingRules_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "booking.proto",
+}
+
 const (
 	Notifications_CreateNotification_FullMethodName = "/booking.Notifications/CreateNotification"
 	Notifications_GetNotification_FullMethodName    = "/booking.Notifications/GetNotification"
@@ -2,23 +2,84 @@ package msgbroker
 
 import (
 	genprotos "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/pricing"
+	"Booking_Service/pkg/quote"
 	"Booking_Service/service"
+	"Booking_Service/storage"
+	"Booking_Service/storage/mongodb"
 	"context"
 	"encoding/json"
+	"github.com/pkg/errors"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log/slog"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 )
 
+// ErrPoison marks messages whose body cannot be decoded. Retrying them can never
+// succeed, so they are dead-lettered right away.
+var ErrPoison = errors.New("poison message")
+
+// permanentErrors are rejections of the request itself. The same message fails the
+// same way on every attempt, so it is answered as a failed operation and not retried.
+var permanentErrors = []error{
+	service.ErrInvalidTransition,
+	service.ErrNotBookingProvider,
+	service.ErrInvalidPaymentTransition,
+	service.ErrBookingAlreadyPaid,
+	service.ErrNotBookingOwner,
+	service.ErrBookingNotPayable,
+	mongodb.ErrSlotTaken,
+	storage.ErrProviderNotApproved,
+	storage.ErrBookingNotFound,
+	storage.ErrServiceNotFound,
+	storage.ErrProviderNotFound,
+	storage.ErrPricingRulesNotFound,
+	quote.ErrInvalidQuote,
+	quote.ErrQuoteExpired,
+	quote.ErrQuoteChanged,
+	pricing.ErrUnknownVehicleClass,
+	pricing.ErrOutOfServiceArea,
+	primitive.ErrInvalidHex,
+}
+
+// Permanent reports whether err rejects the request rather than being a fault of the
+// service or its dependencies, which may go away on a retry.
+func Permanent(err error) bool {
+	for _, target := range permanentErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var parseErr *time.ParseError
+	if errors.As(err, &parseErr) {
+		return true
+	}
+	switch status.Code(errors.Cause(err)) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied, codes.FailedPrecondition:
+		return true
+	}
+	return false
+}
+
+// queues are the work queues consumed by the booking service.
+var queues = []string{"create_booking", "payment_processed", "booking_cancelled", "review_submitted", "create_notification"}
+
 type (
 	MsgBroker struct {
 		BService           *service.BookingService
 		PService           *service.PaymentService
 		RService           *service.ReviewService
 		NService           *service.NotificationService
+		OService           *service.OperationService
+		DService           *service.DeadLetterService
 		channel            *amqp.Channel
 		createBooking      <-chan amqp.Delivery
 		deleteBooking      <-chan amqp.Delivery
@@ -28,6 +89,7 @@ type (
 		logger             *slog.Logger
 		wg                 *sync.WaitGroup
 		numberOfServices   int
+		retry              RetryPolicy
 	}
 )
 
@@ -36,6 +98,8 @@ func New(
 	Pservice *service.PaymentService,
 	Rservice *service.ReviewService,
 	Nservice *service.NotificationService,
+	Oservice *service.OperationService,
+	Dservice *service.DeadLetterService,
 	channel *amqp.Channel,
 	logger *slog.Logger,
 	createBooking <-chan amqp.Delivery,
@@ -44,12 +108,15 @@ func New(
 	createReview <-chan amqp.Delivery,
 	createNotification <-chan amqp.Delivery,
 	wg *sync.WaitGroup,
-	numberOfServices int) *MsgBroker {
+	numberOfServices int,
+	retry RetryPolicy) *MsgBroker {
 	return &MsgBroker{
 		BService:           Bservice,
 		PService:           Pservice,
 		RService:           Rservice,
 		NService:           Nservice,
+		OService:           Oservice,
+		DService:           Dservice,
 		channel:            channel,
 		createBooking:      createBooking,
 		deleteBooking:      deleteBooking,
@@ -59,6 +126,7 @@ func New(
 		logger:             logger,
 		wg:                 wg,
 		numberOfServices:   numberOfServices,
+		retry:              retry,
 	}
 }
 
@@ -73,6 +141,16 @@ func (m *MsgBroker) StartToConsume(ctx context.Context) {
 	go m.consumeMessages(consumerCtx, m.createReview, "review_submitted")
 	go m.consumeMessages(consumerCtx, m.createNotification, "create_notification")
 
+	for _, queue := range queues {
+		deliveries, err := m.channel.Consume(DeadLetterQueue(queue), "", false, false, false, false, nil)
+		if err != nil {
+			m.logger.Error("Failed to consume dead-letter queue", "queue", queue, "error", err)
+			continue
+		}
+		m.wg.Add(1)
+		go m.consumeDeadLetters(consumerCtx, deliveries, queue)
+	}
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
@@ -88,74 +166,265 @@ func (m *MsgBroker) consumeMessages(ctx context.Context, messages <-chan amqp.De
 	for {
 		select {
 		case val := <-messages:
-			var err error
+			result, err := m.handle(ctx, logPrefix, val.Body)
+			m.finish(ctx, val, logPrefix, result, err)
 
-			switch logPrefix {
-			case "create_booking":
-				var req genprotos.CreateBookingRequest
+		case <-ctx.Done():
+			m.logger.Info("Context done, stopping consumer", "consumer", logPrefix)
+			return
+		}
+	}
+}
 
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.BService.CreateBooking(ctx, &req)
-				if err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				val.Ack(false)
+// handle runs the request carried by a message and returns the resource it produced.
+func (m *MsgBroker) handle(ctx context.Context, logPrefix string, body []byte) (interface{}, error) {
+	switch logPrefix {
+	case "create_booking":
+		var req genprotos.CreateBookingRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.BService.CreateBooking(ctx, &req)
 
-			case "booking_updated":
-				var req genprotos.UpdateBookingRequest
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.BService.UpdateBooking(ctx, &req)
+	case "booking_updated":
+		var req genprotos.UpdateBookingRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.BService.UpdateBooking(ctx, &req)
 
-			case "booking_cancelled":
-				var req genprotos.IdRequest
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.BService.CancelBooking(ctx, &req)
+	case "booking_cancelled":
+		var req genprotos.BookingTransitionRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.BService.CancelBooking(ctx, &req)
 
-			case "payment_processed":
-				var req genprotos.CreatePaymentRequest
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.PService.CreatePayment(ctx, &req)
+	case "payment_processed":
+		var req genprotos.CreatePaymentRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.PService.CreatePayment(ctx, &req)
 
-			case "review_submitted":
-				var req genprotos.CreateReviewRequest
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.RService.CreateReview(ctx, &req)
+	case "review_submitted":
+		var req genprotos.CreateReviewRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.RService.CreateReview(ctx, &req)
 
-			case "create_notification":
-				var req genprotos.NewNotification
-				if err := json.Unmarshal(val.Body, &req); err != nil {
-					val.Nack(false, false)
-					continue
-				}
-				_, err = m.NService.CreateNotification(ctx, &req)
+	case "create_notification":
+		var req genprotos.NewNotification
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.Wrapf(ErrPoison, "invalid message body: %v", err)
+		}
+		return m.NService.CreateNotification(ctx, &req)
+	}
+
+	return nil, errors.Errorf("unknown queue %s", logPrefix)
+}
+
+// finish acknowledges a processed message. Requests the service rejects are recorded
+// as failed operations right away. Other failures are retried with backoff until the
+// retry policy gives up; then the failure is recorded under the message's operation ID
+// and the message goes to the queue's dead-letter exchange.
+func (m *MsgBroker) finish(ctx context.Context, val amqp.Delivery, kind string, result interface{}, err error) {
+	if err == nil {
+		m.record(ctx, val, kind, result, nil)
+		val.Ack(false)
+		return
+	}
+
+	attempt := attemptOf(val)
+	if Permanent(err) {
+		m.logger.Warn("Request rejected", "queue", kind, "operation_id", val.MessageId, "attempt", attempt, "error", err)
+		m.record(ctx, val, kind, nil, err)
+		val.Ack(false)
+		return
+	}
+
+	poison := errors.Is(err, ErrPoison)
+	if !poison && attempt < m.retry.MaxAttempts {
+		if pubErr := m.publishRetry(ctx, val, kind, attempt, err); pubErr != nil {
+			// Rejecting without requeue hands the message to the queue's dead-letter exchange.
+			m.logger.Error("Failed to schedule retry", "queue", kind, "operation_id", val.MessageId, "error", pubErr)
+			m.record(ctx, val, kind, nil, err)
+			val.Nack(false, false)
+			return
+		}
+		m.logger.Warn("Failed to process message, retrying", "queue", kind, "operation_id", val.MessageId,
+			"attempt", attempt, "retry_in", m.retry.Backoff(attempt), "error", err)
+		val.Ack(false)
+		return
+	}
+
+	m.logger.Error("Failed to process message", "queue", kind, "operation_id", val.MessageId,
+		"attempt", attempt, "poison", poison, "error", err)
+	m.record(ctx, val, kind, nil, err)
+	if pubErr := m.publishDeadLetter(ctx, val, kind, attempt, poison, err); pubErr != nil {
+		m.logger.Error("Failed to dead-letter message", "queue", kind, "operation_id", val.MessageId, "error", pubErr)
+		val.Nack(false, false)
+		return
+	}
+	val.Ack(false)
+}
+
+// record stores the outcome under the message's operation ID and answers the sender
+// when it waits for a reply.
+func (m *MsgBroker) record(ctx context.Context, val amqp.Delivery, kind string, result interface{}, err error) {
+	if val.MessageId == "" {
+		return
+	}
+
+	userID, _ := val.Headers["user_id"].(string)
+	op, recErr := m.OService.Record(ctx, val.MessageId, kind, userID, result, err)
+	if recErr != nil {
+		m.logger.Error("Failed to record operation", "operation_id", val.MessageId, "error", recErr)
+		return
+	}
+	if val.ReplyTo != "" {
+		m.reply(ctx, val, op)
+	}
+}
+
+func (m *MsgBroker) publishRetry(ctx context.Context, val amqp.Delivery, kind string, attempt int, err error) error {
+	headers := copyHeaders(val.Headers)
+	headers[HeaderAttempt] = int32(attempt + 1)
+	headers[HeaderError] = err.Error()
+
+	return m.channel.PublishWithContext(ctx, "", RetryQueue(kind, attempt), false, false, amqp.Publishing{
+		ContentType:   val.ContentType,
+		MessageId:     val.MessageId,
+		CorrelationId: val.CorrelationId,
+		ReplyTo:       val.ReplyTo,
+		Headers:       headers,
+		Body:          val.Body,
+	})
+}
+
+func (m *MsgBroker) publishDeadLetter(ctx context.Context, val amqp.Delivery, kind string, attempt int, poison bool, err error) error {
+	headers := copyHeaders(val.Headers)
+	headers[HeaderAttempt] = int32(attempt)
+	headers[HeaderError] = err.Error()
+	headers[HeaderOriginalQueue] = kind
+	headers[HeaderPoison] = poison
+	headers[HeaderFailedAt] = time.Now().Format(time.RFC3339)
+
+	return m.channel.PublishWithContext(ctx, DeadLetterExchange(kind), "", false, false, amqp.Publishing{
+		ContentType:   val.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     val.MessageId,
+		CorrelationId: val.CorrelationId,
+		Headers:       headers,
+		Body:          val.Body,
+	})
+}
+
+// consumeDeadLetters moves the messages of a dead-letter queue into storage, where
+// admins can inspect, replay or purge them.
+func (m *MsgBroker) consumeDeadLetters(ctx context.Context, deliveries <-chan amqp.Delivery, queue string) {
+	defer m.wg.Done()
+	for {
+		select {
+		case val, ok := <-deliveries:
+			if !ok {
+				return
 			}
 
-			if err != nil {
-				val.Nack(false, false)
-				continue
+			errMsg, _ := val.Headers[HeaderError].(string)
+			if errMsg == "" {
+				// Rejected by the consumer and dead-lettered by the broker itself.
+				errMsg = "message was rejected"
+			}
+			failedAt, _ := val.Headers[HeaderFailedAt].(string)
+			poison, _ := val.Headers[HeaderPoison].(bool)
+			userID, _ := val.Headers["user_id"].(string)
+			dl := &models.DeadLetter{
+				Queue:       queue,
+				OperationID: val.MessageId,
+				UserID:      userID,
+				Body:        string(val.Body),
+				Error:       errMsg,
+				Attempts:    int32(attemptOf(val)),
+				Poison:      poison,
+				Status:      models.DeadLetterDead,
+				FailedAt:    failedAt,
 			}
 
+			if err := m.DService.Save(ctx, dl); err != nil {
+				// Keep the message in the dead-letter queue until storage is back, backing
+				// off first so it is not redelivered in a tight loop.
+				m.logger.Error("Failed to save dead letter", "queue", queue, "operation_id", val.MessageId, "error", err)
+				select {
+				case <-time.After(m.retry.BaseDelay):
+				case <-ctx.Done():
+				}
+				val.Nack(false, true)
+				continue
+			}
 			val.Ack(false)
 
 		case <-ctx.Done():
-			m.logger.Info("Context done, stopping consumer", "consumer", logPrefix)
+			m.logger.Info("Context done, stopping dead-letter consumer", "queue", queue)
 			return
 		}
 	}
 }
+
+// attemptOf returns which attempt the delivery is; messages without the header are on their first.
+func attemptOf(val amqp.Delivery) int {
+	switch n := val.Headers[HeaderAttempt].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 1
+}
+
+func copyHeaders(h amqp.Table) amqp.Table {
+	headers := make(amqp.Table, len(h)+4)
+	for k, v := range h {
+		headers[k] = v
+	}
+	return headers
+}
+
+// Requeuer publishes dead letters back to their original queue.
+type Requeuer struct {
+	channel *amqp.Channel
+}
+
+func NewRequeuer(channel *amqp.Channel) *Requeuer {
+	return &Requeuer{channel: channel}
+}
+
+func (r *Requeuer) Requeue(ctx context.Context, dl *models.DeadLetter) error {
+	return r.channel.PublishWithContext(ctx, "", dl.Queue, false, false, amqp.Publishing{
+		ContentType:   "application/json",
+		MessageId:     dl.OperationID,
+		CorrelationId: dl.OperationID,
+		Headers:       amqp.Table{"user_id": dl.UserID},
+		Body:          []byte(dl.Body),
+	})
+}
+
+func (m *MsgBroker) reply(ctx context.Context, val amqp.Delivery, op *genprotos.OperationResponse) {
+	body, err := json.Marshal(op)
+	if err != nil {
+		m.logger.Error("Failed to marshal operation reply", "operation_id", op.Id, "error", err)
+		return
+	}
+
+	err = m.channel.PublishWithContext(ctx, "", val.ReplyTo, false, false, amqp.Publishing{
+		ContentType:   "application/json",
+		CorrelationId: val.CorrelationId,
+		Body:          body,
+	})
+	if err != nil {
+		m.logger.Error("Failed to send operation reply", "operation_id", op.Id, "error", err)
+	}
+}
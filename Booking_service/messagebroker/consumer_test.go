@@ -0,0 +1,48 @@
+package msgbroker
+
+import (
+	"Booking_Service/pkg/quote"
+	"Booking_Service/service"
+	"Booking_Service/storage"
+	"Booking_Service/storage/mongodb"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPermanent(t *testing.T) {
+	_, parseErr := time.Parse(time.RFC3339, "tomorrow")
+	_, hexErr := primitive.ObjectIDFromHex("nope")
+
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"invalid transition", errors.Wrap(service.ErrInvalidTransition, "completed -> canceled"), true},
+		{"slot taken", mongodb.ErrSlotTaken, true},
+		{"provider not approved", storage.ErrProviderNotApproved, true},
+		{"service not found", storage.ErrServiceNotFound, true},
+		{"expired quote", quote.ErrQuoteExpired, true},
+		{"changed quote", quote.ErrQuoteChanged, true},
+		{"other user's booking", service.ErrNotBookingOwner, true},
+		{"booking not payable", errors.Wrap(service.ErrBookingNotPayable, "booking is completed"), true},
+		{"concurrent payment", errors.Wrap(service.ErrBookingAlreadyPaid, storage.ErrActivePayment.Error()), true},
+		{"invalid id", errors.Wrap(hexErr, "invalid booking ID"), true},
+		{"invalid time", errors.Wrap(parseErr, "invalid scheduled time format"), true},
+		{"grpc invalid argument", status.Error(codes.InvalidArgument, "bad request"), true},
+		{"grpc unavailable", status.Error(codes.Unavailable, "try later"), false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"database down", errors.New("failed to insert booking: connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.permanent, Permanent(tt.err), tt.name)
+	}
+}
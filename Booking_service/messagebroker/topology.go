@@ -0,0 +1,72 @@
+package msgbroker
+
+import (
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers the consumer adds to retried and dead-lettered messages.
+const (
+	HeaderAttempt       = "x-attempt"
+	HeaderError         = "x-error"
+	HeaderOriginalQueue = "x-original-queue"
+	HeaderPoison        = "x-poison"
+	HeaderFailedAt      = "x-failed-at"
+)
+
+// RetryPolicy bounds how often a failed message is tried again and how long the
+// consumer waits in between. The n-th retry waits BaseDelay * 2^(n-1).
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// Backoff returns how long to wait before the given retry (1 = first retry).
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	return p.BaseDelay * time.Duration(1<<(retry-1))
+}
+
+// RetryQueue is the delay queue that holds messages before their given retry.
+func RetryQueue(queue string, retry int) string {
+	return fmt.Sprintf("%s.retry.%d", queue, retry)
+}
+
+// DeadLetterExchange receives the messages of queue that will not be retried anymore.
+func DeadLetterExchange(queue string) string {
+	return queue + ".dlx"
+}
+
+// DeadLetterQueue keeps the messages routed to the queue's dead-letter exchange.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dead"
+}
+
+// DeclareRetryTopology declares, next to the work queue, one delay queue per retry and
+// the dead-letter exchange and queue. A delay queue holds messages for its backoff and
+// then dead-letters them back into the work queue through the default exchange.
+func DeclareRetryTopology(ch *amqp.Channel, queue string, policy RetryPolicy) error {
+	for retry := 1; retry < policy.MaxAttempts; retry++ {
+		_, err := ch.QueueDeclare(RetryQueue(queue, retry), true, false, false, false, amqp.Table{
+			"x-message-ttl":             policy.Backoff(retry).Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		})
+		if err != nil {
+			return fmt.Errorf("declare retry queue for %s: %w", queue, err)
+		}
+	}
+
+	if err := ch.ExchangeDeclare(DeadLetterExchange(queue), amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare dead-letter exchange for %s: %w", queue, err)
+	}
+	if _, err := ch.QueueDeclare(DeadLetterQueue(queue), true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare dead-letter queue for %s: %w", queue, err)
+	}
+	if err := ch.QueueBind(DeadLetterQueue(queue), "", DeadLetterExchange(queue), false, nil); err != nil {
+		return fmt.Errorf("bind dead-letter queue for %s: %w", queue, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package msgbroker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 2 * time.Second}
+
+	require.Equal(t, 2*time.Second, policy.Backoff(1))
+	require.Equal(t, 4*time.Second, policy.Backoff(2))
+	require.Equal(t, 16*time.Second, policy.Backoff(4))
+}
+
+func TestTopologyNames(t *testing.T) {
+	require.Equal(t, "create_booking.retry.2", RetryQueue("create_booking", 2))
+	require.Equal(t, "create_booking.dlx", DeadLetterExchange("create_booking"))
+	require.Equal(t, "create_booking.dead", DeadLetterQueue("create_booking"))
+}
@@ -8,6 +8,7 @@ type Service struct {
 	Description string             `bson:"description" json:"description"`
 	Price       float64            `bson:"price" json:"price"`
 	Duration    int32              `bson:"duration" json:"duration"` // Duration in minutes
+	UserID      string             `bson:"user_id" json:"user_id"`   // Provider user offering the service
 	CreatedAt   string             `bson:"created_at" json:"created_at"`
 	UpdatedAt   string             `bson:"updated_at" json:"updated_at"`
 }
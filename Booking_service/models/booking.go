@@ -1,18 +1,87 @@
 package models
 
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Booking lifecycle statuses.
+const (
+	StatusPending    = "pending"
+	StatusConfirmed  = "confirmed"
+	StatusEnRoute    = "en_route"
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+	StatusCanceled   = "canceled"
+	StatusNoShow     = "no_show"
+	StatusRejected   = "rejected"
+)
+
+// BusyStatuses are the statuses in which a booking still occupies the provider's time.
+var BusyStatuses = []string{StatusPending, StatusConfirmed, StatusEnRoute, StatusInProgress}
+
 type Booking struct {
-	UserID        string   `bson:"user_id"`
-	ProviderID    string   `bson:"provider_id"`
-	ServiceID     string   `bson:"service_id"`
-	Status        string   `bson:"status"`
-	ScheduledTime string   `bson:"scheduled_time"`
-	Location      GeoPoint `bson:"location"`
-	TotalPrice    float64  `bson:"total_price"`
-	CreatedAt     string   `bson:"created_at"`
-	UpdatedAt     string   `bson:"updated_at"`
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	UserID         string             `bson:"user_id"`
+	OrganizationID string             `bson:"organization_id,omitempty"` // set when the booking is billed to an organization
+	ProviderID     string             `bson:"provider_id"`
+	ServiceID      string             `bson:"service_id"`
+	Status         string             `bson:"status"`
+	ScheduledTime  string             `bson:"scheduled_time"`
+	StartAt        time.Time          `bson:"start_at"`
+	EndAt          time.Time          `bson:"end_at"`
+	Location       GeoPoint           `bson:"location"`
+	VehicleClass   string             `bson:"vehicle_class,omitempty"`
+	TotalPrice     float64            `bson:"total_price"`
+	PriceBreakdown *PriceBreakdown    `bson:"price_breakdown,omitempty"`  // how TotalPrice was calculated
+	PaymentStatus  string             `bson:"payment_status,omitempty"`   // status of the latest payment
+	CancelFee      float64            `bson:"cancellation_fee,omitempty"` // charged for a late cancellation
+	FeeOverride    *FeeOverride       `bson:"fee_override,omitempty"`
+	StatusHistory  []StatusChange     `bson:"status_history"`
+	CreatedAt      string             `bson:"created_at"`
+	UpdatedAt      string             `bson:"updated_at"`
+}
+
+// StatusChange is an append-only record of who moved a booking into which status and when.
+type StatusChange struct {
+	FromStatus string `bson:"from_status"`
+	ToStatus   string `bson:"to_status"`
+	ChangedBy  string `bson:"changed_by"`
+	Reason     string `bson:"reason,omitempty"`
+	ChangedAt  string `bson:"changed_at"`
+}
+
+// FeeOverride records an admin changing the cancellation fee of a booking.
+type FeeOverride struct {
+	OriginalFee  float64 `bson:"original_fee"`
+	Reason       string  `bson:"reason"`
+	OverriddenBy string  `bson:"overridden_by"`
+	OverriddenAt string  `bson:"overridden_at"`
 }
 
+// GeoPoint is a GeoJSON point. Coordinates are kept in [longitude, latitude] order
+// so that MongoDB can index them with a 2dsphere index.
 type GeoPoint struct {
-	Latitude  float64 `bson:"latitude"`
-	Longitude float64 `bson:"longitude"`
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+// NewGeoPoint builds a GeoJSON point from a latitude and longitude.
+func NewGeoPoint(latitude, longitude float64) GeoPoint {
+	return GeoPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+func (p GeoPoint) Latitude() float64 {
+	if len(p.Coordinates) < 2 {
+		return 0
+	}
+	return p.Coordinates[1]
+}
+
+func (p GeoPoint) Longitude() float64 {
+	if len(p.Coordinates) < 2 {
+		return 0
+	}
+	return p.Coordinates[0]
 }
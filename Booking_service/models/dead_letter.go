@@ -0,0 +1,24 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Dead letter statuses.
+const (
+	DeadLetterDead     = "dead"
+	DeadLetterReplayed = "replayed"
+)
+
+// DeadLetter is a broker message that failed for good, kept until an admin replays or purges it.
+type DeadLetter struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Queue       string             `bson:"queue"`
+	OperationID string             `bson:"operation_id,omitempty"`
+	UserID      string             `bson:"user_id,omitempty"`
+	Body        string             `bson:"body"`
+	Error       string             `bson:"error"`
+	Attempts    int32              `bson:"attempts"`
+	Poison      bool               `bson:"poison"`
+	Status      string             `bson:"status"`
+	FailedAt    string             `bson:"failed_at"`
+	ReplayedAt  string             `bson:"replayed_at,omitempty"`
+}
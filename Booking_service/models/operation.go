@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Operation statuses.
+const (
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+// Operation is the recorded outcome of a request that arrived through the message broker.
+type Operation struct {
+	ID        string    `bson:"_id"`
+	Kind      string    `bson:"kind"`
+	Status    string    `bson:"status"`
+	Error     string    `bson:"error,omitempty"`
+	Result    string    `bson:"result,omitempty"`
+	UserID    string    `bson:"user_id"`
+	CreatedAt string    `bson:"created_at"`
+	UpdatedAt string    `bson:"updated_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -1,14 +1,44 @@
 package models
 
+// Payment statuses. A payment is authorized by the payment provider, possibly after
+// an extra customer action such as 3-D Secure, and then captured. Captured money can
+// be refunded in one or more parts.
+const (
+	PaymentPending        = "pending"
+	PaymentRequiresAction = "requires_action"
+	PaymentAuthorized     = "authorized"
+	PaymentCaptured       = "captured"
+	PaymentDeclined       = "declined"
+	PaymentVoided         = "voided"
+	PaymentFailed         = "failed"
+
+	PaymentPartiallyRefunded = "partially_refunded"
+	PaymentRefunded          = "refunded"
+)
+
 // Payment represents a payment record in the MongoDB collection
 type Payment struct {
-	ID            string  `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID        string  `bson:"userId,omitempty" json:"userId,omitempty"`
-	BookingID     string  `bson:"booking_id" json:"booking_id"`
-	Amount        float64 `bson:"amount" json:"amount"`
-	Status        string  `bson:"status" json:"status"`
-	PaymentMethod string  `bson:"payment_method" json:"payment_method"`
-	TransactionID string  `bson:"transaction_id" json:"transaction_id"`
-	CreatedAt     string  `bson:"created_at" json:"created_at"`
-	UpdatedAt     string  `bson:"updated_at" json:"updated_at"`
+	ID             string          `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID         string          `bson:"userId,omitempty" json:"userId,omitempty"`
+	BookingID      string          `bson:"booking_id" json:"booking_id"`
+	OrganizationID string          `bson:"organization_id,omitempty" json:"organization_id,omitempty"` // copied from the booking
+	Amount         float64         `bson:"amount" json:"amount"`
+	Status         string          `bson:"status" json:"status"`
+	PaymentMethod  string          `bson:"payment_method" json:"payment_method"`
+	TransactionID  string          `bson:"transaction_id" json:"transaction_id"`   // assigned by the payment provider
+	RefundedAmount float64         `bson:"refunded_amount" json:"refunded_amount"` // sum of the succeeded refunds
+	FailureReason  string          `bson:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	StatusHistory  []PaymentChange `bson:"status_history" json:"status_history"`
+	CreatedAt      string          `bson:"created_at" json:"created_at"`
+	UpdatedAt      string          `bson:"updated_at" json:"updated_at"`
+}
+
+// PaymentChange records a payment status change and what caused it: "api" for
+// calls made by the service, or the type of the provider event.
+type PaymentChange struct {
+	FromStatus string `bson:"from_status" json:"from_status"`
+	ToStatus   string `bson:"to_status" json:"to_status"`
+	Source     string `bson:"source" json:"source"`
+	Reason     string `bson:"reason,omitempty" json:"reason,omitempty"`
+	ChangedAt  string `bson:"changed_at" json:"changed_at"`
 }
@@ -0,0 +1,91 @@
+package models
+
+// Vehicle classes a customer books a wash for. The class picks the price multiplier.
+const (
+	VehicleSedan      = "sedan"
+	VehicleSUV        = "suv"
+	VehicleVan        = "van"
+	VehicleTruck      = "truck"
+	VehicleMotorcycle = "motorcycle"
+)
+
+// VehicleClasses are the classes accepted on bookings and in pricing rules.
+var VehicleClasses = []string{VehicleSedan, VehicleSUV, VehicleVan, VehicleTruck, VehicleMotorcycle}
+
+// Codes of the lines of a price breakdown.
+const (
+	PriceBase       = "base"
+	PriceVehicle    = "vehicle_class"
+	PriceTimeOfDay  = "time_of_day"
+	PriceWeekend    = "weekend"
+	PriceTravel     = "travel"
+	PriceBookingFee = "booking_fee"
+)
+
+// PricingRules configure how a booking is priced and what canceling it costs. The
+// document without a provider ID holds the platform defaults set by admins; a
+// provider document overrides the vehicle multipliers, travel fee, time rules,
+// weekend multiplier and cancellation policy it sets. The booking fee and tax rate
+// are platform-wide and only come from the defaults.
+type PricingRules struct {
+	ProviderID         string              `bson:"provider_id"`
+	VehicleMultipliers map[string]float64  `bson:"vehicle_multipliers,omitempty"`
+	TravelFee          *TravelFee          `bson:"travel_fee,omitempty"`
+	TimeRules          []TimeRule          `bson:"time_rules,omitempty"`
+	WeekendMultiplier  float64             `bson:"weekend_multiplier,omitempty"`
+	BookingFee         float64             `bson:"booking_fee"`
+	TaxRate            float64             `bson:"tax_rate"`
+	Cancellation       *CancellationPolicy `bson:"cancellation,omitempty"`
+	UpdatedBy          string              `bson:"updated_by"`
+	UpdatedAt          string              `bson:"updated_at"`
+}
+
+// TravelFee charges for the distance between the provider and the booking location.
+// The first FreeKm are free, every further kilometer costs PerKm. Bookings farther
+// than MaxKm are refused; 0 means no limit.
+type TravelFee struct {
+	FreeKm float64 `bson:"free_km"`
+	PerKm  float64 `bson:"per_km"`
+	MaxKm  float64 `bson:"max_km"`
+}
+
+// TimeRule multiplies the service price for bookings starting inside the window,
+// e.g. a rush-hour surcharge. An empty Weekdays list matches every day.
+type TimeRule struct {
+	Name       string  `bson:"name"`
+	Weekdays   []int32 `bson:"weekdays"`
+	Start      string  `bson:"start"`
+	End        string  `bson:"end"`
+	Multiplier float64 `bson:"multiplier"`
+}
+
+// CancellationPolicy charges a percentage of the total price for late cancellations.
+// The tier with the shortest notice a cancellation falls within applies, so
+// cancellations with more notice than every tier are free. Once the washer is en
+// route EnRouteFeePercent applies instead.
+type CancellationPolicy struct {
+	Tiers             []CancellationTier `bson:"tiers"`
+	EnRouteFeePercent float64            `bson:"en_route_fee_percent"`
+}
+
+// CancellationTier charges FeePercent for cancellations less than HoursBefore hours
+// before the booking starts.
+type CancellationTier struct {
+	HoursBefore float64 `bson:"hours_before"`
+	FeePercent  float64 `bson:"fee_percent"`
+}
+
+// PriceBreakdown itemizes the total price of a booking.
+type PriceBreakdown struct {
+	Items    []PriceItem `bson:"items"`
+	Subtotal float64     `bson:"subtotal"`
+	TaxRate  float64     `bson:"tax_rate"`
+	Tax      float64     `bson:"tax"`
+	Total    float64     `bson:"total"`
+}
+
+type PriceItem struct {
+	Code        string  `bson:"code"`
+	Description string  `bson:"description"`
+	Amount      float64 `bson:"amount"`
+}
@@ -4,6 +4,24 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Provider application statuses. Only approved providers can be found and booked.
+const (
+	ProviderSubmitted   = "submitted"
+	ProviderUnderReview = "under_review"
+	ProviderApproved    = "approved"
+	ProviderRejected    = "rejected"
+	ProviderSuspended   = "suspended"
+)
+
+// Document types a provider attaches to its application.
+const (
+	DocumentBusinessLicense = "business_license"
+	DocumentInsurance       = "insurance"
+)
+
+// RequiredDocuments must all be attached before a provider can be approved.
+var RequiredDocuments = []string{DocumentBusinessLicense, DocumentInsurance}
+
 // Provider represents a provider entity in the booking service.
 type Provider struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`
@@ -14,10 +32,36 @@ type Provider struct {
 	Availability  string             `bson:"availability"`
 	AverageRating float64            `bson:"average_rating"`
 	Location      *GeoPoint          `bson:"location"`
+	Schedule      []WorkingHours     `bson:"schedule"`
+	Holidays      []string           `bson:"holidays"`
+	Timezone      string             `bson:"timezone"`
+	Status        string             `bson:"status"`
+	Documents     []ProviderDocument `bson:"documents"`
+	StatusHistory []StatusChange     `bson:"status_history"`
 	CreatedAt     string             `bson:"created_at"`
 	UpdatedAt     string             `bson:"updated_at"`
 }
 
+// ProviderDocument is a file attached to a provider application. The file itself
+// lives in the blob store under Key.
+type ProviderDocument struct {
+	ID          string `bson:"id"`
+	Type        string `bson:"type"`
+	FileName    string `bson:"file_name"`
+	ContentType string `bson:"content_type"`
+	Size        int64  `bson:"size"`
+	Key         string `bson:"key"`
+	UploadedBy  string `bson:"uploaded_by"`
+	UploadedAt  string `bson:"uploaded_at"`
+}
+
+// WorkingHours is the opening window of a provider on one weekday (0 = Sunday).
+type WorkingHours struct {
+	Weekday int32  `bson:"weekday"`
+	Start   string `bson:"start"`
+	End     string `bson:"end"`
+}
+
 type Provider1 struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`
 	UserID        string             `bson:"user_id"`
@@ -26,7 +70,9 @@ type Provider1 struct {
 	Services      []string           `bson:"services"`
 	Availability  string             `bson:"availability"`
 	AverageRating float64            `bson:"average_rating"`
-	Location      string             `bson:"location"`
+	Location      *GeoPoint          `bson:"location"`
+	Schedule      []WorkingHours     `bson:"schedule"`
+	Holidays      []string           `bson:"holidays"`
 	CreatedAt     string             `bson:"created_at"`
 	UpdatedAt     string             `bson:"updated_at"`
 }
@@ -0,0 +1,22 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Refund statuses. A refund is recorded whether or not the payment provider accepted it.
+const (
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// Refund returns part or all of a captured payment to the customer.
+type Refund struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	PaymentID     string             `bson:"payment_id"`
+	BookingID     string             `bson:"booking_id"`
+	Amount        float64            `bson:"amount"`
+	Reason        string             `bson:"reason"`
+	Status        string             `bson:"status"`
+	FailureReason string             `bson:"failure_reason,omitempty"`
+	RequestedBy   string             `bson:"requested_by"` // user who asked for the refund or canceled the booking
+	CreatedAt     string             `bson:"created_at"`
+}
@@ -0,0 +1,30 @@
+// Package blob stores uploaded files, such as provider documents, outside of MongoDB.
+package blob
+
+import (
+	"Booking_Service/config"
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned when no file is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps files under slash-separated keys such as "providers/<id>/<document id>".
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the store selected by BLOB_STORE.
+func New(cfg *config.Config) (Store, error) {
+	switch cfg.BLOB_STORE {
+	case "local", "":
+		return NewLocalStore(cfg.BLOB_DIR)
+	default:
+		return nil, errors.Errorf("unknown blob store %q", cfg.BLOB_STORE)
+	}
+}
@@ -0,0 +1,41 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	n, err := store.Put(ctx, "providers/p1/doc1", strings.NewReader("license"))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), n)
+
+	f, err := store.Open(ctx, "providers/p1/doc1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "license", string(content))
+
+	require.NoError(t, store.Delete(ctx, "providers/p1/doc1"))
+	_, err = store.Open(ctx, "providers/p1/doc1")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "providers/../../secret", "/etc/passwd", "providers\\p1"} {
+		_, err := store.Put(context.Background(), key, strings.NewReader("x"))
+		require.Error(t, err, key)
+	}
+}
@@ -0,0 +1,198 @@
+package payment
+
+import (
+	"Booking_Service/models"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Payment method tokens the mock reacts to, in the spirit of an acquirer's test cards.
+// Any other method is authorized right away.
+const (
+	MockDecline           = "mock_decline"
+	MockInsufficientFunds = "mock_insufficient_funds"
+	Mock3DS               = "mock_3ds"
+	Mock3DSFail           = "mock_3ds_fail"
+)
+
+// Mock is an in-memory acquirer for development. Methods needing 3-D Secure answer
+// requires_action and report the outcome to the webhook after a delay, as a real
+// acquirer would once the customer finished the challenge. Transactions are lost on restart.
+type Mock struct {
+	webhookURL string
+	secret     string
+	delay      time.Duration
+	client     *http.Client
+	logger     *slog.Logger
+
+	mu           sync.Mutex
+	transactions map[string]*mockTransaction
+}
+
+type mockTransaction struct {
+	paymentID  string
+	status     string
+	authorized float64
+	captured   float64
+	refunded   float64
+}
+
+func NewMock(webhookURL, secret string, delay time.Duration, logger *slog.Logger) *Mock {
+	return &Mock{
+		webhookURL:   webhookURL,
+		secret:       secret,
+		delay:        delay,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		logger:       logger,
+		transactions: make(map[string]*mockTransaction),
+	}
+}
+
+func (m *Mock) Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	id := "mock_" + randomHex()
+	tx := &mockTransaction{paymentID: req.PaymentID, authorized: req.Amount}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transactions[id] = tx
+
+	switch req.Method {
+	case MockDecline:
+		tx.status = models.PaymentDeclined
+		return &Result{TransactionID: id, Status: tx.status, Reason: "card_declined"}, nil
+	case MockInsufficientFunds:
+		tx.status = models.PaymentDeclined
+		return &Result{TransactionID: id, Status: tx.status, Reason: "insufficient_funds"}, nil
+	case Mock3DS, Mock3DSFail:
+		tx.status = models.PaymentRequiresAction
+		time.AfterFunc(m.delay, func() { m.finishAction(id, req.Method == Mock3DS) })
+		return &Result{TransactionID: id, Status: tx.status}, nil
+	}
+
+	tx.status = models.PaymentAuthorized
+	return &Result{TransactionID: id, Status: tx.status}, nil
+}
+
+func (m *Mock) Capture(ctx context.Context, transactionID string, amount float64) (*Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, ok := m.transactions[transactionID]
+	if !ok {
+		return nil, ErrUnknownTransaction
+	}
+	if tx.status != models.PaymentAuthorized {
+		return nil, errors.Wrapf(ErrInvalidOperation, "capture of %s transaction", tx.status)
+	}
+	if amount <= 0 || amount > tx.authorized {
+		return nil, errors.Wrapf(ErrInvalidOperation, "capture of %.2f, authorized %.2f", amount, tx.authorized)
+	}
+	tx.status = models.PaymentCaptured
+	tx.captured = amount
+	return &Result{TransactionID: transactionID, Status: tx.status}, nil
+}
+
+func (m *Mock) Void(ctx context.Context, transactionID string) (*Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, ok := m.transactions[transactionID]
+	if !ok {
+		return nil, ErrUnknownTransaction
+	}
+	if tx.status != models.PaymentAuthorized && tx.status != models.PaymentRequiresAction {
+		return nil, errors.Wrapf(ErrInvalidOperation, "void of %s transaction", tx.status)
+	}
+	tx.status = models.PaymentVoided
+	return &Result{TransactionID: transactionID, Status: tx.status}, nil
+}
+
+func (m *Mock) Refund(ctx context.Context, transactionID string, amount float64) (*Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, ok := m.transactions[transactionID]
+	if !ok {
+		return nil, ErrUnknownTransaction
+	}
+	if tx.status != models.PaymentCaptured {
+		return nil, errors.Wrapf(ErrInvalidOperation, "refund of %s transaction", tx.status)
+	}
+	if amount <= 0 || amount > tx.captured-tx.refunded+0.005 {
+		return nil, errors.Wrapf(ErrInvalidOperation, "refund of %.2f, refundable %.2f", amount, tx.captured-tx.refunded)
+	}
+	tx.refunded += amount
+	return &Result{TransactionID: transactionID, Status: tx.status}, nil
+}
+
+// finishAction completes a 3-D Secure challenge and reports it to the webhook.
+func (m *Mock) finishAction(transactionID string, passed bool) {
+	m.mu.Lock()
+	tx, ok := m.transactions[transactionID]
+	if !ok || tx.status != models.PaymentRequiresAction {
+		m.mu.Unlock()
+		return
+	}
+	event := Event{
+		ID:            "evt_" + randomHex(),
+		Type:          EventAuthorized,
+		TransactionID: transactionID,
+		PaymentID:     tx.paymentID,
+		Amount:        tx.authorized,
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+	tx.status = models.PaymentAuthorized
+	if !passed {
+		tx.status = models.PaymentDeclined
+		event.Type = EventDeclined
+		event.Reason = "authentication_failed"
+	}
+	m.mu.Unlock()
+
+	if err := m.send(context.Background(), event); err != nil {
+		m.logger.Error("Failed to deliver payment webhook", "event_id", event.ID, "type", event.Type, "error", err)
+	}
+}
+
+func (m *Mock) send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to build webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(m.secret, body, time.Now()))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+func randomHex() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package payment
+
+import (
+	"Booking_Service/models"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMock(t *testing.T) {
+	ctx := context.Background()
+	m := NewMock("", "secret", time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	res, err := m.Authorize(ctx, AuthorizeRequest{PaymentID: "p1", Amount: 50, Method: "card"})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentAuthorized, res.Status)
+
+	_, err = m.Capture(ctx, res.TransactionID, 60)
+	require.ErrorIs(t, err, ErrInvalidOperation, "more than authorized")
+	res, err = m.Capture(ctx, res.TransactionID, 50)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentCaptured, res.Status)
+
+	_, err = m.Void(ctx, res.TransactionID)
+	require.ErrorIs(t, err, ErrInvalidOperation, "captured transactions are refunded, not voided")
+
+	res, err = m.Authorize(ctx, AuthorizeRequest{PaymentID: "p2", Amount: 50, Method: MockDecline})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentDeclined, res.Status)
+	require.Equal(t, "card_declined", res.Reason)
+
+	_, err = m.Capture(ctx, "unknown", 10)
+	require.ErrorIs(t, err, ErrUnknownTransaction)
+}
+
+func TestMock3DS(t *testing.T) {
+	events := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts := r.Header.Get(SignatureHeader)
+		require.NotEmpty(t, ts)
+
+		var event Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		events <- event
+	}))
+	defer server.Close()
+
+	m := NewMock(server.URL, "secret", time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	res, err := m.Authorize(context.Background(), AuthorizeRequest{PaymentID: "p1", Amount: 50, Method: Mock3DS})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentRequiresAction, res.Status)
+
+	select {
+	case event := <-events:
+		require.Equal(t, EventAuthorized, event.Type)
+		require.Equal(t, res.TransactionID, event.TransactionID)
+		require.Equal(t, "p1", event.PaymentID)
+	case <-time.After(time.Second):
+		t.Fatal("webhook was not called")
+	}
+
+	res, err = m.Capture(context.Background(), res.TransactionID, 50)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentCaptured, res.Status)
+}
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	require.Equal(t, Sign("secret", []byte("{}"), at), Sign("secret", []byte("{}"), at))
+	require.NotEqual(t, Sign("secret", []byte("{}"), at), Sign("other", []byte("{}"), at))
+	require.Contains(t, Sign("secret", []byte("{}"), at), "t=1700000000,v1=")
+}
@@ -0,0 +1,90 @@
+// Package payment moves money through a payment acquirer. PaymentProvider hides
+// which acquirer is used; Mock simulates one locally.
+package payment
+
+import (
+	"Booking_Service/config"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader carries the signature of a webhook: "t=<unix time>,v1=<hex HMAC-SHA256
+// of "<unix time>.<body>">".
+const SignatureHeader = "Payment-Signature"
+
+// Types of the events a payment provider sends to the webhook.
+const (
+	EventAuthorized = "payment.authorized"
+	EventDeclined   = "payment.declined"
+	EventCaptured   = "payment.captured"
+	EventVoided     = "payment.voided"
+	EventRefunded   = "payment.refunded"
+	EventFailed     = "payment.failed"
+)
+
+var (
+	ErrUnknownTransaction = errors.New("unknown transaction")
+	ErrInvalidOperation   = errors.New("operation is not allowed in the transaction's state")
+)
+
+// PaymentProvider is an acquirer. Authorize reserves the amount on the customer's
+// payment method, Capture collects it, Void releases an uncaptured authorization and
+// Refund returns captured money. Outcomes that are not known right away, such as an
+// authorization waiting for 3-D Secure, arrive later as webhook events.
+type PaymentProvider interface {
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error)
+	Capture(ctx context.Context, transactionID string, amount float64) (*Result, error)
+	Void(ctx context.Context, transactionID string) (*Result, error)
+	Refund(ctx context.Context, transactionID string, amount float64) (*Result, error)
+}
+
+type AuthorizeRequest struct {
+	PaymentID string // our payment ID, echoed back in webhook events
+	Amount    float64
+	Method    string // payment method token
+}
+
+// Result is the state of a transaction after a call. Status is one of the
+// models.Payment* statuses; Reason explains declines.
+type Result struct {
+	TransactionID string
+	Status        string
+	Reason        string
+}
+
+// Event is the body of a webhook call.
+type Event struct {
+	ID            string  `json:"id"`
+	Type          string  `json:"type"`
+	TransactionID string  `json:"transaction_id"`
+	PaymentID     string  `json:"payment_id"`
+	Amount        float64 `json:"amount"`
+	Reason        string  `json:"reason,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// New returns the provider selected by PAYMENT_PROVIDER.
+func New(cfg *config.Config, logger *slog.Logger) (PaymentProvider, error) {
+	switch cfg.PAYMENT_PROVIDER {
+	case "mock", "":
+		return NewMock(cfg.PAYMENT_WEBHOOK_URL, cfg.PAYMENT_WEBHOOK_SECRET, cfg.MOCK_ACTION_DELAY, logger), nil
+	default:
+		return nil, errors.Errorf("unknown payment provider %q", cfg.PAYMENT_PROVIDER)
+	}
+}
+
+// Sign returns the SignatureHeader value of a webhook body sent at t.
+func Sign(secret string, body []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%s.%s", ts, body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,279 @@
+// Package pricing calculates the itemized price of a booking from the pricing rules
+// of the platform and the provider.
+package pricing
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBookingFee is charged while admins have not configured any pricing; it is
+// the flat fee bookings always carried.
+const DefaultBookingFee = 5
+
+const earthRadiusKm = 6371.0
+
+var (
+	ErrUnknownVehicleClass = errors.New("unknown vehicle class")
+	ErrOutOfServiceArea    = errors.New("booking location is outside the provider's service area")
+	ErrInvalidRules        = errors.New("invalid pricing rules")
+)
+
+// Input is what a booking contributes to its price.
+type Input struct {
+	ServicePrice float64
+	VehicleClass string
+	Start        time.Time // in the provider's time zone, which time rules are written in
+	Provider     *models.GeoPoint
+	Location     models.GeoPoint
+}
+
+// Defaults returns the rules used when admins have not stored any.
+func Defaults() *models.PricingRules {
+	return &models.PricingRules{BookingFee: DefaultBookingFee}
+}
+
+// Merge returns the rules that apply to a provider: the platform defaults with every
+// section the provider configured taking their place. provider may be nil.
+func Merge(defaults, provider *models.PricingRules) *models.PricingRules {
+	rules := *defaults
+	if provider == nil {
+		return &rules
+	}
+	rules.ProviderID = provider.ProviderID
+	if len(provider.VehicleMultipliers) > 0 {
+		rules.VehicleMultipliers = provider.VehicleMultipliers
+	}
+	if provider.TravelFee != nil {
+		rules.TravelFee = provider.TravelFee
+	}
+	if len(provider.TimeRules) > 0 {
+		rules.TimeRules = provider.TimeRules
+	}
+	if provider.WeekendMultiplier > 0 {
+		rules.WeekendMultiplier = provider.WeekendMultiplier
+	}
+	if provider.Cancellation != nil {
+		rules.Cancellation = provider.Cancellation
+	}
+	return &rules
+}
+
+// Validate reports the first problem that would make the rules price bookings wrongly.
+func Validate(rules *models.PricingRules) error {
+	for class, m := range rules.VehicleMultipliers {
+		if !slices.Contains(models.VehicleClasses, class) {
+			return errors.Wrapf(ErrInvalidRules, "unknown vehicle class %q", class)
+		}
+		if m <= 0 {
+			return errors.Wrapf(ErrInvalidRules, "multiplier of %s must be positive", class)
+		}
+	}
+	if fee := rules.TravelFee; fee != nil && (fee.FreeKm < 0 || fee.PerKm < 0 || fee.MaxKm < 0) {
+		return errors.Wrap(ErrInvalidRules, "travel fee values must not be negative")
+	}
+	for _, rule := range rules.TimeRules {
+		if rule.Multiplier <= 0 {
+			return errors.Wrapf(ErrInvalidRules, "multiplier of time rule %q must be positive", rule.Name)
+		}
+		for _, day := range rule.Weekdays {
+			if day < 0 || day > 6 {
+				return errors.Wrapf(ErrInvalidRules, "time rule %q has invalid weekday %d", rule.Name, day)
+			}
+		}
+		start, err := clock(rule.Start)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidRules, "time rule %q: %v", rule.Name, err)
+		}
+		end, err := clock(rule.End)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidRules, "time rule %q: %v", rule.Name, err)
+		}
+		if end <= start {
+			return errors.Wrapf(ErrInvalidRules, "time rule %q ends before it starts", rule.Name)
+		}
+	}
+	if rules.WeekendMultiplier < 0 {
+		return errors.Wrap(ErrInvalidRules, "weekend multiplier must not be negative")
+	}
+	if rules.BookingFee < 0 {
+		return errors.Wrap(ErrInvalidRules, "booking fee must not be negative")
+	}
+	if rules.TaxRate < 0 || rules.TaxRate > 1 {
+		return errors.Wrap(ErrInvalidRules, "tax rate must be between 0 and 1")
+	}
+	if policy := rules.Cancellation; policy != nil {
+		for _, tier := range policy.Tiers {
+			if tier.HoursBefore <= 0 {
+				return errors.Wrap(ErrInvalidRules, "cancellation tier hours must be positive")
+			}
+			if tier.FeePercent < 0 || tier.FeePercent > 100 {
+				return errors.Wrap(ErrInvalidRules, "cancellation fee must be between 0 and 100 percent")
+			}
+		}
+		if policy.EnRouteFeePercent < 0 || policy.EnRouteFeePercent > 100 {
+			return errors.Wrap(ErrInvalidRules, "en route cancellation fee must be between 0 and 100 percent")
+		}
+	}
+	return nil
+}
+
+// Quote prices a booking. The vehicle class, time-of-day and weekend adjustments
+// apply to the service price; travel and booking fees are added on top, and tax is
+// charged on the subtotal. Every amount is rounded to cents.
+func Quote(rules *models.PricingRules, in Input) (*models.PriceBreakdown, error) {
+	class := in.VehicleClass
+	if class == "" {
+		class = models.VehicleSedan
+	}
+	if !slices.Contains(models.VehicleClasses, class) {
+		return nil, errors.Wrapf(ErrUnknownVehicleClass, "%q", class)
+	}
+
+	breakdown := &models.PriceBreakdown{TaxRate: rules.TaxRate}
+	add := func(code, description string, amount float64) {
+		amount = round(amount)
+		if amount == 0 && code != models.PriceBase {
+			return
+		}
+		breakdown.Items = append(breakdown.Items, models.PriceItem{Code: code, Description: description, Amount: amount})
+		breakdown.Subtotal += amount
+	}
+
+	add(models.PriceBase, "Service price", in.ServicePrice)
+
+	service := in.ServicePrice
+	if m, ok := rules.VehicleMultipliers[class]; ok {
+		add(models.PriceVehicle, fmt.Sprintf("Vehicle class %s (x%g)", class, m), service*(m-1))
+		service *= m
+	}
+
+	if rule := matchTimeRule(rules.TimeRules, in.Start); rule != nil {
+		add(models.PriceTimeOfDay, fmt.Sprintf("%s (x%g)", rule.Name, rule.Multiplier), service*(rule.Multiplier-1))
+	}
+	if m := rules.WeekendMultiplier; m > 0 && isWeekend(in.Start) {
+		add(models.PriceWeekend, fmt.Sprintf("Weekend (x%g)", m), service*(m-1))
+	}
+
+	if fee := rules.TravelFee; fee != nil && in.Provider != nil && len(in.Provider.Coordinates) == 2 {
+		distance := DistanceKm(*in.Provider, in.Location)
+		if fee.MaxKm > 0 && distance > fee.MaxKm {
+			return nil, errors.Wrapf(ErrOutOfServiceArea, "%.1f km away, at most %g km", distance, fee.MaxKm)
+		}
+		if distance > fee.FreeKm {
+			add(models.PriceTravel, fmt.Sprintf("Travel %.1f km", distance), (distance-fee.FreeKm)*fee.PerKm)
+		}
+	}
+
+	add(models.PriceBookingFee, "Booking fee", rules.BookingFee)
+
+	breakdown.Subtotal = round(breakdown.Subtotal)
+	breakdown.Tax = round(breakdown.Subtotal * rules.TaxRate)
+	breakdown.Total = round(breakdown.Subtotal + breakdown.Tax)
+	return breakdown, nil
+}
+
+// CancellationFee returns the fee of canceling, at now, a booking in the given status
+// that starts at start and costs total. Without a policy cancellations are free.
+func CancellationFee(policy *models.CancellationPolicy, total float64, status string, start, now time.Time) float64 {
+	if policy == nil {
+		return 0
+	}
+	if status == models.StatusEnRoute || status == models.StatusInProgress {
+		return round(total * policy.EnRouteFeePercent / 100)
+	}
+
+	notice := start.Sub(now).Hours()
+	var tier *models.CancellationTier
+	for i, t := range policy.Tiers {
+		if notice < t.HoursBefore && (tier == nil || t.HoursBefore < tier.HoursBefore) {
+			tier = &policy.Tiers[i]
+		}
+	}
+	if tier == nil {
+		return 0
+	}
+	return round(total * tier.FeePercent / 100)
+}
+
+// ToResponse converts a price breakdown to its gRPC form.
+func ToResponse(breakdown *models.PriceBreakdown) *pb.PriceBreakdown {
+	if breakdown == nil {
+		return nil
+	}
+	items := make([]*pb.PriceItem, 0, len(breakdown.Items))
+	for _, item := range breakdown.Items {
+		items = append(items, &pb.PriceItem{
+			Code:        item.Code,
+			Description: item.Description,
+			Amount:      item.Amount,
+		})
+	}
+	return &pb.PriceBreakdown{
+		Items:    items,
+		Subtotal: breakdown.Subtotal,
+		TaxRate:  breakdown.TaxRate,
+		Tax:      breakdown.Tax,
+		Total:    breakdown.Total,
+	}
+}
+
+// DistanceKm is the great-circle distance between two points.
+func DistanceKm(a, b models.GeoPoint) float64 {
+	lat1, lat2 := radians(a.Latitude()), radians(b.Latitude())
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude() - a.Longitude())
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// matchTimeRule returns the first rule whose window contains start.
+func matchTimeRule(rules []models.TimeRule, start time.Time) *models.TimeRule {
+	minute := start.Hour()*60 + start.Minute()
+	for i, rule := range rules {
+		if len(rule.Weekdays) > 0 && !slices.Contains(rule.Weekdays, int32(start.Weekday())) {
+			continue
+		}
+		from, err := clock(rule.Start)
+		if err != nil {
+			continue
+		}
+		to, err := clock(rule.End)
+		if err != nil {
+			continue
+		}
+		if minute >= from && minute < to {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// clock returns the minute of the day of an "HH:MM" time.
+func clock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,138 @@
+package pricing
+
+import (
+	"Booking_Service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// monday is 2030-01-07, a Monday.
+var monday = time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+
+func TestQuoteDefaults(t *testing.T) {
+	breakdown, err := Quote(Defaults(), Input{ServicePrice: 50, Start: monday})
+	require.NoError(t, err)
+	require.Equal(t, []models.PriceItem{
+		{Code: models.PriceBase, Description: "Service price", Amount: 50},
+		{Code: models.PriceBookingFee, Description: "Booking fee", Amount: 5},
+	}, breakdown.Items)
+	require.Equal(t, 55.0, breakdown.Total)
+}
+
+func TestQuote(t *testing.T) {
+	provider := models.NewGeoPoint(41.3111, 69.2797)
+	rules := &models.PricingRules{
+		VehicleMultipliers: map[string]float64{models.VehicleSUV: 1.2},
+		TravelFee:          &models.TravelFee{FreeKm: 5, PerKm: 1, MaxKm: 30},
+		TimeRules: []models.TimeRule{
+			{Name: "Evening", Start: "18:00", End: "22:00", Multiplier: 1.5},
+		},
+		WeekendMultiplier: 1.1,
+		BookingFee:        5,
+		TaxRate:           0.12,
+	}
+	in := Input{
+		ServicePrice: 50,
+		VehicleClass: models.VehicleSUV,
+		Start:        monday,
+		Provider:     &provider,
+		Location:     provider,
+	}
+
+	breakdown, err := Quote(rules, in)
+	require.NoError(t, err)
+	// 50 + 10 (suv) + 5 (fee) = 65, plus 12% tax.
+	require.Equal(t, 65.0, breakdown.Subtotal)
+	require.Equal(t, 7.8, breakdown.Tax)
+	require.Equal(t, 72.8, breakdown.Total)
+
+	// Saturday evening, about 11 km away.
+	in.Start = time.Date(2030, time.January, 12, 19, 0, 0, 0, time.UTC)
+	in.Location = models.NewGeoPoint(41.2111, 69.2797)
+	breakdown, err = Quote(rules, in)
+	require.NoError(t, err)
+	codes := make([]string, 0, len(breakdown.Items))
+	for _, item := range breakdown.Items {
+		codes = append(codes, item.Code)
+	}
+	require.Equal(t, []string{
+		models.PriceBase, models.PriceVehicle, models.PriceTimeOfDay,
+		models.PriceWeekend, models.PriceTravel, models.PriceBookingFee,
+	}, codes)
+	require.Equal(t, 30.0, breakdown.Items[2].Amount)
+	require.Equal(t, 6.0, breakdown.Items[3].Amount)
+	require.InDelta(t, 6.12, breakdown.Items[4].Amount, 0.01)
+
+	in.Location = models.NewGeoPoint(40.9, 69.2797)
+	_, err = Quote(rules, in)
+	require.ErrorIs(t, err, ErrOutOfServiceArea)
+
+	in.VehicleClass = "tank"
+	_, err = Quote(rules, in)
+	require.ErrorIs(t, err, ErrUnknownVehicleClass)
+}
+
+func TestMerge(t *testing.T) {
+	defaults := &models.PricingRules{
+		VehicleMultipliers: map[string]float64{models.VehicleSUV: 1.2},
+		WeekendMultiplier:  1.1,
+		BookingFee:         3,
+		TaxRate:            0.12,
+	}
+	provider := &models.PricingRules{
+		ProviderID:        "p1",
+		WeekendMultiplier: 1.3,
+		BookingFee:        100,
+		TaxRate:           0,
+	}
+
+	rules := Merge(defaults, provider)
+	require.Equal(t, defaults.VehicleMultipliers, rules.VehicleMultipliers)
+	require.Equal(t, 1.3, rules.WeekendMultiplier)
+	require.Equal(t, 3.0, rules.BookingFee, "booking fee is platform-wide")
+	require.Equal(t, 0.12, rules.TaxRate, "tax rate is platform-wide")
+	require.Equal(t, 1.1, defaults.WeekendMultiplier, "defaults are not modified")
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(Defaults()))
+	require.ErrorIs(t, Validate(&models.PricingRules{VehicleMultipliers: map[string]float64{"tank": 2}}), ErrInvalidRules)
+	require.ErrorIs(t, Validate(&models.PricingRules{TimeRules: []models.TimeRule{
+		{Name: "Night", Start: "22:00", End: "06:00", Multiplier: 1.2},
+	}}), ErrInvalidRules)
+	require.ErrorIs(t, Validate(&models.PricingRules{TaxRate: 12}), ErrInvalidRules)
+	require.ErrorIs(t, Validate(&models.PricingRules{Cancellation: &models.CancellationPolicy{
+		Tiers: []models.CancellationTier{{HoursBefore: 2, FeePercent: 150}},
+	}}), ErrInvalidRules)
+}
+
+func TestCancellationFee(t *testing.T) {
+	policy := &models.CancellationPolicy{
+		Tiers: []models.CancellationTier{
+			{HoursBefore: 24, FeePercent: 20},
+			{HoursBefore: 2, FeePercent: 50},
+		},
+		EnRouteFeePercent: 100,
+	}
+
+	tests := []struct {
+		name   string
+		status string
+		notice time.Duration
+		fee    float64
+	}{
+		{"more than a day ahead", models.StatusConfirmed, 30 * time.Hour, 0},
+		{"within a day", models.StatusConfirmed, 5 * time.Hour, 16},
+		{"within two hours", models.StatusPending, time.Hour, 40},
+		{"after the start", models.StatusConfirmed, -time.Hour, 40},
+		{"en route", models.StatusEnRoute, 30 * time.Hour, 80},
+	}
+	for _, tt := range tests {
+		fee := CancellationFee(policy, 80, tt.status, monday.Add(tt.notice), monday)
+		require.Equal(t, tt.fee, fee, tt.name)
+	}
+
+	require.Zero(t, CancellationFee(nil, 80, models.StatusEnRoute, monday, monday), "no policy")
+}
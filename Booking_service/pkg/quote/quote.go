@@ -0,0 +1,130 @@
+// Package quote issues price quotes whose ID carries the quoted price and an HMAC
+// signature, so a booking can be charged what the customer was shown without the
+// quote being stored.
+package quote
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidQuote = errors.New("invalid quote")
+	ErrQuoteExpired = errors.New("quote has expired")
+	ErrQuoteChanged = errors.New("booking does not match the quote")
+)
+
+// Quote is the content of a quote ID: the booking inputs that were priced and the price.
+type Quote struct {
+	UserID         string                `json:"user_id"`
+	OrganizationID string                `json:"organization_id,omitempty"`
+	ProviderID     string                `json:"provider_id"`
+	ServiceID      string                `json:"service_id"`
+	ScheduledTime  string                `json:"scheduled_time"`
+	VehicleClass   string                `json:"vehicle_class"`
+	Latitude       float64               `json:"latitude"`
+	Longitude      float64               `json:"longitude"`
+	Price          models.PriceBreakdown `json:"price"`
+	ExpiresAt      time.Time             `json:"expires_at"`
+}
+
+// New builds the quote of a booking request.
+func New(req *pb.CreateBookingRequest, price *models.PriceBreakdown, expiresAt time.Time) *Quote {
+	q := &Quote{
+		UserID:         req.UserId,
+		OrganizationID: req.OrganizationId,
+		ProviderID:     req.ProviderId,
+		ServiceID:      req.ServiceId,
+		ScheduledTime:  req.ScheduledTime,
+		VehicleClass:   req.VehicleClass,
+		Price:          *price,
+		ExpiresAt:      expiresAt.UTC(),
+	}
+	if req.Location != nil {
+		q.Latitude, q.Longitude = req.Location.Latitude, req.Location.Longitude
+	}
+	return q
+}
+
+// Matches reports whether the booking request asks for exactly what was quoted.
+func (q *Quote) Matches(req *pb.CreateBookingRequest) bool {
+	var lat, lon float64
+	if req.Location != nil {
+		lat, lon = req.Location.Latitude, req.Location.Longitude
+	}
+	return q.UserID == req.UserId &&
+		q.OrganizationID == req.OrganizationId &&
+		q.ProviderID == req.ProviderId &&
+		q.ServiceID == req.ServiceId &&
+		q.ScheduledTime == req.ScheduledTime &&
+		q.VehicleClass == req.VehicleClass &&
+		q.Latitude == lat && q.Longitude == lon
+}
+
+// Signer turns quotes into signed IDs and back.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	return &Signer{secret: []byte(secret), ttl: ttl}
+}
+
+// TTL is how long a quote can be booked.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign returns the ID of the quote.
+func (s *Signer) Sign(q *Quote) (string, error) {
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode quote")
+	}
+	return encode(payload) + "." + encode(s.mac(payload)), nil
+}
+
+// Verify checks the signature of the quote ID and that the quote has not expired
+// at now, and returns the quote.
+func (s *Signer) Verify(id string, now time.Time) (*Quote, error) {
+	body, sig, ok := strings.Cut(id, ".")
+	if !ok {
+		return nil, ErrInvalidQuote
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidQuote
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return nil, ErrInvalidQuote
+	}
+
+	var q Quote
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return nil, ErrInvalidQuote
+	}
+	if !now.Before(q.ExpiresAt) {
+		return nil, ErrQuoteExpired
+	}
+	return &q, nil
+}
+
+func (s *Signer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,51 @@
+package quote
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+	req := &pb.CreateBookingRequest{
+		UserId:        "user123",
+		ProviderId:    "provider123",
+		ServiceId:     "service123",
+		ScheduledTime: "2030-01-08T10:00:00Z",
+		Location:      &pb.GeoPoint{Latitude: 41.3111, Longitude: 69.2797},
+		VehicleClass:  models.VehicleSUV,
+	}
+	price := &models.PriceBreakdown{
+		Items: []models.PriceItem{{Code: models.PriceBase, Amount: 50}},
+		Total: 50,
+	}
+
+	signer := NewSigner("secret", 5*time.Minute)
+	id, err := signer.Sign(New(req, price, now.Add(signer.TTL())))
+	require.NoError(t, err)
+
+	q, err := signer.Verify(id, now)
+	require.NoError(t, err)
+	require.Equal(t, *price, q.Price)
+	require.True(t, q.Matches(req))
+
+	req.OrganizationId = "org123"
+	require.False(t, q.Matches(req), "a personal quote cannot be billed to an organization")
+	req.OrganizationId = ""
+
+	req.VehicleClass = models.VehicleSedan
+	require.False(t, q.Matches(req))
+
+	_, err = signer.Verify(id, now.Add(5*time.Minute))
+	require.ErrorIs(t, err, ErrQuoteExpired)
+
+	_, err = NewSigner("other", time.Minute).Verify(id, now)
+	require.ErrorIs(t, err, ErrInvalidQuote)
+
+	_, err = signer.Verify("x"+id, now)
+	require.ErrorIs(t, err, ErrInvalidQuote)
+}
@@ -0,0 +1,135 @@
+// Package slots calculates when a provider is open and which start times are still free.
+package slots
+
+import (
+	"Booking_Service/models"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// DateLayout is the format of days used by holidays and slot queries.
+const DateLayout = "2006-01-02"
+
+// Step is the distance between two consecutive slot start times.
+const Step = 30 * time.Minute
+
+// Interval is a half-open time range [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether the two intervals share any moment.
+func (i Interval) Overlaps(o Interval) bool {
+	return i.Start.Before(o.End) && o.Start.Before(i.End)
+}
+
+// Within reports whether the interval lies completely inside o.
+func (i Interval) Within(o Interval) bool {
+	return !i.Start.Before(o.Start) && !i.End.After(o.End)
+}
+
+// Location returns the time zone the provider's working hours and holidays are given
+// in. Providers without one work in UTC.
+func Location(provider *models.Provider) (*time.Location, error) {
+	if provider.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(provider.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", provider.Timezone)
+	}
+	return loc, nil
+}
+
+// Windows returns the opening windows of the provider on the day the given time falls
+// on in the provider's time zone, whatever offset the time itself carries. Holidays
+// close the whole day. Providers without a weekly schedule fall back to the legacy
+// "HH:MM-HH:MM" availability string, applied to every day of the week.
+func Windows(provider *models.Provider, day time.Time) ([]Interval, error) {
+	loc, err := Location(provider)
+	if err != nil {
+		return nil, err
+	}
+	day = day.In(loc)
+
+	for _, holiday := range provider.Holidays {
+		if holiday == day.Format(DateLayout) {
+			return nil, nil
+		}
+	}
+
+	hours := provider.Schedule
+	if len(hours) == 0 && provider.Availability != "" {
+		start, end, ok := strings.Cut(provider.Availability, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid availability %q", provider.Availability)
+		}
+		hours = []models.WorkingHours{{Weekday: int32(day.Weekday()), Start: start, End: end}}
+	}
+
+	var windows []Interval
+	for _, h := range hours {
+		if time.Weekday(h.Weekday) != day.Weekday() {
+			continue
+		}
+		start, err := atClock(day, h.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := atClock(day, h.End)
+		if err != nil {
+			return nil, err
+		}
+		if !start.Before(end) {
+			return nil, fmt.Errorf("working hours %s-%s end before they start", h.Start, h.End)
+		}
+		windows = append(windows, Interval{Start: start, End: end})
+	}
+
+	return windows, nil
+}
+
+// Free returns every slot of the given length that starts on a Step boundary inside
+// one of the windows and does not overlap any busy interval.
+func Free(windows, busy []Interval, length time.Duration) []Interval {
+	var free []Interval
+	for _, w := range windows {
+		for start := w.Start; !start.Add(length).After(w.End); start = start.Add(Step) {
+			slot := Interval{Start: start, End: start.Add(length)}
+			if !overlapsAny(slot, busy) {
+				free = append(free, slot)
+			}
+		}
+	}
+	return free
+}
+
+// Fits reports whether the slot lies inside one of the windows.
+func Fits(slot Interval, windows []Interval) bool {
+	for _, w := range windows {
+		if slot.Within(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlapsAny(slot Interval, busy []Interval) bool {
+	for _, b := range busy {
+		if slot.Overlaps(b) {
+			return true
+		}
+	}
+	return false
+}
+
+func atClock(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
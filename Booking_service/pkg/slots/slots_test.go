@@ -0,0 +1,90 @@
+package slots
+
+import (
+	"Booking_Service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// monday is 2030-01-07, a Monday.
+var monday = time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return monday.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestWindows(t *testing.T) {
+	provider := &models.Provider{
+		Schedule: []models.WorkingHours{
+			{Weekday: int32(time.Monday), Start: "09:00", End: "12:00"},
+			{Weekday: int32(time.Monday), Start: "13:00", End: "18:00"},
+			{Weekday: int32(time.Tuesday), Start: "10:00", End: "16:00"},
+		},
+		Holidays: []string{"2030-01-14"},
+	}
+
+	windows, err := Windows(provider, monday)
+	require.NoError(t, err)
+	require.Equal(t, []Interval{{at(9, 0), at(12, 0)}, {at(13, 0), at(18, 0)}}, windows)
+
+	windows, err = Windows(provider, monday.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Empty(t, windows, "holiday")
+
+	windows, err = Windows(provider, monday.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Empty(t, windows, "day without working hours")
+}
+
+func TestWindowsLegacyAvailability(t *testing.T) {
+	windows, err := Windows(&models.Provider{Availability: "09:00-18:00"}, monday)
+	require.NoError(t, err)
+	require.Equal(t, []Interval{{at(9, 0), at(18, 0)}}, windows)
+
+	_, err = Windows(&models.Provider{Availability: "all day"}, monday)
+	require.Error(t, err)
+}
+
+func TestWindowsTimezone(t *testing.T) {
+	provider := &models.Provider{
+		Timezone: "America/New_York",
+		Schedule: []models.WorkingHours{{Weekday: int32(time.Monday), Start: "09:00", End: "17:00"}},
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 09:00 with a +00:00 offset is 04:00 in New York, before the provider opens.
+	early := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.FixedZone("", 0))
+	windows, err := Windows(provider, early)
+	require.NoError(t, err)
+	require.Len(t, windows, 1)
+	require.True(t, windows[0].Start.Equal(time.Date(2030, time.January, 7, 9, 0, 0, 0, newYork)))
+	require.False(t, Fits(Interval{early, early.Add(time.Hour)}, windows))
+
+	// The same instant in any offset lands in the same window.
+	open := time.Date(2030, time.January, 7, 15, 0, 0, 0, time.UTC)
+	require.True(t, Fits(Interval{open, open.Add(time.Hour)}, windows))
+	require.True(t, Fits(Interval{open.In(time.FixedZone("", 9*3600)), open.Add(time.Hour)}, windows))
+
+	_, err = Windows(&models.Provider{Timezone: "Mars/Olympus"}, early)
+	require.Error(t, err)
+}
+
+func TestFree(t *testing.T) {
+	windows := []Interval{{at(9, 0), at(12, 0)}}
+	busy := []Interval{{at(10, 0), at(11, 0)}}
+
+	free := Free(windows, busy, time.Hour)
+	require.Equal(t, []Interval{
+		{at(9, 0), at(10, 0)},
+		{at(11, 0), at(12, 0)},
+	}, free)
+}
+
+func TestFits(t *testing.T) {
+	windows := []Interval{{at(9, 0), at(12, 0)}}
+	require.True(t, Fits(Interval{at(11, 0), at(12, 0)}, windows))
+	require.False(t, Fits(Interval{at(11, 30), at(12, 30)}, windows))
+}
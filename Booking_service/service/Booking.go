@@ -2,28 +2,57 @@ package service
 
 import (
 	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/pricing"
+	"Booking_Service/pkg/quote"
+	"Booking_Service/pkg/slots"
 	"Booking_Service/storage"
 	"context"
 	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 type BookingService struct {
 	pb.UnimplementedBookingServiceServer
-	storage storage.IStorage
-	logger  *slog.Logger
+	storage  storage.IStorage
+	quotes   *quote.Signer
+	payments *PaymentService
+	logger   *slog.Logger
 }
 
-func NewBookingService(s storage.IStorage, logger *slog.Logger) *BookingService {
+// NewBookingService creates a new BookingService. Price quotes are signed by quotes,
+// and payments refunds the bookings that are canceled.
+func NewBookingService(s storage.IStorage, quotes *quote.Signer, payments *PaymentService, logger *slog.Logger) *BookingService {
 	return &BookingService{
-		storage: s,
-		logger:  logger,
+		storage:  s,
+		quotes:   quotes,
+		payments: payments,
+		logger:   logger,
 	}
 }
 
 func (s *BookingService) CreateBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.BookingResponse, error) {
 	s.logger.Info("CreateBooking called", "request", req)
 
-	resp, err := s.storage.Booking().Add(ctx, req)
+	// A valid quote fixes the price to what the customer was shown.
+	var quoted *models.PriceBreakdown
+	if req.QuoteId != "" {
+		q, err := s.quotes.Verify(req.QuoteId, time.Now())
+		if err != nil {
+			s.logger.Warn("rejected booking quote", "error", err)
+			return nil, err
+		}
+		if !q.Matches(req) {
+			s.logger.Warn("booking does not match its quote", "user_id", req.UserId)
+			return nil, quote.ErrQuoteChanged
+		}
+		quoted = &q.Price
+	}
+
+	resp, err := s.storage.Booking().Add(ctx, req, quoted)
 
 	if err != nil {
 		s.logger.Error("failed to create booking", "error", err)
@@ -34,6 +63,33 @@ func (s *BookingService) CreateBooking(ctx context.Context, req *pb.CreateBookin
 	return resp, nil
 }
 
+// QuoteBooking prices a booking request without creating the booking. The returned
+// quote ID can be passed to CreateBooking until it expires.
+func (s *BookingService) QuoteBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.BookingQuote, error) {
+	s.logger.Info("QuoteBooking called", "request", req)
+
+	price, err := s.storage.Booking().Quote(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to quote booking", "error", err)
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.quotes.TTL())
+	id, err := s.quotes.Sign(quote.New(req, price, expiresAt))
+	if err != nil {
+		s.logger.Error("failed to sign quote", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Booking quoted", "user_id", req.UserId, "total", price.Total)
+	return &pb.BookingQuote{
+		QuoteId:        id,
+		TotalPrice:     price.Total,
+		PriceBreakdown: pricing.ToResponse(price),
+		ExpiresAt:      expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
 func (s *BookingService) GetBooking(ctx context.Context, req *pb.IdRequest) (*pb.BookingResponse, error) {
 	s.logger.Info("GetBooking called", "request", req)
 
@@ -60,19 +116,123 @@ func (s *BookingService) UpdateBooking(ctx context.Context, req *pb.UpdateBookin
 	return resp, nil
 }
 
-func (s *BookingService) CancelBooking(ctx context.Context, req *pb.IdRequest) (*pb.BookingResponse, error) {
+// CancelBooking cancels the booking and charges the cancellation fee of the provider's
+// policy, evaluated against the scheduled time. The fee is kept from the payment and
+// the rest refunded; an unpaid booking owes it.
+func (s *BookingService) CancelBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
 	s.logger.Info("CancelBooking called", "request", req)
 
-	resp, err := s.storage.Booking().Cancel(ctx, req)
+	booking, err := s.storage.Booking().Read(ctx, &pb.IdRequest{Id: req.Id})
+	if err != nil {
+		s.logger.Error("failed to get booking", "error", err)
+		return nil, err
+	}
+	if err := validateTransition(booking.Status, models.StatusCanceled); err != nil {
+		s.logger.Error("rejected booking status change", "id", req.Id, "error", err)
+		return nil, err
+	}
+	fee, err := s.cancellationFee(ctx, booking)
+	if err != nil {
+		s.logger.Error("failed to calculate cancellation fee", "id", req.Id, "error", err)
+		return nil, err
+	}
+
+	// The fee is saved by the same update that cancels, which only applies while the
+	// booking is still in the status the fee was calculated for.
+	resp, err := s.storage.Booking().Cancel(ctx, req.Id, booking.Status, fee, &models.StatusChange{
+		FromStatus: booking.Status,
+		ToStatus:   models.StatusCanceled,
+		ChangedBy:  req.ActorId,
+		Reason:     req.Reason,
+		ChangedAt:  time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		s.logger.Error("failed to cancel booking", "id", req.Id, "fee", fee, "error", err)
+		return nil, err
+	}
+	s.logger.Info("Booking status changed successfully", "id", req.Id, "from", booking.Status, "to", models.StatusCanceled)
+
+	// The booking stays canceled when the refund fails; the payment can still be
+	// refunded by an admin.
+	reason := "booking canceled"
+	if req.Reason != "" {
+		reason += ": " + req.Reason
+	}
+	status, err := s.payments.refundCanceledBooking(ctx, req.Id, fee, reason, req.ActorId)
+	if err != nil {
+		s.logger.Error("failed to refund canceled booking", "id", req.Id, "error", err)
+	}
+	if status != "" {
+		resp.PaymentStatus = status
+	}
+	return resp, nil
+}
+
+// OverrideCancellationFee lets an admin lower or waive the fee of a canceled booking,
+// e.g. to settle a dispute, and refunds the difference.
+func (s *BookingService) OverrideCancellationFee(ctx context.Context, req *pb.CancellationFeeOverrideRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("OverrideCancellationFee called", "booking_id", req.BookingId, "fee", req.Fee, "actor_id", req.ActorId)
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, ErrOverrideReasonRequired
+	}
+	booking, err := s.storage.Booking().Read(ctx, &pb.IdRequest{Id: req.BookingId})
+	if err != nil {
+		s.logger.Error("failed to get booking", "error", err)
+		return nil, err
+	}
+	if booking.Status != models.StatusCanceled {
+		return nil, errors.Wrapf(ErrBookingNotCanceled, "booking is %s", booking.Status)
+	}
+	fee := cents(req.Fee)
+	if fee < 0 || fee > booking.CancellationFee {
+		return nil, errors.Wrapf(ErrInvalidFeeOverride, "fee %.2f, charged %.2f", fee, booking.CancellationFee)
+	}
+
+	original := booking.CancellationFee
+	if booking.CancellationFeeOverride != nil {
+		original = booking.CancellationFeeOverride.OriginalFee
+	}
+	resp, err := s.storage.Booking().SetCancellationFee(ctx, req.BookingId, fee, &models.FeeOverride{
+		OriginalFee:  original,
+		Reason:       req.Reason,
+		OverriddenBy: req.ActorId,
+		OverriddenAt: time.Now().Format(time.RFC3339),
+	})
 	if err != nil {
-		s.logger.Error("failed to cancel booking", "error", err)
+		s.logger.Error("failed to save cancellation fee", "error", err)
 		return nil, err
 	}
 
-	s.logger.Info("Booking canceled successfully", "response", resp)
+	status, err := s.payments.refundCanceledBooking(ctx, req.BookingId, fee, "cancellation fee override: "+req.Reason, req.ActorId)
+	if err != nil {
+		s.logger.Error("failed to refund overridden cancellation fee", "booking_id", req.BookingId, "error", err)
+		return nil, errors.Wrap(err, "fee was changed but the refund failed")
+	}
+	if status != "" {
+		resp.PaymentStatus = status
+	}
+
+	s.logger.Info("Cancellation fee overridden", "booking_id", req.BookingId, "original_fee", original, "fee", fee)
 	return resp, nil
 }
 
+// cancellationFee returns what canceling the booking now costs under the provider's policy.
+func (s *BookingService) cancellationFee(ctx context.Context, booking *pb.BookingResponse) (float64, error) {
+	rules, err := s.storage.Pricing().Effective(ctx, booking.ProviderId)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get cancellation policy")
+	}
+	if rules.Cancellation == nil {
+		return 0, nil
+	}
+	start, err := time.Parse(time.RFC3339, booking.ScheduledTime)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid scheduled time")
+	}
+	return pricing.CancellationFee(rules.Cancellation, booking.TotalPrice, booking.Status, start, time.Now()), nil
+}
+
 func (s *BookingService) ListBookings(ctx context.Context, req *pb.ListBookingsRequest) (*pb.ListBookingsResponse, error) {
 	s.logger.Info("ListBookings called", "request", req)
 
@@ -85,3 +245,221 @@ func (s *BookingService) ListBookings(ctx context.Context, req *pb.ListBookingsR
 	s.logger.Info("Bookings listed successfully", "response", resp)
 	return resp, nil
 }
+
+func (s *BookingService) ConfirmBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("ConfirmBooking called", "request", req)
+	return s.transition(ctx, req, models.StatusConfirmed)
+}
+
+func (s *BookingService) RejectBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("RejectBooking called", "request", req)
+	return s.transition(ctx, req, models.StatusRejected)
+}
+
+func (s *BookingService) MarkEnRoute(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("MarkEnRoute called", "request", req)
+	return s.transition(ctx, req, models.StatusEnRoute)
+}
+
+func (s *BookingService) StartBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("StartBooking called", "request", req)
+	return s.transition(ctx, req, models.StatusInProgress)
+}
+
+func (s *BookingService) CompleteBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("CompleteBooking called", "request", req)
+	return s.transition(ctx, req, models.StatusCompleted)
+}
+
+func (s *BookingService) MarkNoShow(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("MarkNoShow called", "request", req)
+	return s.transition(ctx, req, models.StatusNoShow)
+}
+
+func (s *BookingService) ListIncomingBookings(ctx context.Context, req *pb.ListIncomingBookingsRequest) (*pb.ListBookingsResponse, error) {
+	s.logger.Info("ListIncomingBookings called", "request", req)
+
+	providerIDs, err := s.storage.Provider().ListIDsByUser(ctx, req.ProviderUserId)
+	if err != nil {
+		s.logger.Error("failed to list providers of user", "error", err)
+		return nil, errors.Wrap(err, "failed to list providers of user")
+	}
+	if len(providerIDs) == 0 {
+		return &pb.ListBookingsResponse{}, nil
+	}
+
+	resp, err := s.storage.Booking().ListByProviders(ctx, providerIDs, models.StatusPending, req.Page, req.Limit)
+	if err != nil {
+		s.logger.Error("failed to list incoming bookings", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Incoming bookings listed successfully", "count", len(resp.Bookings))
+	return resp, nil
+}
+
+func (s *BookingService) AcceptBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("AcceptBooking called", "request", req)
+	if err := s.checkProviderOwner(ctx, req); err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, req, models.StatusConfirmed)
+}
+
+// DeclineBooking is RejectBooking for the provider the booking is addressed to.
+func (s *BookingService) DeclineBooking(ctx context.Context, req *pb.BookingTransitionRequest) (*pb.BookingResponse, error) {
+	s.logger.Info("DeclineBooking called", "request", req)
+	if err := s.checkProviderOwner(ctx, req); err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, req, models.StatusRejected)
+}
+
+func (s *BookingService) GetAvailableSlots(ctx context.Context, req *pb.AvailableSlotsRequest) (*pb.AvailableSlotsResponse, error) {
+	s.logger.Info("GetAvailableSlots called", "request", req)
+
+	service, err := s.storage.Management().GetServiceByID(ctx, &pb.IdRequest{Id: req.ServiceId})
+	if err != nil {
+		s.logger.Error("failed to get service", "error", err)
+		return nil, err
+	}
+
+	provider, err := s.storage.Provider().GetByID(ctx, req.ProviderId)
+	if err != nil {
+		s.logger.Error("failed to get provider", "error", err)
+		return nil, err
+	}
+	if provider.Status != models.ProviderApproved {
+		return nil, storage.ErrProviderNotApproved
+	}
+
+	// The date is a day of the provider's calendar, not of the caller's.
+	loc, err := slots.Location(provider)
+	if err != nil {
+		s.logger.Error("invalid provider timezone", "error", err)
+		return nil, errors.Wrap(err, "invalid provider schedule")
+	}
+	day, err := time.ParseInLocation(slots.DateLayout, req.Date, loc)
+	if err != nil {
+		s.logger.Error("invalid date", "error", err)
+		return nil, errors.Wrap(err, "invalid date format, expected YYYY-MM-DD")
+	}
+
+	windows, err := slots.Windows(provider, day)
+	if err != nil {
+		s.logger.Error("invalid provider schedule", "error", err)
+		return nil, errors.Wrap(err, "invalid provider schedule")
+	}
+
+	busy, err := s.storage.Booking().ListBusy(ctx, req.ProviderId, slots.Interval{Start: day, End: day.AddDate(0, 0, 1)})
+	if err != nil {
+		s.logger.Error("failed to list provider bookings", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &pb.AvailableSlotsResponse{}
+	for _, slot := range slots.Free(windows, busy, time.Duration(service.Duration)*time.Minute) {
+		if slot.Start.Before(now) {
+			continue
+		}
+		resp.Slots = append(resp.Slots, &pb.TimeSlot{
+			StartTime: slot.Start.Format(time.RFC3339),
+			EndTime:   slot.End.Format(time.RFC3339),
+		})
+	}
+
+	s.logger.Info("Available slots calculated successfully", "count", len(resp.Slots))
+	return resp, nil
+}
+
+// RejectPendingBefore rejects every booking that is still pending and was created
+// before the given time. It is used to release customers whose request was never answered.
+func (s *BookingService) RejectPendingBefore(ctx context.Context, before time.Time) {
+	bookings, err := s.storage.Booking().ListCreatedBefore(ctx, models.StatusPending, before)
+	if err != nil {
+		s.logger.Error("failed to list stale bookings", "error", err)
+		return
+	}
+
+	for _, booking := range bookings {
+		_, err := s.transition(ctx, &pb.BookingTransitionRequest{
+			Id:      booking.Id,
+			ActorId: SystemActor,
+			Reason:  "provider did not respond in time",
+		}, models.StatusRejected)
+		if err != nil {
+			s.logger.Error("failed to auto-reject booking", "id", booking.Id, "error", err)
+		}
+	}
+}
+
+// RunAcceptanceTimeout auto-rejects pending bookings older than timeout, checking
+// every interval until ctx is done.
+func (s *BookingService) RunAcceptanceTimeout(ctx context.Context, timeout, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RejectPendingBefore(ctx, time.Now().Add(-timeout))
+		}
+	}
+}
+
+// checkProviderOwner makes sure the booking is addressed to a provider owned by the actor.
+func (s *BookingService) checkProviderOwner(ctx context.Context, req *pb.BookingTransitionRequest) error {
+	booking, err := s.storage.Booking().Read(ctx, &pb.IdRequest{Id: req.Id})
+	if err != nil {
+		s.logger.Error("failed to get booking", "error", err)
+		return err
+	}
+
+	providerIDs, err := s.storage.Provider().ListIDsByUser(ctx, req.ActorId)
+	if err != nil {
+		s.logger.Error("failed to list providers of user", "error", err)
+		return errors.Wrap(err, "failed to list providers of user")
+	}
+
+	for _, id := range providerIDs {
+		if id == booking.ProviderId {
+			return nil
+		}
+	}
+
+	s.logger.Error("booking does not belong to provider", "id", req.Id, "actor", req.ActorId)
+	return ErrNotBookingProvider
+}
+
+// transition validates that the booking may move to the given status and records
+// the change together with the actor and reason in the booking history.
+func (s *BookingService) transition(ctx context.Context, req *pb.BookingTransitionRequest, to string) (*pb.BookingResponse, error) {
+	booking, err := s.storage.Booking().Read(ctx, &pb.IdRequest{Id: req.Id})
+	if err != nil {
+		s.logger.Error("failed to get booking", "error", err)
+		return nil, err
+	}
+
+	if err := validateTransition(booking.Status, to); err != nil {
+		s.logger.Error("rejected booking status change", "id", req.Id, "error", err)
+		return nil, err
+	}
+
+	resp, err := s.storage.Booking().UpdateStatus(ctx, req.Id, booking.Status, &models.StatusChange{
+		FromStatus: booking.Status,
+		ToStatus:   to,
+		ChangedBy:  req.ActorId,
+		Reason:     req.Reason,
+		ChangedAt:  time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		s.logger.Error("failed to update booking status", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Booking status changed successfully", "id", req.Id, "from", booking.Status, "to", to)
+	return resp, nil
+}
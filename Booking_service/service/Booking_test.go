@@ -0,0 +1,102 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/quote"
+	"Booking_Service/storage"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// bookingStorage prices every booking at price and records the bookings it adds.
+// Other storages are left nil.
+type bookingStorage struct {
+	storage.IStorage
+	storage.IBookingStorage
+	price float64
+	added []*pb.CreateBookingRequest
+}
+
+func (s *bookingStorage) Booking() storage.IBookingStorage { return s }
+
+func (s *bookingStorage) Quote(ctx context.Context, req *pb.CreateBookingRequest) (*models.PriceBreakdown, error) {
+	return &models.PriceBreakdown{
+		Items: []models.PriceItem{{Code: models.PriceBase, Amount: s.price}},
+		Total: s.price,
+	}, nil
+}
+
+func (s *bookingStorage) Add(ctx context.Context, req *pb.CreateBookingRequest, quoted *models.PriceBreakdown) (*pb.BookingResponse, error) {
+	s.added = append(s.added, req)
+	total := s.price
+	if quoted != nil {
+		total = quoted.Total
+	}
+	return &pb.BookingResponse{
+		UserId:         req.UserId,
+		OrganizationId: req.OrganizationId,
+		TotalPrice:     total,
+		Status:         models.StatusPending,
+	}, nil
+}
+
+func TestCreateBookingFromQuote(t *testing.T) {
+	newRequest := func(orgID string) *pb.CreateBookingRequest {
+		return &pb.CreateBookingRequest{
+			UserId:         "user123",
+			ProviderId:     "provider123",
+			ServiceId:      "service123",
+			ScheduledTime:  "2030-01-08T10:00:00Z",
+			Location:       &pb.GeoPoint{Latitude: 41.3111, Longitude: 69.2797},
+			OrganizationId: orgID,
+			VehicleClass:   models.VehicleSedan,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		quotedOrg string
+		bookedOrg string
+		err       error
+	}{
+		{name: "Personal"},
+		{name: "Organization", quotedOrg: "org123", bookedOrg: "org123"},
+		{name: "PersonalQuoteForOrganization", bookedOrg: "org123", err: quote.ErrQuoteChanged},
+		{name: "OtherOrganization", quotedOrg: "org123", bookedOrg: "org456", err: quote.ErrQuoteChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := &bookingStorage{price: 50}
+			svc := NewBookingService(s, quote.NewSigner("secret", 5*time.Minute), nil, testLogger)
+
+			q, err := svc.QuoteBooking(ctx, newRequest(tt.quotedOrg))
+			require.NoError(t, err)
+			require.Equal(t, 50.0, q.TotalPrice)
+
+			// The provider raises the price after the quote; the booking keeps the quoted one.
+			s.price = 80
+			req := newRequest(tt.bookedOrg)
+			req.QuoteId = q.QuoteId
+			resp, err := svc.CreateBooking(ctx, req)
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+				require.Empty(t, s.added)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 50.0, resp.TotalPrice)
+			require.Equal(t, tt.bookedOrg, resp.OrganizationId)
+		})
+	}
+}
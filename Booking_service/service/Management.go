@@ -4,7 +4,6 @@ import (
 	pb "Booking_Service/genproto/booking"
 	"Booking_Service/storage"
 	"context"
-	"errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"log/slog"
 	"time"
@@ -40,7 +39,7 @@ func (s *ManagementService) GetServiceByID(ctx context.Context, req *pb.IdReques
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			s.logger.Warn("Service not found", "id", req.Id)
-			return nil, errors.New("service not found")
+			return nil, storage.ErrServiceNotFound
 		}
 		s.logger.Error("Failed to fetch service", "error", err)
 		return nil, err
@@ -0,0 +1,142 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Requeuer publishes a dead-lettered message back to its original queue.
+// It is implemented by the message broker.
+type Requeuer interface {
+	Requeue(ctx context.Context, dl *models.DeadLetter) error
+}
+
+type DeadLetterService struct {
+	pb.UnimplementedDeadLetterServiceServer
+	storage  storage.IStorage
+	requeuer Requeuer
+	logger   *slog.Logger
+}
+
+func NewDeadLetterService(s storage.IStorage, requeuer Requeuer, logger *slog.Logger) *DeadLetterService {
+	return &DeadLetterService{
+		storage:  s,
+		requeuer: requeuer,
+		logger:   logger,
+	}
+}
+
+// Save parks a message that will not be retried anymore.
+func (s *DeadLetterService) Save(ctx context.Context, dl *models.DeadLetter) error {
+	id, err := s.storage.DeadLetter().Save(ctx, dl)
+	if err != nil {
+		s.logger.Error("failed to save dead letter", "queue", dl.Queue, "error", err)
+		return err
+	}
+
+	s.logger.Warn("Message dead-lettered", "id", id, "queue", dl.Queue, "operation_id", dl.OperationID, "error", dl.Error)
+	return nil
+}
+
+func (s *DeadLetterService) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	s.logger.Info("ListDeadLetters called", "request", req)
+
+	dls, err := s.storage.DeadLetter().List(ctx, req.Queue, req.Status, req.Page, req.Limit)
+	if err != nil {
+		s.logger.Error("failed to list dead letters", "error", err)
+		return nil, err
+	}
+
+	resp := &pb.ListDeadLettersResponse{}
+	for _, dl := range dls {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterToResponse(dl))
+	}
+	return resp, nil
+}
+
+func (s *DeadLetterService) GetDeadLetter(ctx context.Context, req *pb.IdRequest) (*pb.DeadLetter, error) {
+	s.logger.Info("GetDeadLetter called", "request", req)
+
+	dl, err := s.storage.DeadLetter().Get(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to get dead letter", "error", err)
+		return nil, err
+	}
+	return deadLetterToResponse(dl), nil
+}
+
+// ReplayDeadLetter publishes the message to its original queue again with a fresh
+// attempt counter. Each dead letter can only be replayed once.
+func (s *DeadLetterService) ReplayDeadLetter(ctx context.Context, req *pb.IdRequest) (*pb.DeadLetter, error) {
+	s.logger.Info("ReplayDeadLetter called", "request", req)
+
+	dl, err := s.storage.DeadLetter().Get(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to get dead letter", "error", err)
+		return nil, err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	if err := s.storage.DeadLetter().SetStatus(ctx, req.Id, models.DeadLetterDead, models.DeadLetterReplayed, now); err != nil {
+		s.logger.Error("failed to mark dead letter as replayed", "error", err)
+		return nil, err
+	}
+
+	if err := s.requeuer.Requeue(ctx, dl); err != nil {
+		s.logger.Error("failed to replay dead letter", "id", req.Id, "error", err)
+		if err := s.storage.DeadLetter().SetStatus(ctx, req.Id, models.DeadLetterReplayed, models.DeadLetterDead, ""); err != nil {
+			s.logger.Error("failed to restore dead letter status", "id", req.Id, "error", err)
+		}
+		return nil, errors.Wrap(err, "failed to replay dead letter")
+	}
+
+	dl.Status = models.DeadLetterReplayed
+	dl.ReplayedAt = now
+	s.logger.Info("Dead letter replayed successfully", "id", req.Id, "queue", dl.Queue)
+	return deadLetterToResponse(dl), nil
+}
+
+func (s *DeadLetterService) DeleteDeadLetter(ctx context.Context, req *pb.IdRequest) (*pb.Void, error) {
+	s.logger.Info("DeleteDeadLetter called", "request", req)
+
+	if err := s.storage.DeadLetter().Delete(ctx, req.Id); err != nil {
+		s.logger.Error("failed to delete dead letter", "error", err)
+		return nil, err
+	}
+	return &pb.Void{}, nil
+}
+
+func (s *DeadLetterService) PurgeDeadLetters(ctx context.Context, req *pb.PurgeDeadLettersRequest) (*pb.PurgeDeadLettersResponse, error) {
+	s.logger.Info("PurgeDeadLetters called", "request", req)
+
+	deleted, err := s.storage.DeadLetter().Purge(ctx, req.Queue)
+	if err != nil {
+		s.logger.Error("failed to purge dead letters", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Dead letters purged successfully", "queue", req.Queue, "deleted", deleted)
+	return &pb.PurgeDeadLettersResponse{Deleted: deleted}, nil
+}
+
+func deadLetterToResponse(dl *models.DeadLetter) *pb.DeadLetter {
+	return &pb.DeadLetter{
+		Id:          dl.ID.Hex(),
+		Queue:       dl.Queue,
+		OperationId: dl.OperationID,
+		UserId:      dl.UserID,
+		Body:        dl.Body,
+		Error:       dl.Error,
+		Attempts:    dl.Attempts,
+		Poison:      dl.Poison,
+		Status:      dl.Status,
+		FailedAt:    dl.FailedAt,
+		ReplayedAt:  dl.ReplayedAt,
+	}
+}
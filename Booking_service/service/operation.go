@@ -0,0 +1,87 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OperationRetention is how long the outcome of a broker request can be looked up.
+const OperationRetention = 24 * time.Hour
+
+type OperationService struct {
+	pb.UnimplementedOperationServiceServer
+	storage storage.IStorage
+	logger  *slog.Logger
+}
+
+func NewOperationService(s storage.IStorage, logger *slog.Logger) *OperationService {
+	return &OperationService{
+		storage: s,
+		logger:  logger,
+	}
+}
+
+func (s *OperationService) GetOperation(ctx context.Context, req *pb.IdRequest) (*pb.OperationResponse, error) {
+	s.logger.Info("GetOperation called", "request", req)
+
+	op, err := s.storage.Operation().Get(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to get operation", "error", err)
+		return nil, err
+	}
+
+	return operationToResponse(op), nil
+}
+
+// Record stores the outcome of the broker request with the given operation ID.
+// result is the resource the request produced and is kept as JSON.
+func (s *OperationService) Record(ctx context.Context, id, kind, userID string, result interface{}, opErr error) (*pb.OperationResponse, error) {
+	now := time.Now()
+	op := &models.Operation{
+		ID:        id,
+		Kind:      kind,
+		Status:    models.OperationSucceeded,
+		UserID:    userID,
+		CreatedAt: now.Format(time.RFC3339),
+		UpdatedAt: now.Format(time.RFC3339),
+		ExpiresAt: now.Add(OperationRetention),
+	}
+
+	if opErr != nil {
+		op.Status = models.OperationFailed
+		op.Error = opErr.Error()
+	} else if result != nil {
+		body, err := json.Marshal(result)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal operation result")
+		}
+		op.Result = string(body)
+	}
+
+	if err := s.storage.Operation().Save(ctx, op); err != nil {
+		s.logger.Error("failed to record operation", "id", id, "error", err)
+		return nil, err
+	}
+
+	return operationToResponse(op), nil
+}
+
+func operationToResponse(op *models.Operation) *pb.OperationResponse {
+	return &pb.OperationResponse{
+		Id:        op.ID,
+		Kind:      op.Kind,
+		Status:    op.Status,
+		Error:     op.Error,
+		Result:    op.Result,
+		UserId:    op.UserID,
+		CreatedAt: op.CreatedAt,
+		UpdatedAt: op.UpdatedAt,
+	}
+}
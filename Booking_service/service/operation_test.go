@@ -0,0 +1,74 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// operationStorage keeps saved operations by ID. Other storages are left nil.
+type operationStorage struct {
+	storage.IStorage
+	storage.IOperationStorage
+	saved map[string]*models.Operation
+}
+
+func (s *operationStorage) Operation() storage.IOperationStorage { return s }
+
+func (s *operationStorage) Save(ctx context.Context, op *models.Operation) error {
+	s.saved[op.ID] = op
+	return nil
+}
+
+func (s *operationStorage) Get(ctx context.Context, id string) (*models.Operation, error) {
+	op, ok := s.saved[id]
+	if !ok {
+		return nil, errors.New("operation not found")
+	}
+	return op, nil
+}
+
+func TestRecordOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		result interface{}
+		opErr  error
+		status string
+		body   string
+		errMsg string
+	}{
+		{name: "Succeeded", result: map[string]string{"id": "booking123"}, status: models.OperationSucceeded, body: `{"id":"booking123"}`},
+		{name: "NoResult", status: models.OperationSucceeded},
+		{name: "Failed", result: map[string]string{"id": "booking123"}, opErr: errors.New("booking not found"), status: models.OperationFailed, errMsg: "booking not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := &operationStorage{saved: make(map[string]*models.Operation)}
+			svc := NewOperationService(s, testLogger)
+
+			resp, err := svc.Record(ctx, "op123", "booking.create", "user123", tt.result, tt.opErr)
+			require.NoError(t, err)
+			require.Equal(t, tt.status, resp.Status)
+			require.Equal(t, tt.body, resp.Result)
+			require.Equal(t, tt.errMsg, resp.Error)
+
+			saved := s.saved["op123"]
+			require.NotNil(t, saved)
+			require.Equal(t, "user123", saved.UserID)
+			require.WithinDuration(t, time.Now().Add(OperationRetention), saved.ExpiresAt, time.Minute)
+
+			got, err := svc.GetOperation(ctx, &pb.IdRequest{Id: "op123"})
+			require.NoError(t, err)
+			require.Equal(t, resp.Status, got.Status)
+			require.Equal(t, "booking.create", got.Kind)
+		})
+	}
+}
@@ -2,36 +2,215 @@ package service
 
 import (
 	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/payment"
 	"Booking_Service/storage"
 	"context"
 	"github.com/pkg/errors"
 	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
 )
 
+// paymentTransitions lists, for every payment status, the statuses it may move to.
+// Statuses without an entry are terminal.
+var paymentTransitions = map[string][]string{
+	models.PaymentPending:           {models.PaymentRequiresAction, models.PaymentAuthorized, models.PaymentDeclined, models.PaymentFailed},
+	models.PaymentRequiresAction:    {models.PaymentAuthorized, models.PaymentDeclined, models.PaymentVoided, models.PaymentFailed},
+	models.PaymentAuthorized:        {models.PaymentCaptured, models.PaymentVoided, models.PaymentFailed},
+	models.PaymentCaptured:          {models.PaymentPartiallyRefunded, models.PaymentRefunded},
+	models.PaymentPartiallyRefunded: {models.PaymentPartiallyRefunded, models.PaymentRefunded},
+}
+
+// eventStatuses maps the payment provider's webhook events to the status they move a payment to.
+var eventStatuses = map[string]string{
+	payment.EventAuthorized: models.PaymentAuthorized,
+	payment.EventDeclined:   models.PaymentDeclined,
+	payment.EventCaptured:   models.PaymentCaptured,
+	payment.EventVoided:     models.PaymentVoided,
+	payment.EventFailed:     models.PaymentFailed,
+}
+
+var (
+	ErrInvalidPaymentTransition = errors.New("invalid payment status transition")
+	ErrBookingAlreadyPaid       = errors.New("booking is already paid")
+	ErrNotBookingOwner          = errors.New("booking belongs to another user")
+	ErrBookingNotPayable        = errors.New("booking cannot be paid")
+	ErrUnsupportedPaymentEvent  = errors.New("unsupported payment event")
+	ErrPaymentNotRefundable     = errors.New("payment is not refundable")
+	ErrInvalidRefundAmount      = errors.New("invalid refund amount")
+	ErrRefundReasonRequired     = errors.New("refund reason is required")
+	ErrRefundRejected           = errors.New("payment provider rejected the refund")
+)
+
+func validatePaymentTransition(from, to string) error {
+	if slices.Contains(paymentTransitions[from], to) {
+		return nil
+	}
+	return errors.Wrapf(ErrInvalidPaymentTransition, "%s -> %s", from, to)
+}
+
+// paymentSourceAPI is recorded in the payment history for changes made by the service itself.
+const paymentSourceAPI = "api"
+
 type PaymentService struct {
 	pb.UnimplementedPaymentServiceServer
-	storage storage.IStorage
-	logger  *slog.Logger
+	storage  storage.IStorage
+	provider payment.PaymentProvider
+	logger   *slog.Logger
 }
 
-func NewPaymentService(storage storage.IStorage, logger *slog.Logger) *PaymentService {
+func NewPaymentService(storage storage.IStorage, provider payment.PaymentProvider, logger *slog.Logger) *PaymentService {
 	return &PaymentService{
-		storage: storage,
-		logger:  logger,
+		storage:  storage,
+		provider: provider,
+		logger:   logger,
 	}
 }
 
+// CreatePayment charges the booking's total price to the payment method. Authorized
+// payments are captured right away; payments that need a customer action stay in
+// requires_action until the provider reports the outcome through the webhook.
 func (s *PaymentService) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.PaymentResponse, error) {
-	s.logger.Info("Creating payment", "booking_id", req.BookingId, "amount", req.Amount)
+	s.logger.Info("Creating payment", "booking_id", req.BookingId, "payment_method", req.PaymentMethod)
+
+	booking, err := s.storage.Booking().Read(ctx, &pb.IdRequest{Id: req.BookingId})
+	if err != nil {
+		s.logger.Error("Failed to get booking of payment", "error", err)
+		return nil, errors.Wrap(err, "could not create payment")
+	}
+	// Members of the organization a booking is billed to may pay it too; the gateway
+	// checks their membership before the request gets here.
+	if booking.UserId != req.UserId && booking.OrganizationId == "" {
+		return nil, ErrNotBookingOwner
+	}
+	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+		return nil, errors.Wrapf(ErrBookingNotPayable, "booking is %s", booking.Status)
+	}
+	switch booking.PaymentStatus {
+	case models.PaymentRequiresAction, models.PaymentAuthorized, models.PaymentCaptured:
+		return nil, errors.Wrapf(ErrBookingAlreadyPaid, "payment is %s", booking.PaymentStatus)
+	}
+	// Payments of organization bookings are billed to the organization. The amount is
+	// never taken from the client, and the transaction comes from the provider.
+	req.OrganizationId = booking.OrganizationId
+	req.Amount = booking.TotalPrice
+	req.TransactionId = ""
 
-	payment, err := s.storage.Payment().CreatePayment(ctx, req)
+	// The check above can race a redelivered request; storage refuses a second active
+	// payment of the booking, so only one of them reaches the provider.
+	p, err := s.storage.Payment().CreatePayment(ctx, req)
+	if errors.Is(err, storage.ErrActivePayment) {
+		return nil, errors.Wrap(ErrBookingAlreadyPaid, err.Error())
+	}
 	if err != nil {
 		s.logger.Error("Failed to create payment", "error", err)
 		return nil, errors.Wrap(err, "could not create payment")
 	}
 
-	s.logger.Info("Payment created successfully", "payment_id", payment.Id)
-	return payment, nil
+	res, err := s.provider.Authorize(ctx, payment.AuthorizeRequest{
+		PaymentID: p.Id,
+		Amount:    p.Amount,
+		Method:    p.PaymentMethod,
+	})
+	if err != nil {
+		s.logger.Error("Payment provider failed to authorize payment", "payment_id", p.Id, "error", err)
+		return s.transition(ctx, p, models.PaymentFailed, "", paymentSourceAPI, err.Error())
+	}
+
+	p, err = s.transition(ctx, p, res.Status, res.TransactionID, paymentSourceAPI, res.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == models.PaymentAuthorized {
+		return s.capture(ctx, p)
+	}
+
+	s.logger.Info("Payment created", "payment_id", p.Id, "status", p.Status)
+	return p, nil
+}
+
+// HandlePaymentEvent applies a webhook event of the payment provider. Events are
+// delivered at least once, so an event whose status the payment has already been
+// through is acknowledged without changes.
+func (s *PaymentService) HandlePaymentEvent(ctx context.Context, req *pb.PaymentEvent) (*pb.PaymentResponse, error) {
+	s.logger.Info("Handling payment event", "event_id", req.Id, "type", req.Type, "transaction_id", req.TransactionId)
+
+	to, ok := eventStatuses[req.Type]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedPaymentEvent, "%q", req.Type)
+	}
+
+	p, err := s.storage.Payment().GetByTransaction(ctx, req.TransactionId)
+	if err != nil {
+		s.logger.Error("Failed to find payment of event", "transaction_id", req.TransactionId, "error", err)
+		return nil, errors.Wrap(err, "could not handle payment event")
+	}
+	if req.PaymentId != "" && req.PaymentId != p.Id {
+		return nil, errors.Errorf("event of payment %s does not match payment %s", req.PaymentId, p.Id)
+	}
+
+	for _, change := range p.StatusHistory {
+		if change.ToStatus == to {
+			s.logger.Info("Payment event already applied", "event_id", req.Id, "payment_id", p.Id)
+			return p, nil
+		}
+	}
+
+	p, err = s.transition(ctx, p, to, "", req.Type, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == models.PaymentAuthorized {
+		return s.capture(ctx, p)
+	}
+
+	s.logger.Info("Payment event handled", "event_id", req.Id, "payment_id", p.Id, "status", p.Status)
+	return p, nil
+}
+
+// RefundPayment returns part or all of a captured payment to the customer. Every
+// attempt is kept in the payment's refund history, including the ones the payment
+// provider rejects.
+func (s *PaymentService) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundResponse, error) {
+	s.logger.Info("Refunding payment", "payment_id", req.PaymentId, "amount", req.Amount, "requested_by", req.RequestedBy)
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, ErrRefundReasonRequired
+	}
+	p, err := s.storage.Payment().GetPayment(ctx, &pb.IdRequest{Id: req.PaymentId})
+	if err != nil {
+		s.logger.Error("Failed to get payment to refund", "error", err)
+		return nil, errors.Wrap(err, "could not refund payment")
+	}
+
+	refund, err := s.refund(ctx, p, req.Amount, req.Reason, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payment refunded", "payment_id", p.Id, "refund_id", refund.Id, "amount", refund.Amount)
+	return refund, nil
+}
+
+// ListRefunds returns the refund history of a payment.
+func (s *PaymentService) ListRefunds(ctx context.Context, req *pb.ListRefundsRequest) (*pb.ListRefundsResponse, error) {
+	s.logger.Info("Listing refunds", "payment_id", req.PaymentId)
+
+	if _, err := s.storage.Payment().GetPayment(ctx, &pb.IdRequest{Id: req.PaymentId}); err != nil {
+		s.logger.Error("Failed to get payment of refunds", "error", err)
+		return nil, errors.Wrap(err, "could not list refunds")
+	}
+	refunds, err := s.storage.Refund().List(ctx, req.PaymentId)
+	if err != nil {
+		s.logger.Error("Failed to list refunds", "error", err)
+		return nil, errors.Wrap(err, "could not list refunds")
+	}
+
+	s.logger.Info("Refunds listed successfully", "count", len(refunds.Refunds))
+	return refunds, nil
 }
 
 func (s *PaymentService) GetPayment(ctx context.Context, req *pb.IdRequest) (*pb.PaymentResponse, error) {
@@ -59,3 +238,180 @@ func (s *PaymentService) ListPayments(ctx context.Context, req *pb.ListPaymentsR
 	s.logger.Info("Payments listed successfully", "count", len(payments.Payments))
 	return payments, nil
 }
+
+// capture collects an authorized payment. When the capture fails the authorization
+// is voided so the customer's money is not held.
+func (s *PaymentService) capture(ctx context.Context, p *pb.PaymentResponse) (*pb.PaymentResponse, error) {
+	if _, err := s.provider.Capture(ctx, p.TransactionId, p.Amount); err != nil {
+		s.logger.Error("Payment provider failed to capture payment", "payment_id", p.Id, "error", err)
+		reason := "capture failed: " + err.Error()
+		if _, err := s.provider.Void(ctx, p.TransactionId); err != nil {
+			s.logger.Error("Payment provider failed to void payment", "payment_id", p.Id, "error", err)
+			return s.transition(ctx, p, models.PaymentFailed, "", paymentSourceAPI, reason)
+		}
+		return s.transition(ctx, p, models.PaymentVoided, "", paymentSourceAPI, reason)
+	}
+	return s.transition(ctx, p, models.PaymentCaptured, "", paymentSourceAPI, "")
+}
+
+// refund returns amount of a captured payment, or everything not refunded yet when
+// amount is 0.
+func (s *PaymentService) refund(ctx context.Context, p *pb.PaymentResponse, amount float64, reason, requestedBy string) (*pb.RefundResponse, error) {
+	if p.Status != models.PaymentCaptured && p.Status != models.PaymentPartiallyRefunded {
+		return nil, errors.Wrapf(ErrPaymentNotRefundable, "payment is %s", p.Status)
+	}
+	refundable := cents(p.Amount - p.RefundedAmount)
+	if amount == 0 {
+		amount = refundable
+	}
+	amount = cents(amount)
+	if amount <= 0 || amount > refundable {
+		return nil, errors.Wrapf(ErrInvalidRefundAmount, "%.2f, refundable %.2f", amount, refundable)
+	}
+
+	refund := &models.Refund{
+		PaymentID:   p.Id,
+		BookingID:   p.BookingId,
+		Amount:      amount,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}
+
+	// Reserve the amount before money moves, so a concurrent refund of the same
+	// payment fails here instead of refunding twice.
+	to := models.PaymentPartiallyRefunded
+	if amount == refundable {
+		to = models.PaymentRefunded
+	}
+	updated, err := s.storage.Payment().AddRefund(ctx, p.Id, p.RefundedAmount, amount, &models.PaymentChange{
+		FromStatus: p.Status,
+		ToStatus:   to,
+		Source:     paymentSourceAPI,
+		Reason:     reason,
+		ChangedAt:  refund.CreatedAt,
+	})
+	if err != nil {
+		s.logger.Error("Failed to reserve refund", "payment_id", p.Id, "amount", amount, "error", err)
+		return nil, errors.Wrap(err, "could not refund payment")
+	}
+
+	if _, err := s.provider.Refund(ctx, p.TransactionId, amount); err != nil {
+		s.logger.Error("Payment provider failed to refund payment", "payment_id", p.Id, "error", err)
+		refund.Status = models.RefundFailed
+		refund.FailureReason = err.Error()
+		if _, err := s.storage.Refund().Create(ctx, refund); err != nil {
+			s.logger.Error("Failed to save failed refund", "payment_id", p.Id, "error", err)
+		}
+		released, err := s.storage.Payment().ReleaseRefund(ctx, p.Id, amount, paymentSourceAPI,
+			"refund rejected: "+refund.FailureReason, time.Now().Format(time.RFC3339))
+		if err != nil {
+			s.logger.Error("Failed to release rejected refund", "payment_id", p.Id, "amount", amount, "error", err)
+		} else {
+			s.syncBooking(ctx, released.BookingId, released.Status)
+		}
+		return nil, errors.Wrap(ErrRefundRejected, refund.FailureReason)
+	}
+	s.syncBooking(ctx, updated.BookingId, to)
+
+	refund.Status = models.RefundSucceeded
+	resp, err := s.storage.Refund().Create(ctx, refund)
+	if err != nil {
+		// The payment already accounts for the money; only the history entry is missing.
+		s.logger.Error("Failed to save refund", "payment_id", p.Id, "amount", amount, "error", err)
+		return nil, errors.Wrap(err, "could not save refund")
+	}
+	return resp, nil
+}
+
+// refundCanceledBooking gives the money of a canceled booking back, except for keep,
+// the cancellation fee. Authorized payments are captured when a fee is kept and
+// voided otherwise; payments still waiting for the customer are voided. Captured
+// money above keep is refunded. Calling it again with a lower keep refunds the
+// difference. It returns the payment status of the booking afterwards, empty when
+// nothing was paid.
+func (s *PaymentService) refundCanceledBooking(ctx context.Context, bookingID string, keep float64, reason, actor string) (string, error) {
+	payments, err := s.storage.Payment().ListByBooking(ctx, bookingID)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get booking payments")
+	}
+
+	status := ""
+	var failed error
+	for _, p := range payments {
+		if p.Status == models.PaymentAuthorized && keep > 0 {
+			captured, err := s.capture(ctx, p)
+			if err != nil {
+				failed = errors.Wrapf(err, "could not capture payment %s", p.Id)
+				continue
+			}
+			p = captured
+		}
+
+		switch p.Status {
+		case models.PaymentRequiresAction, models.PaymentAuthorized:
+			if _, err := s.provider.Void(ctx, p.TransactionId); err != nil {
+				failed = errors.Wrapf(err, "could not void payment %s", p.Id)
+				continue
+			}
+			if _, err := s.transition(ctx, p, models.PaymentVoided, "", paymentSourceAPI, reason); err != nil {
+				failed = err
+				continue
+			}
+			status = models.PaymentVoided
+		case models.PaymentCaptured, models.PaymentPartiallyRefunded:
+			refundable := cents(p.Amount - p.RefundedAmount)
+			amount := cents(refundable - keep)
+			keep = cents(max(keep-refundable, 0))
+			status = p.Status
+			if amount <= 0 {
+				continue
+			}
+			if _, err := s.refund(ctx, p, amount, reason, actor); err != nil {
+				failed = errors.Wrapf(err, "could not refund payment %s", p.Id)
+				continue
+			}
+			status = models.PaymentPartiallyRefunded
+			if amount == refundable {
+				status = models.PaymentRefunded
+			}
+		}
+	}
+	return status, failed
+}
+
+// transition moves the payment to status "to" and mirrors the status on its booking.
+func (s *PaymentService) transition(ctx context.Context, p *pb.PaymentResponse, to, transactionID, source, reason string) (*pb.PaymentResponse, error) {
+	if err := validatePaymentTransition(p.Status, to); err != nil {
+		s.logger.Warn("rejected payment status transition", "payment_id", p.Id, "error", err)
+		return nil, err
+	}
+
+	updated, err := s.storage.Payment().UpdateStatus(ctx, p.Id, p.Status, transactionID, &models.PaymentChange{
+		FromStatus: p.Status,
+		ToStatus:   to,
+		Source:     source,
+		Reason:     reason,
+		ChangedAt:  time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		s.logger.Error("Failed to update payment status", "payment_id", p.Id, "error", err)
+		return nil, errors.Wrap(err, "could not update payment")
+	}
+
+	s.syncBooking(ctx, updated.BookingId, to)
+	return updated, nil
+}
+
+// syncBooking mirrors a payment status on its booking. The payment is the source of
+// truth, so a stale booking status is only logged.
+func (s *PaymentService) syncBooking(ctx context.Context, bookingID, status string) {
+	if err := s.storage.Booking().SetPaymentStatus(ctx, bookingID, status); err != nil {
+		s.logger.Error("Failed to update booking payment status", "booking_id", bookingID, "error", err)
+	}
+}
+
+// cents rounds an amount of money to cents.
+func cents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/payment"
+	"Booking_Service/storage"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidatePaymentTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{models.PaymentPending, models.PaymentAuthorized, true},
+		{models.PaymentPending, models.PaymentRequiresAction, true},
+		{models.PaymentPending, models.PaymentDeclined, true},
+		{models.PaymentRequiresAction, models.PaymentAuthorized, true},
+		{models.PaymentRequiresAction, models.PaymentDeclined, true},
+		{models.PaymentAuthorized, models.PaymentCaptured, true},
+		{models.PaymentAuthorized, models.PaymentVoided, true},
+		{models.PaymentCaptured, models.PaymentPartiallyRefunded, true},
+		{models.PaymentCaptured, models.PaymentRefunded, true},
+		{models.PaymentPartiallyRefunded, models.PaymentPartiallyRefunded, true},
+		{models.PaymentPartiallyRefunded, models.PaymentRefunded, true},
+		{models.PaymentPending, models.PaymentCaptured, false},
+		{models.PaymentRequiresAction, models.PaymentCaptured, false},
+		{models.PaymentDeclined, models.PaymentAuthorized, false},
+		{models.PaymentCaptured, models.PaymentVoided, false},
+		{models.PaymentVoided, models.PaymentCaptured, false},
+		{models.PaymentAuthorized, models.PaymentRefunded, false},
+		{models.PaymentRefunded, models.PaymentPartiallyRefunded, false},
+	}
+
+	for _, tt := range tests {
+		err := validatePaymentTransition(tt.from, tt.to)
+		if tt.ok {
+			require.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			require.True(t, errors.Is(err, ErrInvalidPaymentTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
+
+// paymentStorage serves a single booking and records the payments created for it.
+// Other storages are left nil.
+type paymentStorage struct {
+	storage.IStorage
+	booking  *pb.BookingResponse
+	payments *payments
+}
+
+func (s *paymentStorage) Booking() storage.IBookingStorage { return &bookingReader{booking: s.booking} }
+func (s *paymentStorage) Payment() storage.IPaymentStorage { return s.payments }
+
+type bookingReader struct {
+	storage.IBookingStorage
+	booking *pb.BookingResponse
+}
+
+func (b *bookingReader) Read(ctx context.Context, req *pb.IdRequest) (*pb.BookingResponse, error) {
+	return b.booking, nil
+}
+
+// payments fails every payment with err, as storage does when the booking already
+// has an active one, so the tests never reach the payment provider.
+type payments struct {
+	storage.IPaymentStorage
+	err     error
+	created []*pb.CreatePaymentRequest
+}
+
+func (p *payments) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.PaymentResponse, error) {
+	p.created = append(p.created, req)
+	return nil, p.err
+}
+
+func TestCreatePaymentChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		booking *pb.BookingResponse
+		userID  string
+		err     error
+		created bool
+	}{
+		{
+			name:    "Owner",
+			booking: &pb.BookingResponse{Id: "booking123", UserId: "user123", Status: models.StatusPending, TotalPrice: 55},
+			userID:  "user123",
+			err:     ErrBookingAlreadyPaid,
+			created: true,
+		},
+		{
+			name:    "OrganizationMember",
+			booking: &pb.BookingResponse{Id: "booking123", UserId: "user123", OrganizationId: "org123", Status: models.StatusConfirmed, TotalPrice: 55},
+			userID:  "user456",
+			err:     ErrBookingAlreadyPaid,
+			created: true,
+		},
+		{
+			name:    "OtherUser",
+			booking: &pb.BookingResponse{Id: "booking123", UserId: "user123", Status: models.StatusPending, TotalPrice: 55},
+			userID:  "user456",
+			err:     ErrNotBookingOwner,
+		},
+		{
+			name:    "Completed",
+			booking: &pb.BookingResponse{Id: "booking123", UserId: "user123", Status: models.StatusCompleted, TotalPrice: 55},
+			userID:  "user123",
+			err:     ErrBookingNotPayable,
+		},
+		{
+			name:    "Canceled",
+			booking: &pb.BookingResponse{Id: "booking123", UserId: "user123", Status: models.StatusCanceled, TotalPrice: 55},
+			userID:  "user123",
+			err:     ErrBookingNotPayable,
+		},
+		{
+			name: "Paid",
+			booking: &pb.BookingResponse{
+				Id: "booking123", UserId: "user123", Status: models.StatusConfirmed, TotalPrice: 55,
+				PaymentStatus: models.PaymentCaptured,
+			},
+			userID: "user123",
+			err:    ErrBookingAlreadyPaid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &paymentStorage{booking: tt.booking, payments: &payments{err: storage.ErrActivePayment}}
+			svc := NewPaymentService(s, nil, testLogger)
+
+			_, err := svc.CreatePayment(context.Background(), &pb.CreatePaymentRequest{
+				BookingId:     "booking123",
+				UserId:        tt.userID,
+				Amount:        1,
+				PaymentMethod: "pm_card_visa",
+			})
+			require.True(t, errors.Is(err, tt.err), "got %v", err)
+
+			if !tt.created {
+				require.Empty(t, s.payments.created)
+				return
+			}
+			require.Len(t, s.payments.created, 1)
+			require.Equal(t, 55.0, s.payments.created[0].Amount, "the booking's price is charged")
+			require.Equal(t, tt.booking.OrganizationId, s.payments.created[0].OrganizationId)
+		})
+	}
+}
+
+// ledger keeps a single payment in memory and applies refund reservations to it the
+// way storage does: only while the payment is unchanged since it was read.
+type ledger struct {
+	storage.IStorage
+	payment       *pb.PaymentResponse
+	refunds       []*models.Refund
+	bookingStatus string
+	changed       bool // another refund reserves money between the read and AddRefund
+}
+
+func (l *ledger) Booking() storage.IBookingStorage { return ledgerBookings{ledger: l} }
+func (l *ledger) Payment() storage.IPaymentStorage { return ledgerPayments{ledger: l} }
+func (l *ledger) Refund() storage.IRefundStorage   { return ledgerRefunds{ledger: l} }
+
+// current returns a copy of the payment, as a read from storage would.
+func (l *ledger) current() *pb.PaymentResponse {
+	return proto.Clone(l.payment).(*pb.PaymentResponse)
+}
+
+type ledgerBookings struct {
+	storage.IBookingStorage
+	*ledger
+}
+
+func (b ledgerBookings) SetPaymentStatus(ctx context.Context, id string, status string) error {
+	b.bookingStatus = status
+	return nil
+}
+
+type ledgerPayments struct {
+	storage.IPaymentStorage
+	*ledger
+}
+
+func (p ledgerPayments) GetPayment(ctx context.Context, id *pb.IdRequest) (*pb.PaymentResponse, error) {
+	return p.current(), nil
+}
+
+func (p ledgerPayments) AddRefund(ctx context.Context, id string, refunded float64, amount float64, change *models.PaymentChange) (*pb.PaymentResponse, error) {
+	if p.changed || p.payment.Status != change.FromStatus || p.payment.RefundedAmount != refunded {
+		return nil, errors.New("payment not found or it has changed concurrently")
+	}
+	p.payment.RefundedAmount += amount
+	p.payment.Status = change.ToStatus
+	return p.current(), nil
+}
+
+func (p ledgerPayments) ReleaseRefund(ctx context.Context, id string, amount float64, source, reason, at string) (*pb.PaymentResponse, error) {
+	p.payment.RefundedAmount = cents(p.payment.RefundedAmount - amount)
+	p.payment.Status = models.PaymentPartiallyRefunded
+	if p.payment.RefundedAmount <= 0 {
+		p.payment.Status = models.PaymentCaptured
+	}
+	return p.current(), nil
+}
+
+type ledgerRefunds struct {
+	storage.IRefundStorage
+	*ledger
+}
+
+func (r ledgerRefunds) Create(ctx context.Context, refund *models.Refund) (*pb.RefundResponse, error) {
+	r.refunds = append(r.refunds, refund)
+	return &pb.RefundResponse{PaymentId: refund.PaymentID, Amount: refund.Amount, Status: refund.Status}, nil
+}
+
+// refundProvider refunds everything, or rejects every refund with err.
+type refundProvider struct {
+	payment.PaymentProvider
+	err      error
+	refunded []float64
+}
+
+func (p *refundProvider) Refund(ctx context.Context, transactionID string, amount float64) (*payment.Result, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.refunded = append(p.refunded, amount)
+	return &payment.Result{TransactionID: transactionID, Status: models.PaymentCaptured}, nil
+}
+
+func TestRefundPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		refunded    float64
+		amount      float64
+		reason      string
+		changed     bool
+		providerErr error
+		err         error
+		// state of the payment afterwards
+		wantStatus   string
+		wantRefunded float64
+		wantProvider []float64
+	}{
+		{
+			name: "Full", status: models.PaymentCaptured, reason: "canceled by support",
+			wantStatus: models.PaymentRefunded, wantRefunded: 55, wantProvider: []float64{55},
+		},
+		{
+			name: "Partial", status: models.PaymentCaptured, amount: 20.004, reason: "late arrival",
+			wantStatus: models.PaymentPartiallyRefunded, wantRefunded: 20, wantProvider: []float64{20},
+		},
+		{
+			name: "Remainder", status: models.PaymentPartiallyRefunded, refunded: 20, reason: "late arrival",
+			wantStatus: models.PaymentRefunded, wantRefunded: 55, wantProvider: []float64{35},
+		},
+		{
+			name: "OverRefund", status: models.PaymentPartiallyRefunded, refunded: 20, amount: 35.01, reason: "late arrival",
+			err: ErrInvalidRefundAmount, wantStatus: models.PaymentPartiallyRefunded, wantRefunded: 20,
+		},
+		{
+			name: "Negative", status: models.PaymentCaptured, amount: -5, reason: "late arrival",
+			err: ErrInvalidRefundAmount, wantStatus: models.PaymentCaptured,
+		},
+		{
+			name: "FullyRefunded", status: models.PaymentRefunded, refunded: 55, reason: "late arrival",
+			err: ErrPaymentNotRefundable, wantStatus: models.PaymentRefunded, wantRefunded: 55,
+		},
+		{
+			name: "NotCaptured", status: models.PaymentAuthorized, reason: "late arrival",
+			err: ErrPaymentNotRefundable, wantStatus: models.PaymentAuthorized,
+		},
+		{
+			name: "NoReason", status: models.PaymentCaptured, reason: " ",
+			err: ErrRefundReasonRequired, wantStatus: models.PaymentCaptured,
+		},
+		{
+			// The concurrent refund wins the reservation; this one never reaches the provider.
+			name: "ConcurrentRefund", status: models.PaymentCaptured, reason: "late arrival", changed: true,
+			wantStatus: models.PaymentCaptured,
+		},
+		{
+			name: "ProviderRejects", status: models.PaymentCaptured, amount: 20, reason: "late arrival",
+			providerErr: errors.New("card expired"), err: ErrRefundRejected,
+			wantStatus: models.PaymentCaptured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &ledger{
+				payment: &pb.PaymentResponse{
+					Id: "payment123", BookingId: "booking123", TransactionId: "txn123",
+					Amount: 55, Status: tt.status, RefundedAmount: tt.refunded,
+				},
+				changed: tt.changed,
+			}
+			provider := &refundProvider{err: tt.providerErr}
+			svc := NewPaymentService(l, provider, testLogger)
+
+			refund, err := svc.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+				PaymentId:   "payment123",
+				Amount:      tt.amount,
+				Reason:      tt.reason,
+				RequestedBy: "admin123",
+			})
+			switch {
+			case tt.err != nil:
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+			case tt.changed:
+				require.ErrorContains(t, err, "changed concurrently")
+			default:
+				require.NoError(t, err)
+				require.Equal(t, models.RefundSucceeded, refund.Status)
+				require.Equal(t, tt.wantStatus, l.bookingStatus)
+			}
+
+			require.Equal(t, tt.wantStatus, l.payment.Status)
+			require.Equal(t, tt.wantRefunded, l.payment.RefundedAmount)
+			require.Equal(t, tt.wantProvider, provider.refunded)
+		})
+	}
+}
+
+func TestRefundPaymentRecordsRejection(t *testing.T) {
+	l := &ledger{payment: &pb.PaymentResponse{
+		Id: "payment123", BookingId: "booking123", TransactionId: "txn123",
+		Amount: 55, Status: models.PaymentPartiallyRefunded, RefundedAmount: 20,
+	}}
+	svc := NewPaymentService(l, &refundProvider{err: errors.New("card expired")}, testLogger)
+
+	_, err := svc.RefundPayment(context.Background(), &pb.RefundPaymentRequest{PaymentId: "payment123", Amount: 10, Reason: "late arrival"})
+	require.True(t, errors.Is(err, ErrRefundRejected), "got %v", err)
+
+	// The reservation is given back and the attempt stays in the refund history.
+	require.Equal(t, models.PaymentPartiallyRefunded, l.payment.Status)
+	require.Equal(t, 20.0, l.payment.RefundedAmount)
+	require.Equal(t, models.PaymentPartiallyRefunded, l.bookingStatus)
+	require.Len(t, l.refunds, 1)
+	require.Equal(t, models.RefundFailed, l.refunds[0].Status)
+	require.Equal(t, 10.0, l.refunds[0].Amount)
+	require.Equal(t, "card expired", l.refunds[0].FailureReason)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/pricing"
+	"Booking_Service/storage"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type PricingService struct {
+	pb.UnimplementedPricingServiceServer
+	storage storage.IStorage
+	logger  *slog.Logger
+}
+
+func NewPricingService(s storage.IStorage, logger *slog.Logger) *PricingService {
+	return &PricingService{
+		storage: s,
+		logger:  logger,
+	}
+}
+
+// GetPricingRules returns the rules stored for the provider, or the platform defaults
+// when no provider is given. A provider without rules of its own gets empty rules.
+func (s *PricingService) GetPricingRules(ctx context.Context, req *pb.PricingRulesRequest) (*pb.PricingRules, error) {
+	s.logger.Info("GetPricingRules called", "provider_id", req.ProviderId)
+
+	rules, err := s.storage.Pricing().Get(ctx, req.ProviderId)
+	if errors.Is(err, storage.ErrPricingRulesNotFound) {
+		rules = &models.PricingRules{ProviderID: req.ProviderId}
+		if req.ProviderId == "" {
+			rules = pricing.Defaults()
+		}
+	} else if err != nil {
+		s.logger.Error("failed to get pricing rules", "error", err)
+		return nil, err
+	}
+
+	return pricingRulesToResponse(rules), nil
+}
+
+// SetPricingRules replaces the rules of the provider, or the platform defaults when
+// no provider is given. Booking fee and tax rate only apply to the defaults.
+func (s *PricingService) SetPricingRules(ctx context.Context, req *pb.PricingRules) (*pb.PricingRules, error) {
+	s.logger.Info("SetPricingRules called", "provider_id", req.ProviderId, "updated_by", req.UpdatedBy)
+
+	rules := pricingRulesFromRequest(req)
+	if rules.ProviderID != "" {
+		if _, err := s.storage.Provider().GetByID(ctx, rules.ProviderID); err != nil {
+			s.logger.Error("failed to get provider", "error", err)
+			return nil, err
+		}
+		rules.BookingFee = 0
+		rules.TaxRate = 0
+	}
+	if err := pricing.Validate(rules); err != nil {
+		s.logger.Warn("rejected pricing rules", "provider_id", req.ProviderId, "error", err)
+		return nil, err
+	}
+	rules.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	if err := s.storage.Pricing().Set(ctx, rules); err != nil {
+		s.logger.Error("failed to save pricing rules", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Pricing rules saved", "provider_id", rules.ProviderID)
+	return pricingRulesToResponse(rules), nil
+}
+
+// DeletePricingRules drops the provider's own rules so the platform defaults apply
+// again. Without a provider it resets the defaults.
+func (s *PricingService) DeletePricingRules(ctx context.Context, req *pb.PricingRulesRequest) (*pb.Void, error) {
+	s.logger.Info("DeletePricingRules called", "provider_id", req.ProviderId)
+
+	if err := s.storage.Pricing().Delete(ctx, req.ProviderId); err != nil {
+		s.logger.Error("failed to delete pricing rules", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Pricing rules deleted", "provider_id", req.ProviderId)
+	return &pb.Void{}, nil
+}
+
+func pricingRulesFromRequest(req *pb.PricingRules) *models.PricingRules {
+	rules := &models.PricingRules{
+		ProviderID:         req.ProviderId,
+		VehicleMultipliers: req.VehicleMultipliers,
+		WeekendMultiplier:  req.WeekendMultiplier,
+		BookingFee:         req.BookingFee,
+		TaxRate:            req.TaxRate,
+		UpdatedBy:          req.UpdatedBy,
+	}
+	if req.TravelFee != nil {
+		rules.TravelFee = &models.TravelFee{
+			FreeKm: req.TravelFee.FreeKm,
+			PerKm:  req.TravelFee.PerKm,
+			MaxKm:  req.TravelFee.MaxKm,
+		}
+	}
+	for _, rule := range req.TimeRules {
+		rules.TimeRules = append(rules.TimeRules, models.TimeRule{
+			Name:       rule.Name,
+			Weekdays:   rule.Weekdays,
+			Start:      rule.Start,
+			End:        rule.End,
+			Multiplier: rule.Multiplier,
+		})
+	}
+	if req.Cancellation != nil {
+		rules.Cancellation = &models.CancellationPolicy{EnRouteFeePercent: req.Cancellation.EnRouteFeePercent}
+		for _, tier := range req.Cancellation.Tiers {
+			rules.Cancellation.Tiers = append(rules.Cancellation.Tiers, models.CancellationTier{
+				HoursBefore: tier.HoursBefore,
+				FeePercent:  tier.FeePercent,
+			})
+		}
+	}
+	return rules
+}
+
+func pricingRulesToResponse(rules *models.PricingRules) *pb.PricingRules {
+	resp := &pb.PricingRules{
+		ProviderId:         rules.ProviderID,
+		VehicleMultipliers: rules.VehicleMultipliers,
+		WeekendMultiplier:  rules.WeekendMultiplier,
+		BookingFee:         rules.BookingFee,
+		TaxRate:            rules.TaxRate,
+		UpdatedBy:          rules.UpdatedBy,
+		UpdatedAt:          rules.UpdatedAt,
+	}
+	if rules.TravelFee != nil {
+		resp.TravelFee = &pb.TravelFee{
+			FreeKm: rules.TravelFee.FreeKm,
+			PerKm:  rules.TravelFee.PerKm,
+			MaxKm:  rules.TravelFee.MaxKm,
+		}
+	}
+	for _, rule := range rules.TimeRules {
+		resp.TimeRules = append(resp.TimeRules, &pb.TimeRule{
+			Name:       rule.Name,
+			Weekdays:   rule.Weekdays,
+			Start:      rule.Start,
+			End:        rule.End,
+			Multiplier: rule.Multiplier,
+		})
+	}
+	if rules.Cancellation != nil {
+		resp.Cancellation = &pb.CancellationPolicy{EnRouteFeePercent: rules.Cancellation.EnRouteFeePercent}
+		for _, tier := range rules.Cancellation.Tiers {
+			resp.Cancellation.Tiers = append(resp.Cancellation.Tiers, &pb.CancellationTier{
+				HoursBefore: tier.HoursBefore,
+				FeePercent:  tier.FeePercent,
+			})
+		}
+	}
+	return resp
+}
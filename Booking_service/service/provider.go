@@ -2,30 +2,43 @@ package service
 
 import (
 	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/pkg/blob"
+	"Booking_Service/pkg/slots"
 	"Booking_Service/storage"
 	"context"
-	"errors"
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"log/slog"
 )
 
+// ErrInvalidTimezone is returned when a provider's timezone is not a known IANA zone.
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
 type ProviderService struct {
 	pb.UnimplementedProviderServiceServer
-	storage storage.IProviderStorage
-	logger  *slog.Logger
+	storage         storage.IProviderStorage
+	blobs           blob.Store
+	maxDocumentSize int64
+	logger          *slog.Logger
 }
 
-// NewProviderService creates a new ProviderService.
-func NewProviderService(s storage.IStorage, logger *slog.Logger) *ProviderService {
+// NewProviderService creates a new ProviderService. Application documents are kept in blobs.
+func NewProviderService(s storage.IStorage, blobs blob.Store, maxDocumentSize int64, logger *slog.Logger) *ProviderService {
 	return &ProviderService{
-		storage: s.Provider(),
-		logger:  logger,
+		storage:         s.Provider(),
+		blobs:           blobs,
+		maxDocumentSize: maxDocumentSize,
+		logger:          logger,
 	}
 }
 
 // RegisterProvider handles the registration of a new provider.
 func (s *ProviderService) RegisterProvider(ctx context.Context, req *pb.RegisterProviderRequest) (*pb.ProviderResponse, error) {
 	s.logger.Info("Registering provider", "user_id", req.UserId)
+	if err := validateTimezone(req.Timezone); err != nil {
+		return nil, err
+	}
 	res, err := s.storage.RegisterProvider(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to register provider", "error", err)
@@ -42,7 +55,7 @@ func (s *ProviderService) GetProvider(ctx context.Context, req *pb.IdRequest) (*
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			s.logger.Warn("Provider not found", "id", req.Id)
-			return nil, errors.New("provider not found")
+			return nil, storage.ErrProviderNotFound
 		}
 		s.logger.Error("Failed to fetch provider", "error", err)
 		return nil, err
@@ -54,6 +67,9 @@ func (s *ProviderService) GetProvider(ctx context.Context, req *pb.IdRequest) (*
 // UpdateProvider updates the details of an existing provider.
 func (s *ProviderService) UpdateProvider(ctx context.Context, req *pb.UpdateProviderRequest) (*pb.ProviderResponse, error) {
 	s.logger.Info("Updating provider", "id", req.Id)
+	if err := validateTimezone(req.Timezone); err != nil {
+		return nil, err
+	}
 	res, err := s.storage.UpdateProvider(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update provider", "error", err)
@@ -63,6 +79,14 @@ func (s *ProviderService) UpdateProvider(ctx context.Context, req *pb.UpdateProv
 	return res, nil
 }
 
+// validateTimezone checks that the provider's working hours can be placed in time.
+func validateTimezone(name string) error {
+	if _, err := slots.Location(&models.Provider{Timezone: name}); err != nil {
+		return errors.Wrap(ErrInvalidTimezone, name)
+	}
+	return nil
+}
+
 // DeleteProvider deletes a provider by its ID.
 func (s *ProviderService) DeleteProvider(ctx context.Context, req *pb.IdRequest) (*pb.Void, error) {
 	s.logger.Info("Deleting provider", "id", req.Id)
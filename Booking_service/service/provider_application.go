@@ -0,0 +1,259 @@
+package service
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// providerTransitions lists, for every provider application status, the statuses an
+// admin may move it to. A rejected provider goes back to submitted by uploading new documents.
+var providerTransitions = map[string][]string{
+	models.ProviderSubmitted:   {models.ProviderUnderReview, models.ProviderApproved, models.ProviderRejected},
+	models.ProviderUnderReview: {models.ProviderApproved, models.ProviderRejected},
+	models.ProviderApproved:    {models.ProviderSuspended},
+	models.ProviderSuspended:   {models.ProviderApproved},
+	models.ProviderRejected:    {models.ProviderSubmitted, models.ProviderUnderReview},
+}
+
+// reviewQueue holds the statuses of applications that wait for an admin.
+var reviewQueue = []string{models.ProviderSubmitted, models.ProviderUnderReview}
+
+// documentContentTypes are the file types accepted as provider documents.
+var documentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+var (
+	ErrInvalidProviderTransition = errors.New("invalid provider status transition")
+	ErrMissingDocuments          = errors.New("required documents are missing")
+	ErrReasonRequired            = errors.New("reason is required")
+	ErrNotProviderOwner          = errors.New("provider is not owned by this user")
+	ErrDocumentNotFound          = errors.New("document not found")
+	ErrInvalidDocument           = errors.New("invalid document")
+)
+
+func validateProviderTransition(from, to string) error {
+	if slices.Contains(providerTransitions[from], to) {
+		return nil
+	}
+	return errors.Wrapf(ErrInvalidProviderTransition, "%s -> %s", from, to)
+}
+
+// missingDocuments returns the required document types the provider has not uploaded yet.
+func missingDocuments(provider *models.Provider) []string {
+	var missing []string
+	for _, required := range models.RequiredDocuments {
+		found := false
+		for _, doc := range provider.Documents {
+			if doc.Type == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+// UploadProviderDocument stores a business license or insurance document for the
+// provider's application. Uploading to a rejected application submits it again.
+func (s *ProviderService) UploadProviderDocument(ctx context.Context, req *pb.UploadProviderDocumentRequest) (*pb.ProviderDocument, error) {
+	s.logger.Info("UploadProviderDocument called", "provider_id", req.ProviderId, "type", req.Type)
+
+	if !slices.Contains(models.RequiredDocuments, req.Type) {
+		return nil, errors.Wrapf(ErrInvalidDocument, "unknown document type %q", req.Type)
+	}
+	if len(req.Content) == 0 {
+		return nil, errors.Wrap(ErrInvalidDocument, "empty file")
+	}
+	if int64(len(req.Content)) > s.maxDocumentSize {
+		return nil, errors.Wrapf(ErrInvalidDocument, "file is larger than %d bytes", s.maxDocumentSize)
+	}
+	contentType := http.DetectContentType(req.Content)
+	if !slices.Contains(documentContentTypes, contentType) {
+		return nil, errors.Wrapf(ErrInvalidDocument, "unsupported file type %s", contentType)
+	}
+
+	provider, err := s.storage.GetByID(ctx, req.ProviderId)
+	if err != nil {
+		s.logger.Error("failed to get provider", "error", err)
+		return nil, err
+	}
+	if provider.UserID != req.UserId {
+		return nil, ErrNotProviderOwner
+	}
+
+	doc := &models.ProviderDocument{
+		ID:          primitive.NewObjectID().Hex(),
+		Type:        req.Type,
+		FileName:    req.FileName,
+		ContentType: contentType,
+		UploadedBy:  req.UserId,
+		UploadedAt:  time.Now().Format(time.RFC3339),
+	}
+	doc.Key = "providers/" + req.ProviderId + "/" + doc.ID
+
+	doc.Size, err = s.blobs.Put(ctx, doc.Key, bytes.NewReader(req.Content))
+	if err != nil {
+		s.logger.Error("failed to store document", "error", err)
+		return nil, err
+	}
+	if err := s.storage.AddDocument(ctx, req.ProviderId, doc); err != nil {
+		s.logger.Error("failed to add document", "error", err)
+		if delErr := s.blobs.Delete(ctx, doc.Key); delErr != nil {
+			s.logger.Error("failed to delete orphaned document", "key", doc.Key, "error", delErr)
+		}
+		return nil, err
+	}
+
+	if provider.Status == models.ProviderRejected {
+		_, err := s.storage.UpdateStatus(ctx, req.ProviderId, models.ProviderRejected, &models.StatusChange{
+			FromStatus: models.ProviderRejected,
+			ToStatus:   models.ProviderSubmitted,
+			ChangedBy:  req.UserId,
+			Reason:     "new " + req.Type + " document uploaded",
+			ChangedAt:  doc.UploadedAt,
+		})
+		if err != nil {
+			s.logger.Warn("failed to resubmit provider application", "provider_id", req.ProviderId, "error", err)
+		}
+	}
+
+	s.logger.Info("Provider document uploaded successfully", "provider_id", req.ProviderId, "document_id", doc.ID)
+	return &pb.ProviderDocument{
+		Id:          doc.ID,
+		Type:        doc.Type,
+		FileName:    doc.FileName,
+		ContentType: doc.ContentType,
+		Size:        doc.Size,
+		UploadedAt:  doc.UploadedAt,
+	}, nil
+}
+
+// GetProviderDocument returns a document together with its file. Admins leave user_id
+// empty; everyone else may only read the documents of their own provider.
+func (s *ProviderService) GetProviderDocument(ctx context.Context, req *pb.ProviderDocumentRequest) (*pb.ProviderDocumentContent, error) {
+	s.logger.Info("GetProviderDocument called", "provider_id", req.ProviderId, "document_id", req.DocumentId)
+
+	provider, err := s.storage.GetByID(ctx, req.ProviderId)
+	if err != nil {
+		s.logger.Error("failed to get provider", "error", err)
+		return nil, err
+	}
+	if req.UserId != "" && provider.UserID != req.UserId {
+		return nil, ErrNotProviderOwner
+	}
+
+	idx := slices.IndexFunc(provider.Documents, func(doc models.ProviderDocument) bool {
+		return doc.ID == req.DocumentId
+	})
+	if idx < 0 {
+		return nil, ErrDocumentNotFound
+	}
+	doc := provider.Documents[idx]
+
+	f, err := s.blobs.Open(ctx, doc.Key)
+	if err != nil {
+		s.logger.Error("failed to open document", "key", doc.Key, "error", err)
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		s.logger.Error("failed to read document", "key", doc.Key, "error", err)
+		return nil, errors.Wrap(err, "failed to read document")
+	}
+
+	return &pb.ProviderDocumentContent{
+		Document: &pb.ProviderDocument{
+			Id:          doc.ID,
+			Type:        doc.Type,
+			FileName:    doc.FileName,
+			ContentType: doc.ContentType,
+			Size:        doc.Size,
+			UploadedAt:  doc.UploadedAt,
+		},
+		Content: content,
+	}, nil
+}
+
+// ListProviderApplications lists provider applications for admins, by default the
+// ones waiting for review.
+func (s *ProviderService) ListProviderApplications(ctx context.Context, req *pb.ListProviderApplicationsRequest) (*pb.ListProvidersResponse, error) {
+	s.logger.Info("ListProviderApplications called", "status", req.Status, "page", req.Page, "limit", req.Limit)
+
+	statuses := reviewQueue
+	if req.Status != "" {
+		if _, ok := providerTransitions[req.Status]; !ok {
+			return nil, errors.Errorf("unknown provider status %q", req.Status)
+		}
+		statuses = []string{req.Status}
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 {
+		req.Limit = 10
+	}
+
+	resp, err := s.storage.ListByStatus(ctx, statuses, req.Page, req.Limit)
+	if err != nil {
+		s.logger.Error("failed to list provider applications", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Provider applications listed successfully", "count", len(resp.Providers))
+	return resp, nil
+}
+
+// ReviewProvider moves a provider application to a new status. Approval requires all
+// documents, rejection and suspension require a reason.
+func (s *ProviderService) ReviewProvider(ctx context.Context, req *pb.ReviewProviderRequest) (*pb.ProviderResponse, error) {
+	s.logger.Info("ReviewProvider called", "provider_id", req.ProviderId, "status", req.Status, "reviewer_id", req.ReviewerId)
+
+	provider, err := s.storage.GetByID(ctx, req.ProviderId)
+	if err != nil {
+		s.logger.Error("failed to get provider", "error", err)
+		return nil, err
+	}
+
+	if err := validateProviderTransition(provider.Status, req.Status); err != nil {
+		s.logger.Warn("rejected provider status transition", "provider_id", req.ProviderId, "error", err)
+		return nil, err
+	}
+	switch req.Status {
+	case models.ProviderApproved:
+		if missing := missingDocuments(provider); len(missing) > 0 {
+			return nil, errors.Wrapf(ErrMissingDocuments, "%v", missing)
+		}
+	case models.ProviderRejected, models.ProviderSuspended:
+		if req.Reason == "" {
+			return nil, ErrReasonRequired
+		}
+	}
+
+	resp, err := s.storage.UpdateStatus(ctx, req.ProviderId, provider.Status, &models.StatusChange{
+		FromStatus: provider.Status,
+		ToStatus:   req.Status,
+		ChangedBy:  req.ReviewerId,
+		Reason:     req.Reason,
+		ChangedAt:  time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		s.logger.Error("failed to update provider status", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Provider application reviewed", "provider_id", req.ProviderId, "status", req.Status)
+	return resp, nil
+}
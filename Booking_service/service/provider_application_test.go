@@ -0,0 +1,47 @@
+package service
+
+import (
+	"Booking_Service/models"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProviderTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{models.ProviderSubmitted, models.ProviderUnderReview, true},
+		{models.ProviderSubmitted, models.ProviderApproved, true},
+		{models.ProviderUnderReview, models.ProviderRejected, true},
+		{models.ProviderApproved, models.ProviderSuspended, true},
+		{models.ProviderSuspended, models.ProviderApproved, true},
+		{models.ProviderRejected, models.ProviderSubmitted, true},
+		{models.ProviderRejected, models.ProviderApproved, false},
+		{models.ProviderApproved, models.ProviderSubmitted, false},
+		{models.ProviderSubmitted, models.ProviderSuspended, false},
+		{models.ProviderSuspended, models.ProviderRejected, false},
+	}
+
+	for _, tt := range tests {
+		err := validateProviderTransition(tt.from, tt.to)
+		if tt.ok {
+			require.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			require.True(t, errors.Is(err, ErrInvalidProviderTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
+
+func TestMissingDocuments(t *testing.T) {
+	provider := &models.Provider{}
+	require.Equal(t, models.RequiredDocuments, missingDocuments(provider))
+
+	provider.Documents = append(provider.Documents, models.ProviderDocument{Type: models.DocumentInsurance})
+	require.Equal(t, []string{models.DocumentBusinessLicense}, missingDocuments(provider))
+
+	provider.Documents = append(provider.Documents, models.ProviderDocument{Type: models.DocumentBusinessLicense})
+	require.Empty(t, missingDocuments(provider))
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"Booking_Service/models"
+
+	"github.com/pkg/errors"
+)
+
+// bookingTransitions lists, for every booking status, the statuses it may move to.
+// Statuses without an entry are terminal.
+var bookingTransitions = map[string][]string{
+	models.StatusPending:    {models.StatusConfirmed, models.StatusRejected, models.StatusCanceled},
+	models.StatusConfirmed:  {models.StatusEnRoute, models.StatusCanceled, models.StatusNoShow},
+	models.StatusEnRoute:    {models.StatusInProgress, models.StatusCanceled, models.StatusNoShow},
+	models.StatusInProgress: {models.StatusCompleted},
+}
+
+// ErrInvalidTransition is returned when a booking is asked to jump to a status
+// that is not reachable from its current one.
+var ErrInvalidTransition = errors.New("invalid booking status transition")
+
+// ErrNotBookingProvider is returned when a provider acts on a booking addressed to someone else.
+var ErrNotBookingProvider = errors.New("booking is not assigned to this provider")
+
+// ErrBookingNotCanceled is returned when the cancellation fee of a booking that was not canceled is changed.
+var ErrBookingNotCanceled = errors.New("booking is not canceled")
+
+// ErrInvalidFeeOverride is returned when an override would raise the cancellation fee
+// or make it negative; money already refunded cannot be charged again.
+var ErrInvalidFeeOverride = errors.New("invalid cancellation fee override")
+
+// ErrOverrideReasonRequired is returned when a cancellation fee is overridden without a reason.
+var ErrOverrideReasonRequired = errors.New("reason is required to override a cancellation fee")
+
+// SystemActor is recorded in the status history for changes made by the service itself.
+const SystemActor = "system"
+
+func validateTransition(from, to string) error {
+	for _, next := range bookingTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrInvalidTransition, "%s -> %s", from, to)
+}
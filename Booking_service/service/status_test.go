@@ -0,0 +1,38 @@
+package service
+
+import (
+	"Booking_Service/models"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{models.StatusPending, models.StatusConfirmed, true},
+		{models.StatusConfirmed, models.StatusEnRoute, true},
+		{models.StatusEnRoute, models.StatusInProgress, true},
+		{models.StatusInProgress, models.StatusCompleted, true},
+		{models.StatusPending, models.StatusRejected, true},
+		{models.StatusConfirmed, models.StatusNoShow, true},
+		{models.StatusEnRoute, models.StatusCanceled, true},
+		{models.StatusCompleted, models.StatusPending, false},
+		{models.StatusPending, models.StatusCompleted, false},
+		{models.StatusInProgress, models.StatusCanceled, false},
+		{models.StatusCanceled, models.StatusConfirmed, false},
+		{models.StatusRejected, models.StatusConfirmed, false},
+	}
+
+	for _, tt := range tests {
+		err := validateTransition(tt.from, tt.to)
+		if tt.ok {
+			require.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			require.True(t, errors.Is(err, ErrInvalidTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
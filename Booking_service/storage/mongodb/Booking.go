@@ -3,6 +3,8 @@ package mongodb
 import (
 	pb "Booking_Service/genproto/booking"
 	"Booking_Service/models"
+	"Booking_Service/pkg/pricing"
+	"Booking_Service/pkg/slots"
 	"Booking_Service/storage"
 	redisDB "Booking_Service/storage/redis"
 	"context"
@@ -17,9 +19,14 @@ import (
 	"time"
 )
 
+// ErrSlotTaken is returned when the provider already has a booking overlapping the requested time.
+var ErrSlotTaken = errors.New("provider is already booked at the requested time")
+
 type BookingRepo struct {
 	col  *mongo.Collection
 	col1 *mongo.Collection
+	col2 *mongo.Collection
+	col3 *mongo.Collection
 	Rdb  *redis.Client
 }
 
@@ -27,42 +34,56 @@ func NewBookingRepo(db *mongo.Database, rd *redis.Client) storage.IBookingStorag
 	return &BookingRepo{
 		col:  db.Collection("bookings"),
 		col1: db.Collection("services"),
+		col2: db.Collection("providers"),
+		col3: db.Collection("pricing_rules"),
 		Rdb:  rd,
 	}
 }
 
-func (b *BookingRepo) Add(ctx context.Context, req *pb.CreateBookingRequest) (*pb.BookingResponse, error) {
-	var service models.Service
-	m := &ManagementRepo{
-		collection: b.col1,
-	}
-	// Convert ServiceId to ObjectID
-	serviceID, err := primitive.ObjectIDFromHex(req.ServiceId)
+// Add stores a new booking. A quoted price is charged as is; without one the booking
+// is priced with the provider's current rules.
+func (b *BookingRepo) Add(ctx context.Context, req *pb.CreateBookingRequest, quoted *models.PriceBreakdown) (*pb.BookingResponse, error) {
+	provider, service, slot, err := b.plan(ctx, req)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid service ID format")
+		return nil, err
 	}
 
-	// Find the service by ID
-	err = m.collection.FindOne(ctx, bson.M{"_id": serviceID}).Decode(&service)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("service not found")
+	a := models.NewGeoPoint(req.Location.Latitude, req.Location.Longitude)
+	breakdown := quoted
+	if breakdown == nil {
+		breakdown, err = b.price(ctx, provider, pricing.Input{
+			ServicePrice: service.Price,
+			VehicleClass: req.VehicleClass,
+			Start:        slot.Start,
+			Location:     a,
+		})
+		if err != nil {
+			return nil, err
 		}
-		return nil, errors.Wrap(err, "failed to find service")
 	}
-	fmt.Println(service.Price, serviceID)
-	a := models.GeoPoint{req.Location.Latitude, req.Location.Longitude}
+
+	now := time.Now().Format(time.RFC3339)
 	// Create a new booking instance
 	booking := &models.Booking{
-		UserID:        req.UserId,
-		ProviderID:    req.ProviderId,
-		ServiceID:     req.ServiceId,
-		Status:        "pending",
-		ScheduledTime: req.ScheduledTime,
-		Location:      a,
-		TotalPrice:    service.Price + 5,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-		UpdatedAt:     time.Now().Format(time.RFC3339),
+		UserID:         req.UserId,
+		OrganizationID: req.OrganizationId,
+		ProviderID:     req.ProviderId,
+		ServiceID:      req.ServiceId,
+		Status:         models.StatusPending,
+		ScheduledTime:  req.ScheduledTime,
+		StartAt:        slot.Start,
+		EndAt:          slot.End,
+		Location:       a,
+		VehicleClass:   req.VehicleClass,
+		TotalPrice:     breakdown.Total,
+		PriceBreakdown: breakdown,
+		StatusHistory: []models.StatusChange{{
+			ToStatus:  models.StatusPending,
+			ChangedBy: req.UserId,
+			ChangedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	// Insert the booking into MongoDB
 	res, err := b.col.InsertOne(ctx, booking)
@@ -76,22 +97,80 @@ func (b *BookingRepo) Add(ctx context.Context, req *pb.CreateBookingRequest) (*p
 	if !ok {
 		return nil, errors.New("failed to convert inserted ID to ObjectID")
 	}
+	booking.ID = objID
+
+	// Two requests for the same slot may both pass checkSlot before either is
+	// inserted; the later check sees the other booking and backs out.
+	if err := b.checkConflicts(ctx, req.ProviderId, slot, objID); err != nil {
+		if _, delErr := b.col.DeleteOne(ctx, bson.M{"_id": objID}); delErr != nil {
+			log.Println(delErr)
+		}
+		return nil, err
+	}
 
-	redisDB.IncrementServiceOrderCount(b.Rdb, req.ServiceId)
+	if b.Rdb != nil {
+		redisDB.IncrementServiceOrderCount(b.Rdb, req.ServiceId)
+	}
 
 	// Return the response
-	return &pb.BookingResponse{
-		Id:            objID.Hex(),
-		UserId:        req.UserId,
-		ProviderId:    req.ProviderId,
-		ServiceId:     req.ServiceId,
-		Status:        booking.Status,
-		ScheduledTime: booking.ScheduledTime,
-		Location:      req.Location,
-		TotalPrice:    booking.TotalPrice,
-		CreatedAt:     booking.CreatedAt,
-		UpdatedAt:     booking.UpdatedAt,
-	}, nil
+	return bookingToResponse(booking), nil
+}
+
+// Quote prices a booking request without storing it.
+func (b *BookingRepo) Quote(ctx context.Context, req *pb.CreateBookingRequest) (*models.PriceBreakdown, error) {
+	provider, service, slot, err := b.plan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.price(ctx, provider, pricing.Input{
+		ServicePrice: service.Price,
+		VehicleClass: req.VehicleClass,
+		Start:        slot.Start,
+		Location:     models.NewGeoPoint(req.Location.Latitude, req.Location.Longitude),
+	})
+}
+
+// plan loads the service and provider of a new booking and checks that the provider
+// can take it at the requested time.
+func (b *BookingRepo) plan(ctx context.Context, req *pb.CreateBookingRequest) (*models.Provider, *models.Service, slots.Interval, error) {
+	var service models.Service
+	m := &ManagementRepo{
+		collection: b.col1,
+	}
+	// Convert ServiceId to ObjectID
+	serviceID, err := primitive.ObjectIDFromHex(req.ServiceId)
+	if err != nil {
+		return nil, nil, slots.Interval{}, errors.Wrap(err, "invalid service ID format")
+	}
+
+	// Find the service by ID
+	err = m.collection.FindOne(ctx, bson.M{"_id": serviceID}).Decode(&service)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, slots.Interval{}, storage.ErrServiceNotFound
+		}
+		return nil, nil, slots.Interval{}, errors.Wrap(err, "failed to find service")
+	}
+
+	start, err := time.Parse(time.RFC3339, req.ScheduledTime)
+	if err != nil {
+		return nil, nil, slots.Interval{}, errors.Wrap(err, "invalid scheduled time format")
+	}
+	slot := slots.Interval{Start: start, End: start.Add(time.Duration(service.Duration) * time.Minute)}
+	provider, err := b.provider(ctx, req.ProviderId)
+	if err != nil {
+		return nil, nil, slots.Interval{}, err
+	}
+	// New bookings only go to providers whose application was approved.
+	if provider.Status != models.ProviderApproved {
+		return nil, nil, slots.Interval{}, storage.ErrProviderNotApproved
+	}
+	if err := b.checkSlot(ctx, provider, slot, primitive.NilObjectID); err != nil {
+		return nil, nil, slots.Interval{}, err
+	}
+
+	return provider, &service, slot, nil
 }
 
 func (b *BookingRepo) Read(ctx context.Context, req *pb.IdRequest) (*pb.BookingResponse, error) {
@@ -100,18 +179,19 @@ func (b *BookingRepo) Read(ctx context.Context, req *pb.IdRequest) (*pb.BookingR
 		return nil, errors.Wrap(err, "invalid booking ID")
 	}
 
-	var booking pb.BookingResponse
+	var booking models.Booking
 	err = b.col.FindOne(ctx, bson.M{"_id": id}).Decode(&booking)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("booking not found")
+			return nil, storage.ErrBookingNotFound
 		}
 		return nil, errors.Wrap(err, "failed to decode booking")
 	}
 
-	return &booking, nil
+	return bookingToResponse(&booking), nil
 }
 
+// Update moves a pending or confirmed booking to another service and prices it again.
 func (b *BookingRepo) Update(ctx context.Context, req *pb.UpdateBookingRequest) (*pb.BookingResponse, error) {
 	m := &ManagementRepo{
 		collection: b.col1,
@@ -132,68 +212,177 @@ func (b *BookingRepo) Update(ctx context.Context, req *pb.UpdateBookingRequest)
 	err = m.collection.FindOne(ctx, bson.M{"_id": serviceID}).Decode(&service)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("service not found")
+			return nil, storage.ErrServiceNotFound
 		}
 		return nil, errors.Wrap(err, "failed to find service")
 	}
+
+	var current models.Booking
+	err = b.col.FindOne(ctx, bson.M{"_id": id}).Decode(&current)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, storage.ErrBookingNotFound
+		}
+		return nil, errors.Wrap(err, "failed to find booking")
+	}
+	if current.Status != models.StatusPending && current.Status != models.StatusConfirmed {
+		return nil, errors.Wrapf(storage.ErrBookingLocked, "booking is %s", current.Status)
+	}
+
+	start := current.StartAt
+	if start.IsZero() {
+		start, err = time.Parse(time.RFC3339, current.ScheduledTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid scheduled time format")
+		}
+	}
+	slot := slots.Interval{Start: start, End: start.Add(time.Duration(service.Duration) * time.Minute)}
+	provider, err := b.provider(ctx, current.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkSlot(ctx, provider, slot, id); err != nil {
+		return nil, err
+	}
+	breakdown, err := b.price(ctx, provider, pricing.Input{
+		ServicePrice: service.Price,
+		VehicleClass: current.VehicleClass,
+		Start:        slot.Start,
+		Location:     current.Location,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	updateData := bson.M{
 		"$set": bson.M{
-			"service_id":  req.ServiceId,
-			"total_price": service.Price + 5,
-			"updated_at":  time.Now().Format(time.RFC3339),
+			"service_id":      req.ServiceId,
+			"total_price":     breakdown.Total,
+			"price_breakdown": breakdown,
+			"start_at":        slot.Start,
+			"end_at":          slot.End,
+			"updated_at":      time.Now().Format(time.RFC3339),
 		},
 	}
 
-	_, err = b.col.UpdateOne(ctx, bson.M{"_id": id}, updateData)
+	// The edit only applies while the booking is still in the status it was checked
+	// in, so it cannot slip in after a concurrent transition.
+	res, err := b.col.UpdateOne(ctx, bson.M{"_id": id, "status": current.Status}, updateData)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update booking")
 	}
+	if res.MatchedCount == 0 {
+		return nil, errors.New("booking not found or its status has already changed")
+	}
 
-	var updatedBooking *pb.BookingResponse
+	var updatedBooking models.Booking
 	err = b.col.FindOne(ctx, bson.M{"_id": id}).Decode(&updatedBooking)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to retrieve updated booking")
 	}
 
-	return updatedBooking, nil
+	return bookingToResponse(&updatedBooking), nil
+}
+
+// UpdateStatus moves the booking from status "from" to change.ToStatus and appends
+// the change to its history. The update only applies while the booking is still in
+// "from", so two concurrent transitions can never both succeed.
+func (b *BookingRepo) UpdateStatus(ctx context.Context, id string, from string, change *models.StatusChange) (*pb.BookingResponse, error) {
+	return b.updateStatus(ctx, id, from, change, bson.M{})
+}
+
+// Cancel is UpdateStatus to canceled that also records the cancellation fee, which
+// was calculated for the booking in status "from". Both are written together, so the
+// fee always belongs to the transition that actually happened.
+func (b *BookingRepo) Cancel(ctx context.Context, id string, from string, fee float64, change *models.StatusChange) (*pb.BookingResponse, error) {
+	return b.updateStatus(ctx, id, from, change, bson.M{"cancellation_fee": fee})
+}
+
+func (b *BookingRepo) updateStatus(ctx context.Context, id string, from string, change *models.StatusChange, set bson.M) (*pb.BookingResponse, error) {
+	ID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid booking ID")
+	}
+
+	set["status"] = change.ToStatus
+	set["updated_at"] = change.ChangedAt
+	update := bson.M{
+		"$set":  set,
+		"$push": bson.M{"status_history": change},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedBooking models.Booking
+	err = b.col.FindOneAndUpdate(ctx, bson.M{"_id": ID, "status": from}, update, opts).Decode(&updatedBooking)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("booking not found or its status has already changed")
+		}
+		return nil, errors.Wrap(err, "failed to update status")
+	}
+
+	return bookingToResponse(&updatedBooking), nil
 }
-func (b *BookingRepo) UpdateStatus(ctx context.Context, id string, status string) error {
+
+// SetPaymentStatus records the status of the booking's latest payment.
+func (b *BookingRepo) SetPaymentStatus(ctx context.Context, id string, status string) error {
 	ID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return errors.Wrap(err, "invalid booking ID")
 	}
 
-	_, err = b.col.UpdateOne(ctx, bson.M{"_id": ID}, bson.M{"$set": bson.M{"status": status}})
+	res, err := b.col.UpdateOne(ctx, bson.M{"_id": ID}, bson.M{"$set": bson.M{
+		"payment_status": status,
+		"updated_at":     time.Now().Format(time.RFC3339),
+	}})
 	if err != nil {
-		return errors.Wrap(err, "failed to update status")
+		return errors.Wrap(err, "failed to update payment status")
+	}
+	if res.MatchedCount == 0 {
+		return storage.ErrBookingNotFound
 	}
 	return nil
 }
 
-func (b *BookingRepo) Cancel(ctx context.Context, id *pb.IdRequest) (*pb.BookingResponse, error) {
-	ID, err := primitive.ObjectIDFromHex(id.Id)
+// SetCancellationFee records the fee of a canceled booking. A non-nil override
+// records that an admin changed the fee.
+func (b *BookingRepo) SetCancellationFee(ctx context.Context, id string, fee float64, override *models.FeeOverride) (*pb.BookingResponse, error) {
+	ID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid booking ID")
 	}
-	_, err = b.col.UpdateOne(ctx, bson.M{"_id": ID}, bson.M{"$set": bson.M{"status": "canceled"}})
-	var updatedBooking *pb.BookingResponse
-	err = b.col.FindOne(ctx, bson.M{"_id": ID}).Decode(&updatedBooking)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve updated booking")
+
+	set := bson.M{
+		"cancellation_fee": fee,
+		"updated_at":       time.Now().Format(time.RFC3339),
+	}
+	if override != nil {
+		set["fee_override"] = override
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	return updatedBooking, nil
+	var booking models.Booking
+	err = b.col.FindOneAndUpdate(ctx, bson.M{"_id": ID}, bson.M{"$set": set}, opts).Decode(&booking)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, storage.ErrBookingNotFound
+		}
+		return nil, errors.Wrap(err, "failed to update cancellation fee")
+	}
+	return bookingToResponse(&booking), nil
 }
 
 func (b *BookingRepo) List(ctx context.Context, req *pb.ListBookingsRequest) (*pb.ListBookingsResponse, error) {
 	filter := bson.M{}
-	if req.UserId != "" {
+	if req.OrganizationId != "" {
+		filter["organization_id"] = req.OrganizationId
+	} else if req.UserId != "" {
 		filter["user_id"] = req.UserId
 	}
 
 	opts := options.Find()
 	if req.Page > 0 && req.Limit > 0 {
-		opts.SetSkip(int64(req.Page * req.Limit))
+		opts.SetSkip(int64((req.Page - 1) * req.Limit))
 		opts.SetLimit(int64(req.Limit))
 	}
 
@@ -205,12 +394,12 @@ func (b *BookingRepo) List(ctx context.Context, req *pb.ListBookingsRequest) (*p
 
 	var bookings []*pb.BookingResponse
 	for cur.Next(ctx) {
-		var booking pb.BookingResponse
+		var booking models.Booking
 		err := cur.Decode(&booking)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to decode booking")
 		}
-		bookings = append(bookings, &booking)
+		bookings = append(bookings, bookingToResponse(&booking))
 	}
 
 	if err := cur.Err(); err != nil {
@@ -225,3 +414,205 @@ func (b *BookingRepo) List(ctx context.Context, req *pb.ListBookingsRequest) (*p
 		Bookings: bookings,
 	}, nil
 }
+
+// ListByProviders returns the bookings of the given providers that are in the given status,
+// oldest first so providers answer requests in the order they came in.
+func (b *BookingRepo) ListByProviders(ctx context.Context, providerIDs []string, status string, page, limit int32) (*pb.ListBookingsResponse, error) {
+	filter := bson.M{
+		"provider_id": bson.M{"$in": providerIDs},
+		"status":      status,
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	if page > 0 && limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := b.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list bookings")
+	}
+	defer cur.Close(ctx)
+
+	bookings := []*pb.BookingResponse{}
+	for cur.Next(ctx) {
+		var booking models.Booking
+		if err := cur.Decode(&booking); err != nil {
+			return nil, errors.Wrap(err, "failed to decode booking")
+		}
+		bookings = append(bookings, bookingToResponse(&booking))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return &pb.ListBookingsResponse{Bookings: bookings}, nil
+}
+
+// ListCreatedBefore returns every booking in the given status created before the given
+// time. created_at is an RFC3339 string whose offset depends on the writer, so it is
+// parsed and compared as a date rather than as text.
+func (b *BookingRepo) ListCreatedBefore(ctx context.Context, status string, before time.Time) ([]*pb.BookingResponse, error) {
+	cur, err := b.col.Find(ctx, bson.M{
+		"status": status,
+		"$expr": bson.M{"$lt": bson.A{
+			bson.M{"$dateFromString": bson.M{"dateString": "$created_at", "onError": before}},
+			before,
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list bookings")
+	}
+	defer cur.Close(ctx)
+
+	var bookings []*pb.BookingResponse
+	for cur.Next(ctx) {
+		var booking models.Booking
+		if err := cur.Decode(&booking); err != nil {
+			return nil, errors.Wrap(err, "failed to decode booking")
+		}
+		bookings = append(bookings, bookingToResponse(&booking))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return bookings, nil
+}
+
+// ListBusy returns the time ranges of the provider's bookings that still occupy it and
+// overlap the given period.
+func (b *BookingRepo) ListBusy(ctx context.Context, providerID string, period slots.Interval) ([]slots.Interval, error) {
+	return b.busy(ctx, providerID, period, primitive.NilObjectID)
+}
+
+func (b *BookingRepo) provider(ctx context.Context, providerID string) (*models.Provider, error) {
+	p := &ProviderRepo{
+		collection: b.col2,
+	}
+	return p.GetByID(ctx, providerID)
+}
+
+// price applies the pricing rules of the provider to the booking.
+func (b *BookingRepo) price(ctx context.Context, provider *models.Provider, in pricing.Input) (*models.PriceBreakdown, error) {
+	p := &PricingRepo{
+		col: b.col3,
+	}
+	rules, err := p.Effective(ctx, provider.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	// Time rules and the weekend multiplier read the provider's clock, not the offset
+	// the customer happened to send the scheduled time in.
+	loc, err := slots.Location(provider)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid provider schedule")
+	}
+	in.Start = in.Start.In(loc)
+	in.Provider = provider.Location
+	return pricing.Quote(rules, in)
+}
+
+// checkSlot makes sure the provider works during the whole slot and has no other
+// booking overlapping it. exclude skips the booking being rescheduled.
+func (b *BookingRepo) checkSlot(ctx context.Context, provider *models.Provider, slot slots.Interval, exclude primitive.ObjectID) error {
+	windows, err := slots.Windows(provider, slot.Start)
+	if err != nil {
+		return errors.Wrap(err, "invalid provider schedule")
+	}
+	if !slots.Fits(slot, windows) {
+		return errors.New("provider is not working at the requested time")
+	}
+
+	return b.checkConflicts(ctx, provider.ID.Hex(), slot, exclude)
+}
+
+func (b *BookingRepo) checkConflicts(ctx context.Context, providerID string, slot slots.Interval, exclude primitive.ObjectID) error {
+	busy, err := b.busy(ctx, providerID, slot, exclude)
+	if err != nil {
+		return err
+	}
+	if len(busy) > 0 {
+		return ErrSlotTaken
+	}
+	return nil
+}
+
+func (b *BookingRepo) busy(ctx context.Context, providerID string, period slots.Interval, exclude primitive.ObjectID) ([]slots.Interval, error) {
+	filter := bson.M{
+		"provider_id": providerID,
+		"status":      bson.M{"$in": models.BusyStatuses},
+		"start_at":    bson.M{"$lt": period.End},
+		"end_at":      bson.M{"$gt": period.Start},
+	}
+	if !exclude.IsZero() {
+		filter["_id"] = bson.M{"$ne": exclude}
+	}
+
+	opts := options.Find().SetProjection(bson.M{"start_at": 1, "end_at": 1})
+	cur, err := b.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list provider bookings")
+	}
+	defer cur.Close(ctx)
+
+	var busy []slots.Interval
+	for cur.Next(ctx) {
+		var booking models.Booking
+		if err := cur.Decode(&booking); err != nil {
+			return nil, errors.Wrap(err, "failed to decode booking")
+		}
+		busy = append(busy, slots.Interval{Start: booking.StartAt, End: booking.EndAt})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return busy, nil
+}
+
+func bookingToResponse(booking *models.Booking) *pb.BookingResponse {
+	history := make([]*pb.StatusChange, 0, len(booking.StatusHistory))
+	for _, change := range booking.StatusHistory {
+		history = append(history, &pb.StatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedBy:  change.ChangedBy,
+			Reason:     change.Reason,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+
+	return &pb.BookingResponse{
+		Id:                      booking.ID.Hex(),
+		UserId:                  booking.UserID,
+		OrganizationId:          booking.OrganizationID,
+		ProviderId:              booking.ProviderID,
+		ServiceId:               booking.ServiceID,
+		Status:                  booking.Status,
+		ScheduledTime:           booking.ScheduledTime,
+		Location:                &pb.GeoPoint{Latitude: booking.Location.Latitude(), Longitude: booking.Location.Longitude()},
+		TotalPrice:              booking.TotalPrice,
+		VehicleClass:            booking.VehicleClass,
+		PriceBreakdown:          pricing.ToResponse(booking.PriceBreakdown),
+		PaymentStatus:           booking.PaymentStatus,
+		CancellationFee:         booking.CancelFee,
+		CancellationFeeOverride: feeOverrideToResponse(booking.FeeOverride),
+		StatusHistory:           history,
+		CreatedAt:               booking.CreatedAt,
+		UpdatedAt:               booking.UpdatedAt,
+	}
+}
+
+func feeOverrideToResponse(override *models.FeeOverride) *pb.CancellationFeeOverride {
+	if override == nil {
+		return nil
+	}
+	return &pb.CancellationFeeOverride{
+		OriginalFee:  override.OriginalFee,
+		Reason:       override.Reason,
+		OverriddenBy: override.OverriddenBy,
+		OverriddenAt: override.OverriddenAt,
+	}
+}
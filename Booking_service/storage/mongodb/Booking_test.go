@@ -7,179 +7,450 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 
 	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
 	"github.com/stretchr/testify/require"
 )
 
-func SetupTestDB(t *testing.T) (*mongo.Client, *mongo.Collection, func()) {
-	// Initialize mtest
-	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
-
-	var client *mongo.Client
-	var db *mongo.Database
-	var col *mongo.Collection
-
-	mt.Run("SetupTestDB", func(mt *mtest.T) {
-		client = mt.Client
-		db = client.Database("booking_test")
-		col = db.Collection("bookings")
-	})
+// scheduledAt is a Monday morning inside providerDoc's working hours.
+var scheduledAt = time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
 
-	// Cleanup function to drop the database
-	cleanup := func() {
-		_ = db.Drop(context.Background()) // Drop the test database
+func providerDoc(id primitive.ObjectID) bson.D {
+	return bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: "owner123"},
+		{Key: "availability", Value: "09:00-18:00"},
+		{Key: "status", Value: models.ProviderApproved},
 	}
+}
 
-	return client, col, cleanup
+func bookingDoc(id primitive.ObjectID, userID, status string) bson.D {
+	return bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: userID},
+		{Key: "provider_id", Value: "provider123"},
+		{Key: "service_id", Value: primitive.NewObjectID().Hex()},
+		{Key: "status", Value: status},
+		{Key: "scheduled_time", Value: scheduledAt.Format(time.RFC3339)},
+		{Key: "location", Value: models.NewGeoPoint(40.7128, -74.0060)},
+		{Key: "total_price", Value: 55.0},
+		{Key: "status_history", Value: bson.A{
+			bson.D{{Key: "to_status", Value: models.StatusPending}, {Key: "changed_by", Value: userID}},
+		}},
+	}
 }
 
 func TestAddBooking(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	// Define the mock responses for the services collection
-	mt.Run("SetupTestDB", func(mt *mtest.T) {
-		client := mt.Client
-		//db := client.Database("booking_test")
-		//col := db.Collection("services")
+	serviceDoc := func(id primitive.ObjectID) bson.D {
+		return bson.D{
+			{Key: "_id", Value: id},
+			{Key: "name", Value: "Sample Service"},
+			{Key: "description", Value: "A sample service"},
+			{Key: "price", Value: 50.0},
+			{Key: "duration", Value: 60},
+		}
+	}
 
-		// Mock response for FindOne
+	mt.Run("AddBooking", func(mt *mtest.T) {
 		serviceID := primitive.NewObjectID()
+		providerID := primitive.NewObjectID()
 		mt.AddMockResponses(
-			mtest.CreateCursorResponse(1, "booking_test.services", mtest.FirstBatch, bson.D{
-				{"_id", serviceID},
-				{"name", "Sample Service"},
-				{"description", "A sample service"},
-				{"price", 50.0},
-				{"duration", 60},
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, serviceDoc(serviceID)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, providerDoc(providerID)),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch), // no overlapping bookings
+			mtest.CreateCursorResponse(0, "booking_test.pricing_rules", mtest.FirstBatch, bson.D{
+				{Key: "provider_id", Value: ""},
+				{Key: "vehicle_multipliers", Value: bson.D{{Key: models.VehicleSUV, Value: 1.2}}},
+				{Key: "booking_fee", Value: 5.0},
+				{Key: "tax_rate", Value: 0.1},
 			}),
+			mtest.CreateSuccessResponse(),                                            // InsertOne
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch), // re-check after insert
 		)
 
-		// Mock response for InsertOne
-		mt.AddMockResponses(
-			mtest.CreateSuccessResponse(), // Successful InsertOne response
-		)
-
-		repo := NewBookingRepo(client.Database("booking_test"))
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
 		req := &pb.CreateBookingRequest{
 			UserId:        "user123",
-			ProviderId:    "provider123",
+			ProviderId:    providerID.Hex(),
 			ServiceId:     serviceID.Hex(),
-			ScheduledTime: time.Now().Format(time.RFC3339),
+			ScheduledTime: scheduledAt.Format(time.RFC3339),
 			Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
+			VehicleClass:  models.VehicleSUV,
 		}
 
-		resp, err := repo.Add(context.Background(), req)
-		require.NoError(t, err)
-		require.NotNil(t, resp)
-		require.Equal(t, req.UserId, resp.UserId)
+		resp, err := repo.Add(context.Background(), req, nil)
+		require.NoError(mt, err)
+		require.NotNil(mt, resp)
+		require.Equal(mt, req.UserId, resp.UserId)
+		require.Equal(mt, models.StatusPending, resp.Status)
+		require.Len(mt, resp.StatusHistory, 1)
+		require.Equal(mt, req.UserId, resp.StatusHistory[0].ChangedBy)
+		// 50 + 10 for the SUV + 5 booking fee, plus 10% tax.
+		require.Equal(mt, 71.5, resp.TotalPrice)
+		require.Len(mt, resp.PriceBreakdown.Items, 3)
+		require.Equal(mt, 6.5, resp.PriceBreakdown.Tax)
+	})
+
+	mt.Run("SlotTaken", func(mt *mtest.T) {
+		serviceID := primitive.NewObjectID()
+		providerID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, serviceDoc(serviceID)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, providerDoc(providerID)),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, bson.D{
+				{Key: "start_at", Value: scheduledAt.Add(-30 * time.Minute)},
+				{Key: "end_at", Value: scheduledAt.Add(30 * time.Minute)},
+			}),
+		)
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		_, err := repo.Add(context.Background(), &pb.CreateBookingRequest{
+			UserId:        "user456",
+			ProviderId:    providerID.Hex(),
+			ServiceId:     serviceID.Hex(),
+			ScheduledTime: scheduledAt.Format(time.RFC3339),
+			Location:      &pb.GeoPoint{},
+		}, nil)
+		require.ErrorIs(mt, err, ErrSlotTaken)
+	})
+
+	mt.Run("ProviderNotApproved", func(mt *mtest.T) {
+		serviceID := primitive.NewObjectID()
+		providerID := primitive.NewObjectID()
+		provider := providerDoc(providerID)
+		provider[len(provider)-1].Value = models.ProviderSubmitted
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, serviceDoc(serviceID)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, provider),
+		)
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		_, err := repo.Add(context.Background(), &pb.CreateBookingRequest{
+			UserId:        "user456",
+			ProviderId:    providerID.Hex(),
+			ServiceId:     serviceID.Hex(),
+			ScheduledTime: scheduledAt.Format(time.RFC3339),
+			Location:      &pb.GeoPoint{},
+		}, nil)
+		require.ErrorIs(mt, err, storage.ErrProviderNotApproved)
 	})
 
+	mt.Run("OutsideWorkingHours", func(mt *mtest.T) {
+		serviceID := primitive.NewObjectID()
+		providerID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, serviceDoc(serviceID)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, providerDoc(providerID)),
+		)
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		_, err := repo.Add(context.Background(), &pb.CreateBookingRequest{
+			UserId:        "user456",
+			ProviderId:    providerID.Hex(),
+			ServiceId:     serviceID.Hex(),
+			ScheduledTime: scheduledAt.Add(7*time.Hour + 30*time.Minute).Format(time.RFC3339),
+			Location:      &pb.GeoPoint{},
+		}, nil)
+		require.Error(mt, err)
+	})
 }
 
 func TestReadBooking(t *testing.T) {
-	client, _, cleanup := SetupTestDB(t)
-	defer cleanup()
-
-	repo := NewBookingRepo(client.Database("booking_test"))
-	booking := &pb.CreateBookingRequest{
-		UserId:        "user123",
-		ProviderId:    "provider123",
-		ServiceId:     primitive.NewObjectID().Hex(),
-		ScheduledTime: time.Now().Format(time.RFC3339),
-		Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
-	}
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	resp, err := repo.Add(context.Background(), booking)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	mt.Run("ReadBooking", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, bookingDoc(id, "user123", models.StatusPending)),
+		)
 
-	readResp, err := repo.Read(context.Background(), &pb.IdRequest{Id: resp.Id})
-	require.NoError(t, err)
-	require.NotNil(t, readResp)
-	require.Equal(t, resp.UserId, readResp.UserId)
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		readResp, err := repo.Read(context.Background(), &pb.IdRequest{Id: id.Hex()})
+		require.NoError(mt, err)
+		require.NotNil(mt, readResp)
+		require.Equal(mt, id.Hex(), readResp.Id)
+		require.Equal(mt, "user123", readResp.UserId)
+		require.Equal(mt, 40.7128, readResp.Location.Latitude)
+		require.Equal(mt, -74.0060, readResp.Location.Longitude)
+	})
 }
 
 func TestUpdateBooking(t *testing.T) {
-	client, _, cleanup := SetupTestDB(t)
-	defer cleanup()
-
-	repo := NewBookingRepo(client.Database("booking_test"))
-	booking := &pb.CreateBookingRequest{
-		UserId:        "user123",
-		ProviderId:    "provider123",
-		ServiceId:     primitive.NewObjectID().Hex(),
-		ScheduledTime: time.Now().Format(time.RFC3339),
-		Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
-	}
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	resp, err := repo.Add(context.Background(), booking)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	mt.Run("UpdateBooking", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		serviceID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: serviceID},
+				{Key: "price", Value: 70.0},
+				{Key: "duration", Value: 90},
+			}),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, append(
+				bookingDoc(id, "user123", models.StatusPending),
+				bson.E{Key: "provider_id", Value: primitive.NewObjectID().Hex()},
+			)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, providerDoc(primitive.NewObjectID())),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "booking_test.pricing_rules", mtest.FirstBatch), // default pricing
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, append(
+				bookingDoc(id, "user123", models.StatusPending)[:3],
+				bson.E{Key: "service_id", Value: serviceID.Hex()},
+			)),
+		)
 
-	updateReq := &pb.UpdateBookingRequest{
-		Id:        resp.Id,
-		ServiceId: primitive.NewObjectID().Hex(),
-	}
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		updateReq := &pb.UpdateBookingRequest{
+			Id:        id.Hex(),
+			ServiceId: serviceID.Hex(),
+		}
+
+		updateResp, err := repo.Update(context.Background(), updateReq)
+		require.NoError(mt, err)
+		require.NotNil(mt, updateResp)
+		require.Equal(mt, updateReq.ServiceId, updateResp.ServiceId)
+	})
+
+	mt.Run("Locked", func(mt *mtest.T) {
+		serviceID := primitive.NewObjectID()
+		for _, status := range []string{models.StatusEnRoute, models.StatusInProgress, models.StatusCompleted, models.StatusCanceled} {
+			id := primitive.NewObjectID()
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, bson.D{{Key: "_id", Value: serviceID}}),
+				mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, bookingDoc(id, "user123", status)),
+			)
+
+			_, err := NewBookingRepo(mt.Client.Database("booking_test"), nil).Update(context.Background(), &pb.UpdateBookingRequest{
+				Id:        id.Hex(),
+				ServiceId: serviceID.Hex(),
+			})
+			require.ErrorIs(mt, err, storage.ErrBookingLocked, status)
+		}
+	})
+
+	mt.Run("StatusAlreadyChanged", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		serviceID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: serviceID},
+				{Key: "price", Value: 70.0},
+				{Key: "duration", Value: 90},
+			}),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, append(
+				bookingDoc(id, "user123", models.StatusConfirmed),
+				bson.E{Key: "provider_id", Value: primitive.NewObjectID().Hex()},
+			)),
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, providerDoc(primitive.NewObjectID())),
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "booking_test.pricing_rules", mtest.FirstBatch),
+			// The provider set out between the read and the update.
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+		)
 
-	updateResp, err := repo.Update(context.Background(), updateReq)
-	require.NoError(t, err)
-	require.NotNil(t, updateResp)
-	require.Equal(t, updateReq.ServiceId, updateResp.ServiceId)
+		_, err := NewBookingRepo(mt.Client.Database("booking_test"), nil).Update(context.Background(), &pb.UpdateBookingRequest{
+			Id:        id.Hex(),
+			ServiceId: serviceID.Hex(),
+		})
+		require.EqualError(mt, err, "booking not found or its status has already changed")
+		var filter bson.Raw
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName == "update" {
+				filter = e.Command.Lookup("updates", "0", "q").Document()
+			}
+		}
+		require.NotNil(mt, filter)
+		require.Equal(mt, models.StatusConfirmed, filter.Lookup("status").StringValue())
+	})
 }
 
 func TestCancelBooking(t *testing.T) {
-	client, _, cleanup := SetupTestDB(t)
-	defer cleanup()
-
-	repo := NewBookingRepo(client.Database("booking_test"))
-	booking := &pb.CreateBookingRequest{
-		UserId:        "user123",
-		ProviderId:    "provider123",
-		ServiceId:     primitive.NewObjectID().Hex(),
-		ScheduledTime: time.Now().Format(time.RFC3339),
-		Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
-	}
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("CancelBooking", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		canceled := bookingDoc(id, "user123", models.StatusCanceled)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: canceled}))
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		cancelResp, err := repo.UpdateStatus(context.Background(), id.Hex(), models.StatusPending, &models.StatusChange{
+			FromStatus: models.StatusPending,
+			ToStatus:   models.StatusCanceled,
+			ChangedBy:  "user123",
+			ChangedAt:  time.Now().Format(time.RFC3339),
+		})
+		require.NoError(mt, err)
+		require.NotNil(mt, cancelResp)
+		require.Equal(mt, models.StatusCanceled, cancelResp.Status)
+	})
+
+	mt.Run("CancelWithFee", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		canceled := append(bookingDoc(id, "user123", models.StatusCanceled), bson.E{Key: "cancellation_fee", Value: 27.5})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: canceled}))
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		resp, err := repo.Cancel(context.Background(), id.Hex(), models.StatusConfirmed, 27.5, &models.StatusChange{
+			FromStatus: models.StatusConfirmed,
+			ToStatus:   models.StatusCanceled,
+			ChangedBy:  "user123",
+			ChangedAt:  time.Now().Format(time.RFC3339),
+		})
+		require.NoError(mt, err)
+		require.Equal(mt, 27.5, resp.CancellationFee)
+
+		cmd := mt.GetStartedEvent().Command
+		require.Equal(mt, models.StatusConfirmed, cmd.Lookup("query", "status").StringValue())
+		require.Equal(mt, models.StatusCanceled, cmd.Lookup("update", "$set", "status").StringValue())
+		require.Equal(mt, 27.5, cmd.Lookup("update", "$set", "cancellation_fee").Double())
+	})
 
-	resp, err := repo.Add(context.Background(), booking)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	mt.Run("StatusAlreadyChanged", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
 
-	cancelResp, err := repo.Cancel(context.Background(), &pb.IdRequest{Id: resp.Id})
-	require.NoError(t, err)
-	require.NotNil(t, cancelResp)
-	require.Equal(t, "canceled", cancelResp.Status)
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		_, err := repo.UpdateStatus(context.Background(), primitive.NewObjectID().Hex(), models.StatusPending, &models.StatusChange{
+			FromStatus: models.StatusPending,
+			ToStatus:   models.StatusCanceled,
+		})
+		require.Error(mt, err)
+	})
 }
 
 func TestListBookings(t *testing.T) {
-	client, _, cleanup := SetupTestDB(t)
-	defer cleanup()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	repo := NewBookingRepo(client.Database("booking_test"))
+	mt.Run("ListBookings", func(mt *mtest.T) {
+		var docs []bson.D
+		for i := 0; i < 5; i++ {
+			docs = append(docs, bookingDoc(primitive.NewObjectID(), "user123", models.StatusPending))
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch, docs...),
+		)
 
-	for i := 0; i < 5; i++ {
-		booking := &pb.CreateBookingRequest{
-			UserId:        "user" + primitive.NewObjectID().Hex(),
-			ProviderId:    "provider" + primitive.NewObjectID().Hex(),
-			ServiceId:     primitive.NewObjectID().Hex(),
-			ScheduledTime: time.Now().Format(time.RFC3339),
-			Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		listReq := &pb.ListBookingsRequest{
+			UserId: "user123",
+			Limit:  5,
+			Page:   0,
 		}
 
-		_, err := repo.Add(context.Background(), booking)
-		require.NoError(t, err)
-	}
+		listResp, err := repo.List(context.Background(), listReq)
+		require.NoError(mt, err)
+		require.NotNil(mt, listResp)
+		require.Len(mt, listResp.Bookings, 5)
+	})
+
+	mt.Run("FirstPage", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch))
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		_, err := repo.List(context.Background(), &pb.ListBookingsRequest{UserId: "user123", Limit: 5, Page: 1})
+		require.NoError(mt, err)
+
+		cmd := mt.GetStartedEvent().Command
+		require.Equal(mt, int64(0), cmd.Lookup("skip").AsInt64(), "pages start at 1")
+	})
+}
+
+func TestListCreatedBefore(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	listReq := &pb.ListBookingsRequest{
-		UserId: "user123",
-		Limit:  5,
-		Page:   0,
+	mt.Run("ComparesDates", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch,
+			bookingDoc(primitive.NewObjectID(), "user123", models.StatusPending),
+		))
+
+		before := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.FixedZone("", 5*3600))
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		resp, err := repo.ListCreatedBefore(context.Background(), models.StatusPending, before)
+		require.NoError(mt, err)
+		require.Len(mt, resp, 1)
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter")
+		require.Equal(mt, models.StatusPending, filter.Document().Lookup("status").StringValue())
+		bound := filter.Document().Lookup("$expr", "$lt").Array().Index(1).Value().Time()
+		require.True(mt, before.Equal(bound), "created_at is compared with a date")
+	})
+}
+
+func TestListBookingsByProviders(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("ListByProviders", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch,
+				bookingDoc(primitive.NewObjectID(), "user123", models.StatusPending),
+				bookingDoc(primitive.NewObjectID(), "user456", models.StatusPending),
+			),
+		)
+
+		repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+		resp, err := repo.ListByProviders(context.Background(), []string{"provider123"}, models.StatusPending, 0, 10)
+		require.NoError(mt, err)
+		require.Len(mt, resp.Bookings, 2)
+		require.Equal(mt, "provider123", resp.Bookings[0].ProviderId)
+	})
+}
+
+func TestQuoteProviderTimezone(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	serviceID := primitive.NewObjectID()
+	providerID := primitive.NewObjectID()
+
+	// 17:00 on a Monday in New York, sent in three offsets. Only the provider's clock
+	// decides the evening surcharge; from +05:00 it would otherwise look like 03:00 on
+	// a Tuesday.
+	tests := []string{
+		"2030-01-07T17:00:00-05:00",
+		"2030-01-07T22:00:00Z",
+		"2030-01-08T03:00:00+05:00",
 	}
 
-	listResp, err := repo.List(context.Background(), listReq)
-	require.NoError(t, err)
-	require.NotNil(t, listResp)
-	require.Len(t, listResp.Bookings, 5)
+	for _, scheduled := range tests {
+		mt.Run(scheduled, func(mt *mtest.T) {
+			provider := append(providerDoc(providerID), bson.E{Key: "timezone", Value: "America/New_York"})
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "booking_test.services", mtest.FirstBatch, bson.D{
+					{Key: "_id", Value: serviceID},
+					{Key: "price", Value: 50.0},
+					{Key: "duration", Value: 30},
+				}),
+				mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch, provider),
+				mtest.CreateCursorResponse(0, "booking_test.bookings", mtest.FirstBatch),
+				mtest.CreateCursorResponse(0, "booking_test.pricing_rules", mtest.FirstBatch, bson.D{
+					{Key: "provider_id", Value: ""},
+					{Key: "booking_fee", Value: 5.0},
+					{Key: "time_rules", Value: bson.A{bson.D{
+						{Key: "name", Value: "Evening"},
+						{Key: "weekdays", Value: bson.A{int32(time.Monday)}},
+						{Key: "start", Value: "17:00"},
+						{Key: "end", Value: "18:00"},
+						{Key: "multiplier", Value: 1.5},
+					}}},
+				}),
+			)
+
+			repo := NewBookingRepo(mt.Client.Database("booking_test"), nil)
+			price, err := repo.Quote(context.Background(), &pb.CreateBookingRequest{
+				UserId:        "user123",
+				ProviderId:    providerID.Hex(),
+				ServiceId:     serviceID.Hex(),
+				ScheduledTime: scheduled,
+				Location:      &pb.GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
+				VehicleClass:  models.VehicleSedan,
+			})
+			require.NoError(mt, err)
+			// 50 + 25 for the evening + 5 booking fee.
+			require.Equal(mt, 80.0, price.Total)
+		})
+	}
 }
@@ -29,6 +29,7 @@ func (r *ManagementRepo) CreateService(ctx context.Context, service *pb.CreateSe
 		Description: service.Description,
 		Price:       service.Price,
 		Duration:    service.Duration,
+		UserID:      service.UserId,
 		CreatedAt:   time.Now().Format(time.RFC3339),
 		UpdatedAt:   time.Now().Format(time.RFC3339),
 	}
@@ -38,25 +39,18 @@ func (r *ManagementRepo) CreateService(ctx context.Context, service *pb.CreateSe
 	}
 	id := res.InsertedID.(primitive.ObjectID)
 
-	return &pb.ServiceResponse{
-		Id:          id.String(),
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Duration:    req.Duration,
-		CreatedAt:   time.Now().Format(time.RFC3339),
-		UpdatedAt:   time.Now().Format(time.RFC3339),
-	}, err
+	req.ID = id
+	return serviceToResponse(req), err
 }
 
 func (r *ManagementRepo) GetServiceByID(ctx context.Context, id *pb.IdRequest) (*pb.ServiceResponse, error) {
-	var service pb.ServiceResponse
+	var service models.Service
 	ID, err := primitive.ObjectIDFromHex(id.Id)
 	err = r.collection.FindOne(ctx, bson.M{"_id": ID}).Decode(&service)
 	if err != nil {
 		return nil, err
 	}
-	return &service, err
+	return serviceToResponse(&service), err
 }
 
 func (r *ManagementRepo) UpdateService(ctx context.Context, service *pb.UpdateServiceRequest) error {
@@ -70,9 +64,13 @@ func (r *ManagementRepo) UpdateService(ctx context.Context, service *pb.UpdateSe
 		},
 	}
 
-	_, err := r.collection.UpdateOne(
+	ID, err := primitive.ObjectIDFromHex(service.Id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": service.Id},
+		bson.M{"_id": ID},
 		update,
 	)
 	return err
@@ -128,3 +126,16 @@ func (r *ManagementRepo) PopularServices(ctx context.Context, req *pb.Void) (*pb
 	}
 	return response, nil
 }
+
+func serviceToResponse(service *models.Service) *pb.ServiceResponse {
+	return &pb.ServiceResponse{
+		Id:          service.ID.Hex(),
+		Name:        service.Name,
+		Description: service.Description,
+		Price:       service.Price,
+		Duration:    service.Duration,
+		CreatedAt:   service.CreatedAt,
+		UpdatedAt:   service.UpdatedAt,
+		UserId:      service.UserID,
+	}
+}
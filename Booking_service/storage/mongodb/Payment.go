@@ -26,18 +26,22 @@ func NewPaymentRepo(db *mongo.Database) storage.IPaymentStorage {
 func (r *PaymentRepo) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.PaymentResponse, error) {
 	// Create a new payment instance
 	payment := &models.Payment{
-		UserID:        req.UserId,
-		BookingID:     req.BookingId,
-		Amount:        req.Amount,
-		Status:        "pending", // Default status
-		PaymentMethod: req.PaymentMethod,
-		TransactionID: req.TransactionId,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-		UpdatedAt:     time.Now().Format(time.RFC3339),
+		UserID:         req.UserId,
+		BookingID:      req.BookingId,
+		OrganizationID: req.OrganizationId,
+		Amount:         req.Amount,
+		Status:         models.PaymentPending,
+		PaymentMethod:  req.PaymentMethod,
+		TransactionID:  req.TransactionId,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+		UpdatedAt:      time.Now().Format(time.RFC3339),
 	}
 
 	// Insert the payment into MongoDB
 	res, err := r.collection.InsertOne(ctx, payment)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, storage.ErrActivePayment
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -48,17 +52,8 @@ func (r *PaymentRepo) CreatePayment(ctx context.Context, req *pb.CreatePaymentRe
 		return nil, errors.New("failed to convert inserted ID to ObjectID")
 	}
 
-	// Return the payment response
-	return &pb.PaymentResponse{
-		Id:            objID.Hex(),
-		BookingId:     payment.BookingID,
-		Amount:        payment.Amount,
-		Status:        payment.Status,
-		PaymentMethod: payment.PaymentMethod,
-		TransactionId: payment.TransactionID,
-		CreatedAt:     payment.CreatedAt,
-		UpdatedAt:     payment.UpdatedAt,
-	}, nil
+	payment.ID = objID.Hex()
+	return paymentToResponse(payment), nil
 }
 
 func (r *PaymentRepo) GetPayment(ctx context.Context, id *pb.IdRequest) (*pb.PaymentResponse, error) {
@@ -79,16 +74,8 @@ func (r *PaymentRepo) GetPayment(ctx context.Context, id *pb.IdRequest) (*pb.Pay
 	}
 
 	// Return the payment response
-	return &pb.PaymentResponse{
-		Id:            objID.Hex(),
-		BookingId:     payment.BookingID,
-		Amount:        payment.Amount,
-		Status:        payment.Status,
-		PaymentMethod: payment.PaymentMethod,
-		TransactionId: payment.TransactionID,
-		CreatedAt:     payment.CreatedAt,
-		UpdatedAt:     payment.UpdatedAt,
-	}, nil
+	payment.ID = objID.Hex()
+	return paymentToResponse(&payment), nil
 }
 
 func (r *PaymentRepo) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
@@ -96,7 +83,11 @@ func (r *PaymentRepo) ListPayments(ctx context.Context, req *pb.ListPaymentsRequ
 		SetSkip(int64((req.Page - 1) * req.Limit)).
 		SetLimit(int64(req.Limit))
 
-	cursor, err := r.collection.Find(ctx, bson.M{"user_id": req.UserId}, opts)
+	filter := bson.M{"userId": req.UserId}
+	if req.OrganizationId != "" {
+		filter = bson.M{"organization_id": req.OrganizationId}
+	}
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -104,11 +95,11 @@ func (r *PaymentRepo) ListPayments(ctx context.Context, req *pb.ListPaymentsRequ
 
 	var payments []*pb.PaymentResponse
 	for cursor.Next(ctx) {
-		var payment pb.PaymentResponse
+		var payment models.Payment
 		if err := cursor.Decode(&payment); err != nil {
 			return nil, err
 		}
-		payments = append(payments, &payment)
+		payments = append(payments, paymentToResponse(&payment))
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -117,3 +108,191 @@ func (r *PaymentRepo) ListPayments(ctx context.Context, req *pb.ListPaymentsRequ
 
 	return &pb.ListPaymentsResponse{Payments: payments}, nil
 }
+
+// GetByTransaction finds the payment of a payment provider transaction.
+func (r *PaymentRepo) GetByTransaction(ctx context.Context, transactionID string) (*pb.PaymentResponse, error) {
+	var payment models.Payment
+	err := r.collection.FindOne(ctx, bson.M{"transaction_id": transactionID}).Decode(&payment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("payment not found")
+		}
+		return nil, errors.Wrap(err, "failed to find payment")
+	}
+	return paymentToResponse(&payment), nil
+}
+
+// UpdateStatus moves the payment from status "from" to change.ToStatus and appends
+// the change to its history. A non-empty transactionID is stored too, and the reason
+// of a decline or failure becomes the failure reason. It fails if the status has
+// changed concurrently.
+func (r *PaymentRepo) UpdateStatus(ctx context.Context, id string, from string, transactionID string, change *models.PaymentChange) (*pb.PaymentResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid payment ID")
+	}
+
+	set := bson.M{
+		"status":     change.ToStatus,
+		"updated_at": change.ChangedAt,
+	}
+	if transactionID != "" {
+		set["transaction_id"] = transactionID
+	}
+	if change.ToStatus == models.PaymentDeclined || change.ToStatus == models.PaymentFailed {
+		set["failure_reason"] = change.Reason
+	}
+	update := bson.M{
+		"$set":  set,
+		"$push": bson.M{"status_history": change},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var payment models.Payment
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objID, "status": from}, update, opts).Decode(&payment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("payment not found or its status has already changed")
+		}
+		return nil, errors.Wrap(err, "failed to update payment status")
+	}
+	return paymentToResponse(&payment), nil
+}
+
+// ListByBooking returns the payments of a booking, oldest first.
+func (r *PaymentRepo) ListByBooking(ctx context.Context, bookingID string) ([]*pb.PaymentResponse, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"booking_id": bookingID}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list booking payments")
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*pb.PaymentResponse
+	for cursor.Next(ctx) {
+		var payment models.Payment
+		if err := cursor.Decode(&payment); err != nil {
+			return nil, errors.Wrap(err, "failed to decode payment")
+		}
+		payments = append(payments, paymentToResponse(&payment))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return payments, nil
+}
+
+// AddRefund reserves amount of the payment for a refund: it adds amount to the
+// refunded amount and moves the payment to change.ToStatus. It fails if the payment
+// is no longer in change.FromStatus with "refunded" already refunded, so of two
+// concurrent refunds only one gets to call the payment provider.
+func (r *PaymentRepo) AddRefund(ctx context.Context, id string, refunded float64, amount float64, change *models.PaymentChange) (*pb.PaymentResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid payment ID")
+	}
+
+	filter := bson.M{"_id": objID, "status": change.FromStatus, "refunded_amount": refunded}
+	if refunded == 0 {
+		// Payments created before refunds existed have no refunded amount.
+		delete(filter, "refunded_amount")
+		filter["$or"] = bson.A{
+			bson.M{"refunded_amount": 0},
+			bson.M{"refunded_amount": bson.M{"$exists": false}},
+		}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     change.ToStatus,
+			"updated_at": change.ChangedAt,
+		},
+		"$inc":  bson.M{"refunded_amount": amount},
+		"$push": bson.M{"status_history": change},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var payment models.Payment
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&payment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("payment not found or it has changed concurrently")
+		}
+		return nil, errors.Wrap(err, "failed to record refund")
+	}
+	return paymentToResponse(&payment), nil
+}
+
+// ReleaseRefund gives back the reservation of a refund the payment provider rejected:
+// amount is subtracted from the refunded amount and the payment returns to captured
+// or partially_refunded, whichever the remaining refunded amount implies.
+func (r *PaymentRepo) ReleaseRefund(ctx context.Context, id string, amount float64, source, reason, at string) (*pb.PaymentResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid payment ID")
+	}
+
+	refunded := bson.M{"$round": bson.A{bson.M{"$subtract": bson.A{"$refunded_amount", amount}}, 2}}
+	status := bson.M{"$cond": bson.A{
+		bson.M{"$lte": bson.A{"$refunded_amount", 0}},
+		models.PaymentCaptured,
+		models.PaymentPartiallyRefunded,
+	}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"refunded_amount": refunded}}},
+		{{Key: "$set", Value: bson.M{
+			"status":     status,
+			"updated_at": at,
+			"status_history": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$status_history", bson.A{}}},
+				bson.A{bson.M{
+					"from_status": "$status",
+					"to_status":   status,
+					"source":      source,
+					"reason":      reason,
+					"changed_at":  at,
+				}},
+			}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var payment models.Payment
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objID, "refunded_amount": bson.M{"$gte": amount}}, pipeline, opts).Decode(&payment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("payment not found or refund is not reserved")
+		}
+		return nil, errors.Wrap(err, "failed to release refund")
+	}
+	return paymentToResponse(&payment), nil
+}
+
+func paymentToResponse(payment *models.Payment) *pb.PaymentResponse {
+	history := make([]*pb.PaymentStatusChange, 0, len(payment.StatusHistory))
+	for _, change := range payment.StatusHistory {
+		history = append(history, &pb.PaymentStatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Source:     change.Source,
+			Reason:     change.Reason,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+
+	return &pb.PaymentResponse{
+		Id:             payment.ID,
+		BookingId:      payment.BookingID,
+		Amount:         payment.Amount,
+		Status:         payment.Status,
+		PaymentMethod:  payment.PaymentMethod,
+		TransactionId:  payment.TransactionID,
+		CreatedAt:      payment.CreatedAt,
+		UpdatedAt:      payment.UpdatedAt,
+		UserId:         payment.UserID,
+		OrganizationId: payment.OrganizationID,
+		RefundedAmount: payment.RefundedAmount,
+		FailureReason:  payment.FailureReason,
+		StatusHistory:  history,
+	}
+}
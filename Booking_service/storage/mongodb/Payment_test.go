@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePayment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	req := &pb.CreatePaymentRequest{
+		BookingId:     "booking123",
+		UserId:        "user123",
+		Amount:        55,
+		PaymentMethod: "pm_card_visa",
+	}
+
+	mt.Run("Created", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		p, err := NewPaymentRepo(mt.Client.Database("booking_test")).CreatePayment(context.Background(), req)
+		require.NoError(mt, err)
+		require.Equal(mt, models.PaymentPending, p.Status)
+		require.Equal(mt, 55.0, p.Amount)
+	})
+
+	// A redelivered request loses the race on the unique booking_id_active index.
+	mt.Run("ActivePayment", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Code:    11000,
+			Message: "E11000 duplicate key error collection: booking_test.Payments index: booking_id_active",
+		}))
+
+		_, err := NewPaymentRepo(mt.Client.Database("booking_test")).CreatePayment(context.Background(), req)
+		require.ErrorIs(mt, err, storage.ErrActivePayment)
+	})
+}
+
+func TestAddRefund(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	id := primitive.NewObjectID()
+	change := &models.PaymentChange{
+		FromStatus: models.PaymentPartiallyRefunded,
+		ToStatus:   models.PaymentRefunded,
+		Source:     "api",
+		Reason:     "late arrival",
+	}
+
+	// findAndModifyFilter returns the filter of the last findAndModify command.
+	findAndModifyFilter := func(mt *mtest.T) bson.Raw {
+		var filter bson.Raw
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName == "findAndModify" {
+				filter = e.Command.Lookup("query").Document()
+			}
+		}
+		require.NotNil(mt, filter)
+		return filter
+	}
+
+	tests := []struct {
+		name     string
+		refunded float64
+		value    interface{}
+		errText  string
+		check    func(mt *mtest.T, filter bson.Raw)
+	}{
+		{
+			name:     "Reserved",
+			refunded: 20,
+			value: bson.D{
+				{Key: "_id", Value: id},
+				{Key: "amount", Value: 55.0},
+				{Key: "status", Value: models.PaymentRefunded},
+				{Key: "refunded_amount", Value: 55.0},
+			},
+			check: func(mt *mtest.T, filter bson.Raw) {
+				require.Equal(mt, models.PaymentPartiallyRefunded, filter.Lookup("status").StringValue())
+				require.Equal(mt, 20.0, filter.Lookup("refunded_amount").Double(), "only applies to the amount the refund was checked against")
+			},
+		},
+		{
+			name:  "FirstRefundOfLegacyPayment",
+			value: bson.D{{Key: "_id", Value: id}, {Key: "status", Value: models.PaymentRefunded}},
+			check: func(mt *mtest.T, filter bson.Raw) {
+				_, err := filter.LookupErr("refunded_amount")
+				require.Error(mt, err)
+				require.NotEmpty(mt, filter.Lookup("$or").Array())
+			},
+		},
+		{
+			name:     "ChangedConcurrently",
+			refunded: 20,
+			errText:  "payment not found or it has changed concurrently",
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: tt.value}})
+
+			p, err := NewPaymentRepo(mt.Client.Database("booking_test")).AddRefund(context.Background(), id.Hex(), tt.refunded, 35, change)
+			if tt.errText != "" {
+				require.EqualError(mt, err, tt.errText)
+				return
+			}
+			require.NoError(mt, err)
+			require.Equal(mt, models.PaymentRefunded, p.Status)
+			tt.check(mt, findAndModifyFilter(mt))
+		})
+	}
+}
+
+func TestReleaseRefund(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	id := primitive.NewObjectID()
+
+	mt.Run("Released", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: id},
+			{Key: "amount", Value: 55.0},
+			{Key: "status", Value: models.PaymentPartiallyRefunded},
+			{Key: "refunded_amount", Value: 20.0},
+		}}})
+
+		p, err := NewPaymentRepo(mt.Client.Database("booking_test")).ReleaseRefund(context.Background(), id.Hex(), 10, "api", "refund rejected", "2030-01-07T10:00:00Z")
+		require.NoError(mt, err)
+		require.Equal(mt, models.PaymentPartiallyRefunded, p.Status)
+		require.Equal(mt, 20.0, p.RefundedAmount)
+	})
+
+	// Releasing more than is reserved would refund money that was never returned.
+	mt.Run("NotReserved", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		_, err := NewPaymentRepo(mt.Client.Database("booking_test")).ReleaseRefund(context.Background(), id.Hex(), 10, "api", "refund rejected", "2030-01-07T10:00:00Z")
+		require.EqualError(mt, err, "payment not found or refund is not reserved")
+
+		var filter bson.Raw
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName == "findAndModify" {
+				filter = e.Command.Lookup("query").Document()
+			}
+		}
+		require.Equal(mt, 10.0, filter.Lookup("refunded_amount", "$gte").Double())
+	})
+}
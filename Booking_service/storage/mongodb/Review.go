@@ -63,13 +63,25 @@ func (r *ReviewRepo) Create(ctx context.Context, req *pb.CreateReviewRequest) (*
 
 // GetByID retrieves a review by its ID
 func (r *ReviewRepo) GetByID(id *pb.IdRequest) (*pb.ReviewResponse, error) {
-	var review *pb.ReviewResponse
+	var review models.Review
 	Id, err := primitive.ObjectIDFromHex(id.Id)
 	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&review)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("review not found")
+		}
 		return nil, err
 	}
-	return review, nil
+	return &pb.ReviewResponse{
+		Id:         review.ID.Hex(),
+		BookingId:  review.BookingID,
+		UserId:     review.UserID,
+		ProviderId: review.ProviderID,
+		Rating:     review.Rating,
+		Comment:    review.Comment,
+		CreatedAt:  review.CreatedAt,
+		UpdatedAt:  review.UpdatedAt,
+	}, nil
 }
 
 // GetAll retrieves all reviews from the database
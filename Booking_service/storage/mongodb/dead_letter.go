@@ -0,0 +1,128 @@
+package mongodb
+
+import (
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeadLetterRepo struct {
+	col *mongo.Collection
+}
+
+func NewDeadLetterRepo(db *mongo.Database) storage.IDeadLetterStorage {
+	return &DeadLetterRepo{col: db.Collection("dead_letters")}
+}
+
+func (r *DeadLetterRepo) Save(ctx context.Context, dl *models.DeadLetter) (string, error) {
+	res, err := r.col.InsertOne(ctx, dl)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to save dead letter")
+	}
+
+	id, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", errors.New("id conversion failed")
+	}
+	return id.Hex(), nil
+}
+
+func (r *DeadLetterRepo) Get(ctx context.Context, id string) (*models.DeadLetter, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid dead letter ID")
+	}
+
+	var dl models.DeadLetter
+	err = r.col.FindOne(ctx, bson.M{"_id": objID}).Decode(&dl)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("dead letter not found")
+		}
+		return nil, errors.Wrap(err, "failed to get dead letter")
+	}
+	return &dl, nil
+}
+
+// List returns dead letters, newest first, optionally narrowed to one queue and status.
+func (r *DeadLetterRepo) List(ctx context.Context, queue, status string, page, limit int32) ([]*models.DeadLetter, error) {
+	filter := bson.M{}
+	if queue != "" {
+		filter["queue"] = queue
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.M{"_id": -1})
+	if page > 0 && limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list dead letters")
+	}
+
+	var dls []*models.DeadLetter
+	if err := cur.All(ctx, &dls); err != nil {
+		return nil, errors.Wrap(err, "failed to decode dead letters")
+	}
+	return dls, nil
+}
+
+// SetStatus moves the dead letter from status "from" to "to". It fails when the dead
+// letter is no longer in "from", so a message cannot be replayed twice.
+func (r *DeadLetterRepo) SetStatus(ctx context.Context, id, from, to, at string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.Wrap(err, "invalid dead letter ID")
+	}
+
+	set := bson.M{"status": to, "replayed_at": at}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": objID, "status": from}, bson.M{"$set": set})
+	if err != nil {
+		return errors.Wrap(err, "failed to update dead letter")
+	}
+	if res.MatchedCount == 0 {
+		return errors.Errorf("dead letter not found or not %s", from)
+	}
+	return nil
+}
+
+func (r *DeadLetterRepo) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.Wrap(err, "invalid dead letter ID")
+	}
+
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return errors.Wrap(err, "failed to delete dead letter")
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("dead letter not found")
+	}
+	return nil
+}
+
+// Purge deletes every dead letter of the queue, or of all queues when queue is empty.
+func (r *DeadLetterRepo) Purge(ctx context.Context, queue string) (int64, error) {
+	filter := bson.M{}
+	if queue != "" {
+		filter["queue"] = queue
+	}
+
+	res, err := r.col.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge dead letters")
+	}
+	return res.DeletedCount, nil
+}
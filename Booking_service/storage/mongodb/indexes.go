@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"Booking_Service/models"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// geoCollections hold a "location" GeoJSON point that is searched by distance.
+var geoCollections = []string{"providers", "bookings"}
+
+// ensureGeoIndexes converts locations saved in the old {latitude, longitude} shape
+// to GeoJSON points and makes sure every geo collection has a 2dsphere index.
+func ensureGeoIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, name := range geoCollections {
+		col := db.Collection(name)
+
+		_, err := col.UpdateMany(ctx,
+			bson.M{"location.latitude": bson.M{"$exists": true}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"location": bson.M{
+					"type":        "Point",
+					"coordinates": bson.A{"$location.longitude", "$location.latitude"},
+				},
+			}}}},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate %s locations", name)
+		}
+
+		_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "location", Value: "2dsphere"}},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s location index", name)
+		}
+	}
+
+	return nil
+}
+
+// ensureOperationIndexes lets MongoDB drop operation results once they expire.
+func ensureOperationIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("operations").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create operations expiry index")
+	}
+	return nil
+}
+
+// ensureProviderStatus approves providers registered before the approval workflow,
+// which were already taking bookings, and indexes the application status. Providers
+// registered since then start out submitted.
+func ensureProviderStatus(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection("providers")
+
+	_, err := col.UpdateMany(ctx,
+		bson.M{"status": bson.M{"$exists": false}},
+		bson.M{
+			"$set": bson.M{"status": models.ProviderApproved},
+			"$push": bson.M{"status_history": models.StatusChange{
+				ToStatus:  models.ProviderApproved,
+				ChangedBy: "system",
+				Reason:    "registered before provider approval was required",
+				ChangedAt: time.Now().Format(time.RFC3339),
+			}},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set provider status")
+	}
+
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create provider status index")
+	}
+	return nil
+}
+
+// ensureOrganizationIndexes indexes the bookings and payments billed to an
+// organization so the organization listings do not scan the collections.
+func ensureOrganizationIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, name := range []string{"bookings", "Payments"} {
+		_, err := db.Collection(name).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "organization_id", Value: 1}},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s organization index", name)
+		}
+	}
+	return nil
+}
+
+// activePaymentStatuses are the payment statuses that hold or may still take the
+// customer's money. A booking has at most one payment in them.
+var activePaymentStatuses = bson.A{
+	models.PaymentPending,
+	models.PaymentRequiresAction,
+	models.PaymentAuthorized,
+	models.PaymentCaptured,
+	models.PaymentPartiallyRefunded,
+	models.PaymentRefunded,
+}
+
+// ensurePaymentIndexes indexes the acquirer's transaction IDs, which webhook events
+// use to find their payment, and keeps a booking from being charged twice. Failed,
+// declined and voided payments do not count, so the customer can try again.
+func ensurePaymentIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("Payments").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "transaction_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "booking_id", Value: 1}},
+			Options: options.Index().
+				SetName("booking_id_active").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": bson.M{"$in": activePaymentStatuses}}),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create payment indexes")
+	}
+	return nil
+}
+
+// ensureRefundIndexes indexes refunds by the payment they belong to.
+func ensureRefundIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("refunds").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "payment_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create refund payment index")
+	}
+	return nil
+}
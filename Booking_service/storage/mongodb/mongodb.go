@@ -31,8 +31,28 @@ func ConnectDB() (storage.IStorage, error) {
 		return nil, err
 	}
 
+	db := client.Database(config.Load().MongoDB_NAME)
+	if err := ensureGeoIndexes(context.Background(), db); err != nil {
+		return nil, err
+	}
+	if err := ensureOperationIndexes(context.Background(), db); err != nil {
+		return nil, err
+	}
+	if err := ensureProviderStatus(context.Background(), db); err != nil {
+		return nil, err
+	}
+	if err := ensureOrganizationIndexes(context.Background(), db); err != nil {
+		return nil, err
+	}
+	if err := ensurePaymentIndexes(context.Background(), db); err != nil {
+		return nil, err
+	}
+	if err := ensureRefundIndexes(context.Background(), db); err != nil {
+		return nil, err
+	}
+
 	strg := Storage{
-		mongo: client.Database(config.Load().MongoDB_NAME),
+		mongo: db,
 		redis: redisDB.ConnectRedis(),
 	}
 
@@ -69,3 +89,11 @@ func (s Storage) Search() storage.ISearchStorage {
 }
 
 func (s Storage) Notification() storage.INotificationStorage { return NewNotificationRepo(s.mongo) }
+
+func (s Storage) Operation() storage.IOperationStorage { return NewOperationRepo(s.mongo) }
+
+func (s Storage) DeadLetter() storage.IDeadLetterStorage { return NewDeadLetterRepo(s.mongo) }
+
+func (s Storage) Pricing() storage.IPricingStorage { return NewPricingRepo(s.mongo) }
+
+func (s Storage) Refund() storage.IRefundStorage { return NewRefundRepo(s.mongo) }
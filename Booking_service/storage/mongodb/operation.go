@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OperationRepo struct {
+	col *mongo.Collection
+}
+
+func NewOperationRepo(db *mongo.Database) storage.IOperationStorage {
+	return &OperationRepo{col: db.Collection("operations")}
+}
+
+// Save stores the operation, replacing an earlier result recorded under the same ID.
+func (r *OperationRepo) Save(ctx context.Context, op *models.Operation) error {
+	_, err := r.col.ReplaceOne(ctx, bson.M{"_id": op.ID}, op, options.Replace().SetUpsert(true))
+	if err != nil {
+		return errors.Wrap(err, "failed to save operation")
+	}
+	return nil
+}
+
+func (r *OperationRepo) Get(ctx context.Context, id string) (*models.Operation, error) {
+	var op models.Operation
+	err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&op)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("operation not found")
+		}
+		return nil, errors.Wrap(err, "failed to get operation")
+	}
+	return &op, nil
+}
@@ -0,0 +1,84 @@
+package mongodb
+
+import (
+	"Booking_Service/models"
+	"Booking_Service/pkg/pricing"
+	"Booking_Service/storage"
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PricingRepo struct {
+	col *mongo.Collection
+}
+
+func NewPricingRepo(db *mongo.Database) storage.IPricingStorage {
+	return &PricingRepo{col: db.Collection("pricing_rules")}
+}
+
+// Get returns the rules stored for the provider, or the platform defaults when
+// providerID is empty.
+func (r *PricingRepo) Get(ctx context.Context, providerID string) (*models.PricingRules, error) {
+	var rules models.PricingRules
+	err := r.col.FindOne(ctx, bson.M{"provider_id": providerID}).Decode(&rules)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, storage.ErrPricingRulesNotFound
+		}
+		return nil, errors.Wrap(err, "failed to find pricing rules")
+	}
+	return &rules, nil
+}
+
+// Set replaces the rules stored under rules.ProviderID.
+func (r *PricingRepo) Set(ctx context.Context, rules *models.PricingRules) error {
+	_, err := r.col.ReplaceOne(ctx, bson.M{"provider_id": rules.ProviderID}, rules, options.Replace().SetUpsert(true))
+	if err != nil {
+		return errors.Wrap(err, "failed to save pricing rules")
+	}
+	return nil
+}
+
+func (r *PricingRepo) Delete(ctx context.Context, providerID string) error {
+	res, err := r.col.DeleteOne(ctx, bson.M{"provider_id": providerID})
+	if err != nil {
+		return errors.Wrap(err, "failed to delete pricing rules")
+	}
+	if res.DeletedCount == 0 {
+		return storage.ErrPricingRulesNotFound
+	}
+	return nil
+}
+
+// Effective returns the rules that price the provider's bookings: the platform
+// defaults merged with the provider's own rules, both read in one query.
+func (r *PricingRepo) Effective(ctx context.Context, providerID string) (*models.PricingRules, error) {
+	cur, err := r.col.Find(ctx, bson.M{"provider_id": bson.M{"$in": []string{"", providerID}}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find pricing rules")
+	}
+	defer cur.Close(ctx)
+
+	defaults := pricing.Defaults()
+	var own *models.PricingRules
+	for cur.Next(ctx) {
+		var rules models.PricingRules
+		if err := cur.Decode(&rules); err != nil {
+			return nil, errors.Wrap(err, "failed to decode pricing rules")
+		}
+		if rules.ProviderID == "" {
+			defaults = &rules
+		} else {
+			own = &rules
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return pricing.Merge(defaults, own), nil
+}
@@ -5,6 +5,7 @@ import (
 	"Booking_Service/models"
 	"Booking_Service/storage"
 	"context"
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -23,6 +24,7 @@ func NewProviderRepo(db *mongo.Database) storage.IProviderStorage {
 }
 
 func (r *ProviderRepo) RegisterProvider(ctx context.Context, req *pb.RegisterProviderRequest) (*pb.ProviderResponse, error) {
+	location := models.NewGeoPoint(req.GetLocation().GetLatitude(), req.GetLocation().GetLongitude())
 	provider := &models.Provider{
 		UserID:        req.UserId,
 		CompanyName:   req.CompanyName,
@@ -30,31 +32,31 @@ func (r *ProviderRepo) RegisterProvider(ctx context.Context, req *pb.RegisterPro
 		Services:      req.Services,
 		Availability:  req.Availability,
 		AverageRating: req.AverageRating,
-		Location:      &models.GeoPoint{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
-		CreatedAt:     time.Now().Format(time.RFC3339),
-		UpdatedAt:     time.Now().Format(time.RFC3339),
+		Location:      &location,
+		Schedule:      scheduleFromProto(req.Schedule),
+		Holidays:      req.Holidays,
+		Timezone:      req.Timezone,
+		Status:        models.ProviderSubmitted,
+		Documents:     []models.ProviderDocument{},
+		StatusHistory: []models.StatusChange{{
+			ToStatus:  models.ProviderSubmitted,
+			ChangedBy: req.UserId,
+			ChangedAt: time.Now().Format(time.RFC3339),
+		}},
+		CreatedAt: time.Now().Format(time.RFC3339),
+		UpdatedAt: time.Now().Format(time.RFC3339),
 	}
 	res, err := r.collection.InsertOne(ctx, provider)
 	if err != nil {
 		return nil, err
 	}
-	id := res.InsertedID.(primitive.ObjectID)
+	provider.ID = res.InsertedID.(primitive.ObjectID)
 
-	return &pb.ProviderResponse{
-		Id:            id.Hex(),
-		UserId:        provider.UserID,
-		CompanyName:   provider.CompanyName,
-		Description:   provider.Description,
-		Services:      provider.Services,
-		AverageRating: provider.AverageRating,
-		Location:      req.Location,
-		CreatedAt:     provider.CreatedAt,
-		UpdatedAt:     provider.UpdatedAt,
-	}, nil
+	return providerToResponse(provider), nil
 }
 
 func (r *ProviderRepo) GetProvider(ctx context.Context, id *pb.IdRequest) (*pb.ProviderResponse, error) {
-	var provider models.Provider1
+	var provider models.Provider
 	ID, err := primitive.ObjectIDFromHex(id.Id)
 	if err != nil {
 		return nil, err
@@ -65,16 +67,26 @@ func (r *ProviderRepo) GetProvider(ctx context.Context, id *pb.IdRequest) (*pb.P
 		return nil, err
 	}
 
-	return &pb.ProviderResponse{
-		Id:            provider.ID.Hex(),
-		UserId:        provider.UserID,
-		CompanyName:   provider.CompanyName,
-		Description:   provider.Description,
-		Services:      provider.Services,
-		AverageRating: provider.AverageRating,
-		CreatedAt:     provider.CreatedAt,
-		UpdatedAt:     provider.UpdatedAt,
-	}, nil
+	return providerToResponse(&provider), nil
+}
+
+// GetByID returns the stored provider document, including its working schedule.
+func (r *ProviderRepo) GetByID(ctx context.Context, id string) (*models.Provider, error) {
+	ID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid provider ID")
+	}
+
+	var provider models.Provider
+	err = r.collection.FindOne(ctx, bson.M{"_id": ID}).Decode(&provider)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, storage.ErrProviderNotFound
+		}
+		return nil, errors.Wrap(err, "failed to find provider")
+	}
+
+	return &provider, nil
 }
 
 func (r *ProviderRepo) UpdateProvider(ctx context.Context, req *pb.UpdateProviderRequest) (*pb.ProviderResponse, error) {
@@ -90,7 +102,10 @@ func (r *ProviderRepo) UpdateProvider(ctx context.Context, req *pb.UpdateProvide
 			"services":       req.Services,
 			"availability":   req.Availability,
 			"average_rating": req.AverageRating,
-			"location":       req.Location,
+			"location":       models.NewGeoPoint(req.GetLocation().GetLatitude(), req.GetLocation().GetLongitude()),
+			"schedule":       scheduleFromProto(req.Schedule),
+			"holidays":       req.Holidays,
+			"timezone":       req.Timezone,
 			"updated_at":     time.Now().Format(time.RFC3339),
 		},
 	}
@@ -113,13 +128,28 @@ func (r *ProviderRepo) DeleteProvider(ctx context.Context, id *pb.IdRequest) err
 	return err
 }
 
+// ListProviders lists approved providers only; applications are listed by ListByStatus.
 func (r *ProviderRepo) ListProviders(ctx context.Context, req *pb.ListProvidersRequest) (*pb.ListProvidersResponse, error) {
-	var providers []*models.Provider
 	opts := options.Find().
 		SetSkip(int64((req.Page - 1) * req.Limit)).
 		SetLimit(int64(req.Limit))
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	return r.list(ctx, bson.M{"status": models.ProviderApproved}, opts)
+}
+
+// ListByStatus returns the providers in any of the given statuses, oldest application first.
+func (r *ProviderRepo) ListByStatus(ctx context.Context, statuses []string, page, limit int32) (*pb.ListProvidersResponse, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+
+	return r.list(ctx, bson.M{"status": bson.M{"$in": statuses}}, opts)
+}
+
+func (r *ProviderRepo) list(ctx context.Context, filter bson.M, opts *options.FindOptions) (*pb.ListProvidersResponse, error) {
+	var providers []*models.Provider
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -129,20 +159,152 @@ func (r *ProviderRepo) ListProviders(ctx context.Context, req *pb.ListProvidersR
 
 	var providerResponses []*pb.ProviderResponse
 	for _, provider := range providers {
-		providerResponses = append(providerResponses, &pb.ProviderResponse{
-			Id:            provider.ID.Hex(),
-			UserId:        provider.UserID,
-			CompanyName:   provider.CompanyName,
-			Description:   provider.Description,
-			Services:      provider.Services,
-			AverageRating: provider.AverageRating,
-			Location:      &pb.GeoPoint{Latitude: provider.Location.Latitude, Longitude: provider.Location.Longitude},
-			CreatedAt:     provider.CreatedAt,
-			UpdatedAt:     provider.UpdatedAt,
-		})
+		providerResponses = append(providerResponses, providerToResponse(provider))
 	}
 
 	return &pb.ListProvidersResponse{
 		Providers: providerResponses,
 	}, nil
 }
+
+// AddDocument attaches an uploaded document to the provider.
+func (r *ProviderRepo) AddDocument(ctx context.Context, id string, doc *models.ProviderDocument) error {
+	ID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.Wrap(err, "invalid provider ID")
+	}
+
+	update := bson.M{
+		"$push": bson.M{"documents": doc},
+		"$set":  bson.M{"updated_at": doc.UploadedAt},
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": ID}, update)
+	if err != nil {
+		return errors.Wrap(err, "failed to add provider document")
+	}
+	if res.MatchedCount == 0 {
+		return storage.ErrProviderNotFound
+	}
+	return nil
+}
+
+// UpdateStatus moves the provider application from status "from" to change.ToStatus
+// and appends the change to its history. Like bookings, the update only applies while
+// the provider is still in "from".
+func (r *ProviderRepo) UpdateStatus(ctx context.Context, id string, from string, change *models.StatusChange) (*pb.ProviderResponse, error) {
+	ID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid provider ID")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     change.ToStatus,
+			"updated_at": change.ChangedAt,
+		},
+		"$push": bson.M{"status_history": change},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var provider models.Provider
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": ID, "status": from}, update, opts).Decode(&provider)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("provider not found or its status has already changed")
+		}
+		return nil, errors.Wrap(err, "failed to update provider status")
+	}
+
+	return providerToResponse(&provider), nil
+}
+
+// ListIDsByUser returns the IDs of all provider profiles owned by the given user.
+func (r *ProviderRepo) ListIDsByUser(ctx context.Context, userID string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []string
+	for cursor.Next(ctx) {
+		var provider models.Provider
+		if err := cursor.Decode(&provider); err != nil {
+			return nil, err
+		}
+		ids = append(ids, provider.ID.Hex())
+	}
+
+	return ids, cursor.Err()
+}
+
+func providerToResponse(provider *models.Provider) *pb.ProviderResponse {
+	documents := make([]*pb.ProviderDocument, 0, len(provider.Documents))
+	for _, doc := range provider.Documents {
+		documents = append(documents, documentToProto(&doc))
+	}
+
+	history := make([]*pb.StatusChange, 0, len(provider.StatusHistory))
+	for _, change := range provider.StatusHistory {
+		history = append(history, &pb.StatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedBy:  change.ChangedBy,
+			Reason:     change.Reason,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+
+	return &pb.ProviderResponse{
+		Id:            provider.ID.Hex(),
+		UserId:        provider.UserID,
+		CompanyName:   provider.CompanyName,
+		Description:   provider.Description,
+		Services:      provider.Services,
+		AverageRating: provider.AverageRating,
+		Location:      geoPointToProto(provider.Location),
+		Schedule:      scheduleToProto(provider.Schedule),
+		Holidays:      provider.Holidays,
+		Timezone:      provider.Timezone,
+		Status:        provider.Status,
+		Documents:     documents,
+		StatusHistory: history,
+		CreatedAt:     provider.CreatedAt,
+		UpdatedAt:     provider.UpdatedAt,
+	}
+}
+
+func documentToProto(doc *models.ProviderDocument) *pb.ProviderDocument {
+	return &pb.ProviderDocument{
+		Id:          doc.ID,
+		Type:        doc.Type,
+		FileName:    doc.FileName,
+		ContentType: doc.ContentType,
+		Size:        doc.Size,
+		UploadedAt:  doc.UploadedAt,
+	}
+}
+
+func scheduleFromProto(hours []*pb.WorkingHours) []models.WorkingHours {
+	schedule := make([]models.WorkingHours, 0, len(hours))
+	for _, h := range hours {
+		schedule = append(schedule, models.WorkingHours{Weekday: h.Weekday, Start: h.Start, End: h.End})
+	}
+	return schedule
+}
+
+func scheduleToProto(hours []models.WorkingHours) []*pb.WorkingHours {
+	schedule := make([]*pb.WorkingHours, 0, len(hours))
+	for _, h := range hours {
+		schedule = append(schedule, &pb.WorkingHours{Weekday: h.Weekday, Start: h.Start, End: h.End})
+	}
+	return schedule
+}
+
+func geoPointToProto(p *models.GeoPoint) *pb.GeoPoint {
+	if p == nil {
+		return nil
+	}
+	return &pb.GeoPoint{Latitude: p.Latitude(), Longitude: p.Longitude()}
+}
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"Booking_Service/storage"
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RefundRepo struct {
+	col *mongo.Collection
+}
+
+func NewRefundRepo(db *mongo.Database) storage.IRefundStorage {
+	return &RefundRepo{col: db.Collection("refunds")}
+}
+
+func (r *RefundRepo) Create(ctx context.Context, refund *models.Refund) (*pb.RefundResponse, error) {
+	res, err := r.col.InsertOne(ctx, refund)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save refund")
+	}
+	refund.ID = res.InsertedID.(primitive.ObjectID)
+	return refundToResponse(refund), nil
+}
+
+// List returns the refunds of a payment, oldest first.
+func (r *RefundRepo) List(ctx context.Context, paymentID string) (*pb.ListRefundsResponse, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cur, err := r.col.Find(ctx, bson.M{"payment_id": paymentID}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list refunds")
+	}
+	defer cur.Close(ctx)
+
+	refunds := []*pb.RefundResponse{}
+	for cur.Next(ctx) {
+		var refund models.Refund
+		if err := cur.Decode(&refund); err != nil {
+			return nil, errors.Wrap(err, "failed to decode refund")
+		}
+		refunds = append(refunds, refundToResponse(&refund))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor error")
+	}
+
+	return &pb.ListRefundsResponse{Refunds: refunds}, nil
+}
+
+func refundToResponse(refund *models.Refund) *pb.RefundResponse {
+	return &pb.RefundResponse{
+		Id:            refund.ID.Hex(),
+		PaymentId:     refund.PaymentID,
+		BookingId:     refund.BookingID,
+		Amount:        refund.Amount,
+		Reason:        refund.Reason,
+		Status:        refund.Status,
+		FailureReason: refund.FailureReason,
+		RequestedBy:   refund.RequestedBy,
+		CreatedAt:     refund.CreatedAt,
+	}
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
 	"Booking_Service/storage"
 	"context"
 	"fmt"
@@ -20,12 +21,15 @@ func NewSearchingRepo(db *mongo.Database) storage.ISearchStorage {
 	}
 }
 
+// providerHit is a provider document together with the distance added by $geoNear.
+type providerHit struct {
+	models.Provider `bson:",inline"`
+	Distance        float64 `bson:"distance"`
+}
+
 func (s *SearchingRepo) SearchProviders(ctx context.Context, req *pb.Filter) (*pb.ListProvidersResponses, error) {
 
-	filter := bson.M{}
-	if req.Location != "" {
-		filter["location"] = req.Location
-	}
+	filter := bson.M{"status": models.ProviderApproved}
 	if req.Rating > 0 {
 		filter["average_rating"] = bson.M{"$gte": req.Rating}
 	}
@@ -33,19 +37,39 @@ func (s *SearchingRepo) SearchProviders(ctx context.Context, req *pb.Filter) (*p
 		filter["company_name"] = req.CompanyName
 	}
 
-	opts := options.Find()
-	opts.SetSkip(int64(req.Page * req.Limit))
-	opts.SetLimit(int64(req.Limit))
+	var pipeline mongo.Pipeline
+	if req.Center != nil {
+		// $geoNear has to be the first stage and already returns the nearest providers first.
+		geoNear := bson.M{
+			"near":          models.NewGeoPoint(req.Center.Latitude, req.Center.Longitude),
+			"distanceField": "distance",
+			"spherical":     true,
+			"query":         filter,
+		}
+		if req.RadiusKm > 0 {
+			geoNear["maxDistance"] = req.RadiusKm * 1000
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$geoNear", Value: geoNear}})
+	} else {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
+	}
 
 	if req.ByRating {
-		opts.SetSort(bson.D{{Key: "average_rating", Value: -1}})
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "average_rating", Value: -1}}}})
 	}
 
 	if req.NumberOfComments {
-		opts.SetSort(bson.D{{Key: "number_of_comments", Value: -1}})
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "number_of_comments", Value: -1}}}})
 	}
 
-	cursor, err := s.collection.Find(ctx, filter, opts)
+	if req.Limit > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$skip", Value: int64(req.Page * req.Limit)}},
+			bson.D{{Key: "$limit", Value: int64(req.Limit)}},
+		)
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -53,15 +77,25 @@ func (s *SearchingRepo) SearchProviders(ctx context.Context, req *pb.Filter) (*p
 
 	var providers []*pb.Providers
 	for cursor.Next(ctx) {
-		var provider pb.Providers
-		if err := cursor.Decode(&provider); err != nil {
+		var hit providerHit
+		if err := cursor.Decode(&hit); err != nil {
 			fmt.Println(err)
 			return nil, err
 		}
-		providers = append(providers, &provider)
+		provider := &pb.Providers{
+			Id:            hit.ID.Hex(),
+			AverageRating: float32(hit.AverageRating),
+			CompanyName:   hit.CompanyName,
+			Availability:  hit.Availability,
+			Point:         geoPointToProto(hit.Location),
+		}
+		if req.Center != nil {
+			provider.DistanceKm = hit.Distance / 1000
+		}
+		providers = append(providers, provider)
 	}
 
-	return &pb.ListProvidersResponses{Provideres: providers}, nil
+	return &pb.ListProvidersResponses{Provideres: providers}, cursor.Err()
 }
 
 func (s *SearchingRepo) SearchServices(ctx context.Context, req *pb.Filter) (*pb.ListServicesResponses, error) {
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	pb "Booking_Service/genproto/booking"
+	"Booking_Service/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestSearchProvidersNearby(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("NearestFirst", func(mt *mtest.T) {
+		near := primitive.NewObjectID()
+		far := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "booking_test.providers", mtest.FirstBatch,
+				bson.D{
+					{Key: "_id", Value: near},
+					{Key: "company_name", Value: "Near Wash"},
+					{Key: "location", Value: models.NewGeoPoint(41.3111, 69.2797)},
+					{Key: "distance", Value: 1500.0},
+				},
+				bson.D{
+					{Key: "_id", Value: far},
+					{Key: "company_name", Value: "Far Wash"},
+					{Key: "location", Value: models.NewGeoPoint(41.35, 69.3)},
+					{Key: "distance", Value: 4200.0},
+				},
+			),
+		)
+
+		repo := NewSearchingRepo(mt.Client.Database("booking_test"))
+		resp, err := repo.SearchProviders(context.Background(), &pb.Filter{
+			Center:   &pb.GeoPoint{Latitude: 41.3, Longitude: 69.27},
+			RadiusKm: 5,
+			Limit:    10,
+		})
+		require.NoError(mt, err)
+
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		first, err := pipeline.Index(0).Value().Document().IndexErr(0)
+		require.NoError(mt, err)
+		require.Equal(mt, "$geoNear", first.Key())
+		require.Equal(mt, 5000.0, first.Value().Document().Lookup("maxDistance").Double())
+
+		require.Len(mt, resp.Provideres, 2)
+		require.Equal(mt, near.Hex(), resp.Provideres[0].Id)
+		require.InDelta(mt, 1.5, resp.Provideres[0].DistanceKm, 1e-9)
+		require.Equal(mt, 41.3111, resp.Provideres[0].Point.Latitude)
+		require.Equal(mt, 69.2797, resp.Provideres[0].Point.Longitude)
+	})
+}
@@ -3,12 +3,37 @@ package storage
 import (
 	pb "Booking_Service/genproto/booking"
 	"Booking_Service/models"
+	"Booking_Service/pkg/slots"
 )
 
 import (
 	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrProviderNotApproved is returned when a provider that has not been approved is booked.
+var ErrProviderNotApproved = errors.New("provider is not approved")
+
+// Errors returned when a booking, service or provider does not exist.
+var (
+	ErrBookingNotFound  = errors.New("booking not found")
+	ErrServiceNotFound  = errors.New("service not found")
+	ErrProviderNotFound = errors.New("provider not found")
 )
 
+// ErrBookingLocked is returned when a booking is edited after the provider has set
+// out for it or once it has ended.
+var ErrBookingLocked = errors.New("booking can no longer be changed")
+
+// ErrActivePayment is returned when a payment is created for a booking that already
+// has one that has not failed, been declined or been voided.
+var ErrActivePayment = errors.New("booking already has an active payment")
+
+// ErrPricingRulesNotFound is returned when no pricing rules are stored for a provider.
+var ErrPricingRulesNotFound = errors.New("pricing rules not found")
+
 type IStorage interface {
 	Booking() IBookingStorage
 	Management() IManagementStorage
@@ -17,16 +42,26 @@ type IStorage interface {
 	Payment() IPaymentStorage
 	Provider() IProviderStorage
 	Notification() INotificationStorage
+	Operation() IOperationStorage
+	DeadLetter() IDeadLetterStorage
+	Pricing() IPricingStorage
+	Refund() IRefundStorage
 	Close()
 }
 
 type IBookingStorage interface {
-	Add(ctx context.Context, req *pb.CreateBookingRequest) (*pb.BookingResponse, error)
+	Add(ctx context.Context, req *pb.CreateBookingRequest, quoted *models.PriceBreakdown) (*pb.BookingResponse, error)
+	Quote(ctx context.Context, req *pb.CreateBookingRequest) (*models.PriceBreakdown, error)
 	Read(ctx context.Context, req *pb.IdRequest) (*pb.BookingResponse, error)
 	Update(ctx context.Context, req *pb.UpdateBookingRequest) (*pb.BookingResponse, error)
-	UpdateStatus(ctx context.Context, id string, status string) error
-	Cancel(ctx context.Context, id *pb.IdRequest) (*pb.BookingResponse, error)
+	UpdateStatus(ctx context.Context, id string, from string, change *models.StatusChange) (*pb.BookingResponse, error)
+	Cancel(ctx context.Context, id string, from string, fee float64, change *models.StatusChange) (*pb.BookingResponse, error)
+	SetPaymentStatus(ctx context.Context, id string, status string) error
+	SetCancellationFee(ctx context.Context, id string, fee float64, override *models.FeeOverride) (*pb.BookingResponse, error)
 	List(ctx context.Context, req *pb.ListBookingsRequest) (*pb.ListBookingsResponse, error)
+	ListByProviders(ctx context.Context, providerIDs []string, status string, page, limit int32) (*pb.ListBookingsResponse, error)
+	ListCreatedBefore(ctx context.Context, status string, before time.Time) ([]*pb.BookingResponse, error)
+	ListBusy(ctx context.Context, providerID string, period slots.Interval) ([]slots.Interval, error)
 }
 
 type IManagementStorage interface {
@@ -42,6 +77,16 @@ type IPaymentStorage interface {
 	CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.PaymentResponse, error)
 	GetPayment(ctx context.Context, id *pb.IdRequest) (*pb.PaymentResponse, error)
 	ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error)
+	GetByTransaction(ctx context.Context, transactionID string) (*pb.PaymentResponse, error)
+	UpdateStatus(ctx context.Context, id string, from string, transactionID string, change *models.PaymentChange) (*pb.PaymentResponse, error)
+	ListByBooking(ctx context.Context, bookingID string) ([]*pb.PaymentResponse, error)
+	AddRefund(ctx context.Context, id string, refunded float64, amount float64, change *models.PaymentChange) (*pb.PaymentResponse, error)
+	ReleaseRefund(ctx context.Context, id string, amount float64, source, reason, at string) (*pb.PaymentResponse, error)
+}
+
+type IRefundStorage interface {
+	Create(ctx context.Context, refund *models.Refund) (*pb.RefundResponse, error)
+	List(ctx context.Context, paymentID string) (*pb.ListRefundsResponse, error)
 }
 
 type IReviewStorage interface {
@@ -58,6 +103,11 @@ type IProviderStorage interface {
 	ListProviders(ctx context.Context, req *pb.ListProvidersRequest) (*pb.ListProvidersResponse, error)
 	UpdateProvider(ctx context.Context, req *pb.UpdateProviderRequest) (*pb.ProviderResponse, error)
 	DeleteProvider(ctx context.Context, req *pb.IdRequest) error
+	ListIDsByUser(ctx context.Context, userID string) ([]string, error)
+	GetByID(ctx context.Context, id string) (*models.Provider, error)
+	AddDocument(ctx context.Context, id string, doc *models.ProviderDocument) error
+	UpdateStatus(ctx context.Context, id string, from string, change *models.StatusChange) (*pb.ProviderResponse, error)
+	ListByStatus(ctx context.Context, statuses []string, page, limit int32) (*pb.ListProvidersResponse, error)
 }
 
 type ISearchStorage interface {
@@ -68,3 +118,24 @@ type INotificationStorage interface {
 	Create(ctx context.Context, req *models.NewNotification) (string, error)
 	Get(ctx context.Context, id string) (*models.Notification, error)
 }
+
+type IOperationStorage interface {
+	Save(ctx context.Context, op *models.Operation) error
+	Get(ctx context.Context, id string) (*models.Operation, error)
+}
+
+type IDeadLetterStorage interface {
+	Save(ctx context.Context, dl *models.DeadLetter) (string, error)
+	Get(ctx context.Context, id string) (*models.DeadLetter, error)
+	List(ctx context.Context, queue, status string, page, limit int32) ([]*models.DeadLetter, error)
+	SetStatus(ctx context.Context, id, from, to, at string) error
+	Delete(ctx context.Context, id string) error
+	Purge(ctx context.Context, queue string) (int64, error)
+}
+
+type IPricingStorage interface {
+	Get(ctx context.Context, providerID string) (*models.PricingRules, error)
+	Set(ctx context.Context, rules *models.PricingRules) error
+	Delete(ctx context.Context, providerID string) error
+	Effective(ctx context.Context, providerID string) (*models.PricingRules, error)
+}
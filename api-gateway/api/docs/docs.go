@@ -66,42 +66,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/payments/{id}": {
-            "get": {
+        "/admin/bookings/{id}/cancellation-fee": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves payment details by payment ID",
+                "description": "Lowers or waives the fee charged for canceling a booking, e.g. to settle a dispute. The difference is refunded and the original fee kept on the booking",
                 "tags": [
-                    "payments"
+                    "bookings"
                 ],
-                "summary": "Retrieves a payment by ID",
+                "summary": "Overrides the cancellation fee of a booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Payment ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New fee and reason",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CancellationFeeOverride"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.PaymentResponse"
+                            "$ref": "#/definitions/booking.BookingResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid payment ID",
+                        "description": "Invalid fee or missing reason",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Payment not found",
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is not canceled",
                         "schema": {
                             "type": "string"
                         }
@@ -115,42 +130,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/providers/{id}": {
-            "get": {
+        "/admin/bookings/{id}/confirm": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves provider details by provider ID",
+                "description": "Moves a pending booking to confirmed",
                 "tags": [
-                    "providers"
+                    "bookings"
                 ],
-                "summary": "Retrieves a provider by ID",
+                "summary": "Confirms a booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.BookingTransition"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ProviderResponse"
+                            "$ref": "#/definitions/booking.BookingResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid provider ID",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Provider not found",
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
                         "schema": {
                             "type": "string"
                         }
@@ -164,42 +193,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/reviews/{id}": {
-            "get": {
+        "/admin/bookings/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves review details by review ID",
+                "description": "Moves a pending booking to rejected",
                 "tags": [
-                    "reviews"
+                    "bookings"
                 ],
-                "summary": "Retrieves a review by ID",
+                "summary": "Rejects a booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Review ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.BookingTransition"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ReviewResponse"
+                            "$ref": "#/definitions/booking.BookingResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid review ID",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Review not found",
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
                         "schema": {
                             "type": "string"
                         }
@@ -213,42 +256,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/services/{id}": {
+        "/admin/dead-letters": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves service details by service ID",
+                "description": "Lists broker messages that failed for good, newest first",
                 "tags": [
-                    "services"
+                    "dead-letters"
                 ],
-                "summary": "Retrieves a service by ID",
+                "summary": "Lists dead-lettered messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Original queue, e.g. create_booking",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "dead or replayed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ServiceResponse"
+                            "$ref": "#/definitions/booking.ListDeadLettersResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid service ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Service not found",
+                        "description": "Invalid pagination",
                         "schema": {
                             "type": "string"
                         }
@@ -260,42 +314,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/user": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates user info by ID",
+                "description": "Drops every dead-lettered message of a queue, or of all queues when no queue is given",
                 "tags": [
-                    "admin"
+                    "dead-letters"
                 ],
-                "summary": "Updates user",
+                "summary": "Purges dead-lettered messages",
                 "parameters": [
                     {
-                        "description": "New user data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/users.UpdateProfileRequest"
-                        }
+                        "type": "string",
+                        "description": "Original queue, e.g. create_booking",
+                        "name": "queue",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/users.UserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user id",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/booking.PurgeDeadLettersResponse"
                         }
                     },
                     "500": {
@@ -307,22 +350,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/user/{id}": {
+        "/admin/dead-letters/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves user info by ID",
+                "description": "Returns the body, last error and attempt count of a dead-lettered message",
                 "tags": [
-                    "admin"
+                    "dead-letters"
                 ],
-                "summary": "Gets user",
+                "summary": "Inspects a dead-lettered message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Dead letter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -332,11 +375,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/users.GetProfileResponse"
+                            "$ref": "#/definitions/booking.DeadLetter"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user id",
+                    "404": {
+                        "description": "Dead letter not found",
                         "schema": {
                             "type": "string"
                         }
@@ -355,15 +398,15 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes user info by ID",
+                "description": "Drops a single dead-lettered message for good",
                 "tags": [
-                    "admin"
+                    "dead-letters"
                 ],
-                "summary": "Deletes user",
+                "summary": "Deletes a dead-lettered message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Dead letter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -371,13 +414,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Dead letter deleted",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user id",
+                    "404": {
+                        "description": "Dead letter not found",
                         "schema": {
                             "type": "string"
                         }
@@ -391,53 +434,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
-            "get": {
+        "/admin/dead-letters/{id}/replay": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves users from the database by filtering",
+                "description": "Publishes the message to its original queue again with a fresh retry budget",
                 "tags": [
-                    "admin"
+                    "dead-letters"
                 ],
-                "summary": "Fetches users",
+                "summary": "Replays a dead-lettered message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Full name",
-                        "name": "full_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Role",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of users per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/users.UserResponses"
+                            "$ref": "#/definitions/booking.DeadLetter"
                         }
                     },
-                    "400": {
-                        "description": "Invalid pagination parameters",
+                    "404": {
+                        "description": "Dead letter not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Dead letter already replayed",
                         "schema": {
                             "type": "string"
                         }
@@ -451,38 +483,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/bookings": {
-            "post": {
+        "/admin/payments/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new booking and returns the booking details",
+                "description": "Retrieves payment details by payment ID",
                 "tags": [
-                    "bookings"
+                    "payments"
                 ],
-                "summary": "Creates a new booking",
+                "summary": "Retrieves a payment by ID",
                 "parameters": [
                     {
-                        "description": "Booking details",
-                        "name": "booking",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Booking"
-                        }
+                        "type": "string",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.BookingResponse"
+                            "$ref": "#/definitions/booking.PaymentResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid booking data",
+                        "description": "Invalid payment ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
                         "schema": {
                             "type": "string"
                         }
@@ -496,31 +532,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/bookings/all": {
+        "/admin/payments/{id}/refunds": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of bookings with pagination",
+                "description": "Returns every refund attempt of the payment, oldest first, including the ones the payment provider rejected",
                 "tags": [
-                    "bookings"
+                    "payments"
                 ],
-                "summary": "Lists bookings with pagination",
+                "summary": "Lists the refunds of a payment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -528,7 +557,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListBookingsResponse"
+                            "$ref": "#/definitions/booking.ListRefundsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -538,44 +579,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/customer/bookings/{id}": {
-            "get": {
-                "security": [
+            },
+            "post": {
+                "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves booking details by booking ID",
+                "description": "Returns part or all of a captured payment to the customer. An amount of 0 refunds everything not refunded yet. Canceled bookings are refunded automatically",
                 "tags": [
-                    "bookings"
+                    "payments"
                 ],
-                "summary": "Retrieves a booking by ID",
+                "summary": "Refunds a payment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
+                        "description": "Payment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Amount and reason of the refund",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.RefundPaymentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.BookingResponse"
+                            "$ref": "#/definitions/booking.RefundResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid booking ID",
+                        "description": "Invalid refund or payment is not refundable",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Booking not found",
+                        "description": "Payment not found",
                         "schema": {
                             "type": "string"
                         }
@@ -585,55 +633,33 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "502": {
+                        "description": "Payment provider rejected the refund",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/pricing": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates booking details by booking ID",
+                "description": "Returns the default pricing rules, including the booking fee and tax rate, that apply to every provider",
                 "tags": [
-                    "bookings"
-                ],
-                "summary": "Updates a booking by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated booking details",
-                        "name": "booking",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/booking.UpdateBookingRequest"
-                        }
-                    }
+                    "pricing"
                 ],
+                "summary": "Gets the platform pricing rules",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.BookingResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid booking data or ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Booking not found",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/booking.PricingRules"
                         }
                     },
                     "500": {
@@ -644,41 +670,37 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Cancels booking by booking ID",
+                "description": "Replaces the default pricing rules. Providers' own rules override everything but the booking fee and tax rate",
                 "tags": [
-                    "bookings"
+                    "pricing"
                 ],
-                "summary": "Cancels a booking by ID",
+                "summary": "Replaces the platform pricing rules",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Pricing rules",
+                        "name": "rules",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.PricingRules"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.BookingResponse"
+                            "$ref": "#/definitions/booking.PricingRules"
                         }
                     },
                     "400": {
-                        "description": "Invalid booking ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Booking not found",
+                        "description": "Invalid pricing rules",
                         "schema": {
                             "type": "string"
                         }
@@ -692,39 +714,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/payments": {
+        "/admin/provider-applications": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of payments with pagination",
+                "description": "Lists provider applications oldest first, by default the ones waiting for review (submitted and under_review)",
                 "tags": [
-                    "payments"
+                    "provider-applications"
                 ],
-                "summary": "Lists all payments with pagination",
+                "summary": "Lists provider applications",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "submitted, under_review, approved, rejected or suspended",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Page number",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "integer",
                         "description": "Page limit",
                         "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListPaymentsResponse"
+                            "$ref": "#/definitions/booking.ListProvidersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -734,44 +766,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/providers/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Processes a payment and returns the payment details",
+                "description": "Retrieves provider details by provider ID",
                 "tags": [
-                    "payments"
+                    "providers"
                 ],
-                "summary": "Creates a new payment",
+                "summary": "Retrieves a provider by ID",
                 "parameters": [
                     {
-                        "description": "Payment details",
-                        "name": "payment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/booking.CreatePaymentRequest"
-                        }
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.PaymentResponse"
+                            "$ref": "#/definitions/booking.ProviderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid payment data",
+                        "description": "Invalid provider ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Server error while processing payment",
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
@@ -779,42 +817,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/payments/{id}": {
+        "/admin/providers/{id}/documents/{document_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves payment details by payment ID",
+                "description": "Lets an admin read any document attached to a provider application",
+                "produces": [
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "payments"
+                    "provider-applications"
                 ],
-                "summary": "Retrieves a payment by ID",
+                "summary": "Downloads a document of a provider application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Payment ID",
+                        "description": "Provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Document file",
                         "schema": {
-                            "$ref": "#/definitions/booking.PaymentResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid payment ID",
-                        "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     },
                     "404": {
-                        "description": "Payment not found",
+                        "description": "Provider or document not found",
                         "schema": {
                             "type": "string"
                         }
@@ -828,27 +870,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/profile": {
+        "/admin/providers/{id}/pricing": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves user profile by ID",
+                "description": "Returns the pricing rules the provider set on top of the platform defaults",
                 "tags": [
-                    "user"
+                    "pricing"
+                ],
+                "summary": "Gets a provider's pricing rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Gets user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/users.GetProfileResponse"
+                            "$ref": "#/definitions/booking.PricingRules"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user id",
+                    "403": {
+                        "description": "Caller does not own the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
                         "schema": {
                             "type": "string"
                         }
@@ -867,19 +924,26 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates user info by ID",
+                "description": "Sets vehicle class multipliers, travel fee, time-of-day rules, the weekend multiplier and the cancellation policy of the provider. Sections left empty fall back to the platform defaults",
                 "tags": [
-                    "user"
+                    "pricing"
                 ],
-                "summary": "Updates user",
+                "summary": "Replaces a provider's pricing rules",
                 "parameters": [
                     {
-                        "description": "New user data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rules",
+                        "name": "rules",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UUser"
+                            "$ref": "#/definitions/booking.PricingRules"
                         }
                     }
                 ],
@@ -887,57 +951,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/users.UserResponseU"
+                            "$ref": "#/definitions/booking.PricingRules"
                         }
                     },
                     "400": {
-                        "description": "Invalid user id or data",
+                        "description": "Invalid pricing rules",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Server error while processing request",
+                    "403": {
+                        "description": "Caller does not own the provider",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/customer/providers": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a list of providers with pagination",
-                "tags": [
-                    "providers"
-                ],
-                "summary": "Lists all providers with pagination",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Provider not found",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListProvidersResponse"
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -947,20 +979,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/customer/providers/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves provider details by provider ID",
+                "description": "Drops the provider's own rules so its bookings are priced with the platform defaults",
                 "tags": [
-                    "providers"
+                    "pricing"
                 ],
-                "summary": "Retrieves a provider by ID",
+                "summary": "Removes a provider's pricing rules",
                 "parameters": [
                     {
                         "type": "string",
@@ -972,19 +1002,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Pricing rules deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/booking.ProviderResponse"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid provider ID",
+                    "403": {
+                        "description": "Caller does not own the provider",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Provider not found",
+                        "description": "Provider has no pricing rules",
                         "schema": {
                             "type": "string"
                         }
@@ -998,38 +1028,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/reviews": {
-            "get": {
+        "/admin/providers/{id}/review": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of reviews with pagination",
+                "description": "Moves a provider application to under_review, approved, rejected or suspended. Approval needs a business license and insurance document, rejection and suspension need a reason.",
                 "tags": [
-                    "reviews"
+                    "provider-applications"
                 ],
-                "summary": "Lists all reviews with pagination",
+                "summary": "Reviews a provider application",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Provider ID",
-                        "name": "provider_id",
-                        "in": "query",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "description": "Decision",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProviderReview"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid decision",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{id}": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query",
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves review details by review ID",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Retrieves a review by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1037,14 +1117,51 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListReviewsResponse"
+                            "$ref": "#/definitions/booking.ReviewResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Invalid review ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists all roles with the permissions granted to them",
+                "tags": [
+                    "rbac"
+                ],
+                "summary": "Lists roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RolePermissions"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Server error while processing request",
@@ -1060,37 +1177,43 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Submits a review for a booking and returns the review details",
+                "description": "Creates a role without permissions; grant them with /admin/roles/{role}/permissions",
                 "tags": [
-                    "reviews"
+                    "rbac"
                 ],
-                "summary": "Creates a new review",
+                "summary": "Creates a role",
                 "parameters": [
                     {
-                        "description": "Review details",
-                        "name": "review",
+                        "description": "Role",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewRequest"
+                            "$ref": "#/definitions/models.CreateRole"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/booking.ReviewResponse"
+                            "$ref": "#/definitions/models.Role"
                         }
                     },
                     "400": {
-                        "description": "Invalid review data",
+                        "description": "Invalid role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Server error while processing review",
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
@@ -1098,42 +1221,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/reviews/{id}": {
-            "get": {
+        "/admin/roles/{role}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves review details by review ID",
+                "description": "Deletes a custom role together with its permissions and user assignments",
                 "tags": [
-                    "reviews"
+                    "rbac"
                 ],
-                "summary": "Retrieves a review by ID",
+                "summary": "Deletes a role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Review ID",
-                        "name": "id",
+                        "description": "Role name",
+                        "name": "role",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Role deleted",
                         "schema": {
-                            "$ref": "#/definitions/booking.ReviewResponse"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid review ID",
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Built-in role or role still in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/roles/{role}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "rbac"
+                ],
+                "summary": "Lists the permissions of a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Permission"
+                            }
+                        }
                     },
                     "404": {
-                        "description": "Review not found",
+                        "description": "Role not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1146,32 +1314,32 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates the details of a review by its ID",
+                "description": "Allows the role to call a path, as registered in the router, with a method. Takes effect on every gateway instance.",
                 "tags": [
-                    "reviews"
+                    "rbac"
                 ],
-                "summary": "Updates a review by ID",
+                "summary": "Grants a permission to a role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Review ID",
-                        "name": "id",
+                        "description": "Role name",
+                        "name": "role",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Review details",
-                        "name": "review",
+                        "description": "Permission",
+                        "name": "permission",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Review"
+                            "$ref": "#/definitions/models.Permission"
                         }
                     }
                 ],
@@ -1179,23 +1347,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ReviewResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Permission"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid review data",
+                        "description": "Invalid permission",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Review not found",
+                        "description": "Role not found",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Server error while processing review",
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
@@ -1208,35 +1379,52 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a review by its ID",
                 "tags": [
-                    "reviews"
+                    "rbac"
                 ],
-                "summary": "Deletes a review by ID",
+                "summary": "Revokes a permission from a role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Review ID",
-                        "name": "id",
+                        "description": "Role name",
+                        "name": "role",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Permission",
+                        "name": "permission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Permission"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Review successfully deleted",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Permission"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid review ID",
+                        "description": "Invalid permission",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Review not found",
+                        "description": "Role or permission not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Protected permission",
                         "schema": {
                             "type": "string"
                         }
@@ -1250,47 +1438,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/search/provider": {
+        "/admin/security-events": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Searchs Providers and returns details",
+                "description": "Lists failed and successful logins, blocked attempts and account/IP lockouts, newest first",
                 "tags": [
-                    "search"
+                    "admin"
                 ],
-                "summary": "Searchs Providers",
+                "summary": "Lists security events",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Sort by rating",
-                        "name": "by_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Sort by number of comments",
-                        "name": "by_noc",
+                        "type": "string",
+                        "description": "Event type (login_failed, login_succeeded, login_blocked, account_locked, ip_locked)",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Company Name",
-                        "name": "company",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Location",
-                        "name": "location",
+                        "description": "Email",
+                        "name": "email",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Ishlash Vaqt oralig` + "`" + `i (8:00-20:00)",
-                        "name": "availability",
+                        "description": "Client IP",
+                        "name": "ip",
                         "in": "query"
                     },
                     {
@@ -1301,7 +1483,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Limit",
+                        "description": "Number of events per page",
                         "name": "limit",
                         "in": "query"
                     }
@@ -1310,11 +1492,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListProvidersResponses"
+                            "$ref": "#/definitions/users.SecurityEvents"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Server error while processing SearchProviders",
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
@@ -1322,65 +1510,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/search/service": {
+        "/admin/services/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Searchs service and returns details",
+                "description": "Retrieves service details by service ID",
                 "tags": [
-                    "search"
+                    "services"
                 ],
-                "summary": "Searchs Services",
+                "summary": "Retrieves a service by ID",
                 "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "Sort by price",
-                        "name": "by_price",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Location",
-                        "name": "location",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Price",
-                        "name": "price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Duration",
-                        "name": "duration",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListServicesResponses"
+                            "$ref": "#/definitions/booking.ServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Server error while processing SearchServices",
+                        "description": "Server error while processing request",
                         "schema": {
                             "type": "string"
                         }
@@ -1388,39 +1559,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/services": {
-            "get": {
+        "/admin/user": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of services with pagination",
+                "description": "Updates user info by ID",
                 "tags": [
-                    "services"
+                    "admin"
                 ],
-                "summary": "Lists all services with pagination",
+                "summary": "Updates user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "New user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.UpdateProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListServicesResponse"
+                            "$ref": "#/definitions/users.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id or unknown role",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -1432,33 +1604,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/services/popular": {
+        "/admin/user/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves service details",
+                "description": "Retrieves user info by ID",
                 "tags": [
-                    "services"
+                    "admin"
+                ],
+                "summary": "Gets user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Retrieves a popular service",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListServicesResponse"
+                            "$ref": "#/definitions/users.GetProfileResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid service ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Service not found",
+                        "description": "Invalid user id",
                         "schema": {
                             "type": "string"
                         }
@@ -1470,24 +1645,22 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/customer/services/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves service details by service ID",
+                "description": "Deletes user info by ID",
                 "tags": [
-                    "services"
+                    "admin"
                 ],
-                "summary": "Retrieves a service by ID",
+                "summary": "Deletes user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1495,19 +1668,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/booking.ServiceResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid service ID",
+                        "description": "User deleted successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "400": {
+                        "description": "Invalid user id",
                         "schema": {
                             "type": "string"
                         }
@@ -1521,39 +1688,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/provider": {
+        "/admin/users": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of providers with pagination",
+                "description": "Retrieves users from the database by filtering",
                 "tags": [
-                    "providers"
+                    "admin"
                 ],
-                "summary": "Lists all providers with pagination",
+                "summary": "Fetches users",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full name",
+                        "name": "full_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Page number",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Page limit",
+                        "description": "Number of users per page",
                         "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListProvidersResponse"
+                            "$ref": "#/definitions/users.UserResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -1563,38 +1746,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/users/{id}/roles": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Registers a new provider and returns the provider details",
+                "description": "Returns the role of the user's profile and the roles assigned on top of it",
                 "tags": [
-                    "providers"
+                    "rbac"
                 ],
-                "summary": "Registers a new provider",
+                "summary": "Lists the roles of a user",
                 "parameters": [
                     {
-                        "description": "Provider details",
-                        "name": "provider",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Provider"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ProviderResponse"
+                            "$ref": "#/definitions/models.UserRoles"
                         }
                     },
                     "400": {
-                        "description": "Invalid provider data",
+                        "description": "Invalid user ID",
                         "schema": {
                             "type": "string"
                         }
@@ -1606,40 +1789,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/provider/notifications": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds a new notification",
+                "description": "Gives the user the permissions of another role in addition to the role of their profile",
                 "tags": [
-                    "notification"
+                    "rbac"
                 ],
-                "summary": "Creates notification",
+                "summary": "Assigns a role to a user",
                 "parameters": [
                     {
-                        "description": "Receiver ID, Title and Message",
-                        "name": "data",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/booking.NewNotification"
+                            "$ref": "#/definitions/models.AssignRole"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Notification created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.UserRoles"
                         }
                     },
                     "400": {
-                        "description": "Invalid data format",
+                        "description": "Invalid data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1653,36 +1847,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/provider/notifications/{id}": {
-            "get": {
+        "/admin/users/{id}/roles/{role}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Gets notification by ID",
+                "description": "Removes a role assigned with POST /admin/users/{id}/roles. The role of the profile is changed with PUT /admin/user.",
                 "tags": [
-                    "notification"
+                    "rbac"
                 ],
-                "summary": "Gets notification",
+                "summary": "Removes an assigned role from a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.Notification"
+                            "$ref": "#/definitions/models.UserRoles"
                         }
                     },
                     "400": {
-                        "description": "Invalid data format",
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not assigned",
                         "schema": {
                             "type": "string"
                         }
@@ -1696,22 +1903,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/provider/services": {
-            "get": {
+        "/customer/bookings": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of services with pagination",
+                "description": "Creates a new booking and returns the booking details",
                 "tags": [
-                    "services"
+                    "bookings"
                 ],
-                "summary": "Lists all services with pagination",
+                "summary": "Creates a new booking",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
+                        "description": "Booking details",
+                        "name": "booking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Booking"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wait up to this long for the outcome, e.g. 5s",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request processed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "202": {
+                        "description": "Request accepted, poll /operations/{id} for the outcome",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/bookings/all": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of bookings with pagination",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Lists bookings with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query",
                         "required": true
@@ -1728,7 +2004,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ListServicesResponse"
+                            "$ref": "#/definitions/booking.ListBookingsResponse"
                         }
                     },
                     "500": {
@@ -1738,26 +2014,28 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/customer/bookings/quote": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new service and returns the service details",
+                "description": "Prices a booking without creating it. Pass the returned quote_id when creating the booking before it expires to be charged the quoted price",
                 "tags": [
-                    "services"
+                    "bookings"
                 ],
-                "summary": "Creates a new service",
+                "summary": "Quotes the price of a booking",
                 "parameters": [
                     {
-                        "description": "Service details",
-                        "name": "service",
+                        "description": "Booking details",
+                        "name": "booking",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/booking.CreateServiceRequest"
+                            "$ref": "#/definitions/models.Booking"
                         }
                     }
                 ],
@@ -1765,11 +2043,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ServiceResponse"
+                            "$ref": "#/definitions/booking.BookingQuote"
                         }
                     },
                     "400": {
-                        "description": "Invalid service data",
+                        "description": "Invalid booking data or the provider cannot take the booking",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Service, provider or organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Provider is already booked at the requested time",
                         "schema": {
                             "type": "string"
                         }
@@ -1783,51 +2079,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/provider/services/{id}": {
-            "put": {
+        "/customer/bookings/slots": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates service details by service ID",
+                "description": "Lists the start times at which the provider can still take the service on the given day",
                 "tags": [
-                    "services"
+                    "bookings"
                 ],
-                "summary": "Updates a service by ID",
+                "summary": "Lists free booking slots",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
+                        "name": "service_id",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Updated service details",
-                        "name": "service",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/booking.UpdateServiceRequest"
-                        }
+                        "type": "string",
+                        "description": "Day in YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ServiceResponse"
+                            "$ref": "#/definitions/booking.AvailableSlotsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid service data or ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Service not found",
+                        "description": "Missing or invalid query parameters",
                         "schema": {
                             "type": "string"
                         }
@@ -1841,22 +2136,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/provider/{id}": {
+        "/customer/bookings/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves provider details by provider ID",
+                "description": "Retrieves booking details by booking ID",
                 "tags": [
-                    "providers"
+                    "bookings"
                 ],
-                "summary": "Retrieves a provider by ID",
+                "summary": "Retrieves a booking by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1866,17 +2161,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ProviderResponse"
+                            "$ref": "#/definitions/booking.BookingResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid provider ID",
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Provider not found",
+                        "description": "Booking not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1895,26 +2196,26 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates provider details by provider ID",
+                "description": "Updates booking details by booking ID",
                 "tags": [
-                    "providers"
+                    "bookings"
                 ],
-                "summary": "Updates a provider by ID",
+                "summary": "Updates a booking by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated provider details",
-                        "name": "provider",
+                        "description": "Updated booking details",
+                        "name": "booking",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/booking.UpdateProviderRequest"
+                            "$ref": "#/definitions/booking.UpdateBookingRequest"
                         }
                     }
                 ],
@@ -1922,17 +2223,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/booking.ProviderResponse"
+                            "$ref": "#/definitions/booking.BookingResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid provider data or ID",
+                        "description": "Invalid booking data or ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Provider not found",
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Requested time is not available or the booking can no longer be changed",
                         "schema": {
                             "type": "string"
                         }
@@ -1951,35 +2264,59 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a provider by provider ID",
+                "description": "Cancels booking by booking ID. The provider's cancellation policy may charge a fee depending on how close to the scheduled time the booking is canceled; the result carries it as cancellation_fee. The fee is kept from the payment and the rest refunded",
                 "tags": [
-                    "providers"
+                    "bookings"
                 ],
-                "summary": "Deletes a provider by ID",
+                "summary": "Cancels a booking by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cancellation reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wait up to this long for the outcome, e.g. 5s",
+                        "name": "wait",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "Request processed",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "202": {
+                        "description": "Request accepted, poll /operations/{id} for the outcome",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
                         }
                     },
                     "400": {
-                        "description": "Invalid provider ID",
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Provider not found",
+                        "description": "Booking not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1992,259 +2329,4227 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "booking.BookingResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "location": {
-                    "$ref": "#/definitions/booking.GeoPoint"
-                },
-                "provider_id": {
-                    "type": "string"
-                },
-                "scheduled_time": {
-                    "type": "string"
-                },
-                "service_id": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "total_price": {
-                    "type": "number"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
-                }
-            }
         },
-        "booking.CreatePaymentRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "booking_id": {
-                    "type": "string"
-                },
-                "payment_method": {
-                    "type": "string"
-                },
-                "transaction_id": {
-                    "type": "string"
-                },
+        "/customer/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the organizations the caller belongs to together with the caller's role in each",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Lists the user's organizations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationResponses"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a fleet or company account; the caller becomes its first owner",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Creates an organization",
+                "parameters": [
+                    {
+                        "description": "Organization details",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrganization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/invitations/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Joins the organization with the token from the invitation email. The caller's verified email must match the invitation",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Accepts an invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired invitation, or email not verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves an organization the caller belongs to",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Gets an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the bookings billed to the organization. Only owners and managers can see them",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Lists organization bookings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListBookingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization id or pagination parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the invitations of an organization that were not accepted yet",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Lists pending invitations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.Invitations"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Emails an invitation to join the organization. Owners and managers can invite, only owners can invite owners",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Invites a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitee email and role",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InviteMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/users.Invitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email or role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/invitations/{invitation_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revokes a pending invitation so its token can no longer be accepted",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Revokes an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation revoked successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id or unknown invitation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the members of an organization the caller belongs to",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Lists organization members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationMembers"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a member of the organization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Changes the role of an organization member. Only owners can change roles and the last owner cannot be demoted",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Changes a member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role: owner, manager or driver",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MemberRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id or role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Organization must keep at least one owner",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a member from an organization. Members can leave on their own, owners can remove anyone and managers can remove drivers",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Removes a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Organization must keep at least one owner",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/organizations/{org_id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the payments billed to the organization. Only owners and managers can see them",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Lists organization payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization id or pagination parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's organization role does not allow this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of payments with pagination",
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Lists all payments with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListPaymentsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Charges the total price of the booking to the payment method. Amount and transaction ID are set by the service. Payment methods needing 3-D Secure stay in requires_action until the payment provider reports the outcome",
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Creates a new payment",
+                "parameters": [
+                    {
+                        "description": "Payment details",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.CreatePaymentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wait up to this long for the outcome, e.g. 5s",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request processed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "202": {
+                        "description": "Request accepted, poll /operations/{id} for the outcome",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Booking belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing payment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/payments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves payment details by payment ID",
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Retrieves a payment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/payments/{id}/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns every refund attempt of the payment, oldest first, including the ones the payment provider rejected",
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Lists the refunds of a payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListRefundsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves user profile by ID",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Gets user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.GetProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates user info by ID",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Updates user",
+                "parameters": [
+                    {
+                        "description": "New user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.UserResponseU"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id or data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/providers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of providers with pagination",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Lists all providers with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListProvidersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/providers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves provider details by provider ID",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Retrieves a provider by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of reviews with pagination",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Lists all reviews with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submits a review for a booking and returns the review details",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Creates a new review",
+                "parameters": [
+                    {
+                        "description": "Review details",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wait up to this long for the outcome, e.g. 5s",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request processed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "202": {
+                        "description": "Request accepted, poll /operations/{id} for the outcome",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing review",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/reviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves review details by review ID",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Retrieves a review by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the details of a review by its ID",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Updates a review by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review details",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing review",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a review by its ID",
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Deletes a review by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review successfully deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/search/provider": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Searchs Providers and returns details",
+                "tags": [
+                    "search"
+                ],
+                "summary": "Searchs Providers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sort by rating",
+                        "name": "by_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort by number of comments",
+                        "name": "by_noc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company Name",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the search center",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the search center",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in kilometers",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ishlash Vaqt oralig` + "`" + `i (8:00-20:00)",
+                        "name": "availability",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListProvidersResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search center or radius",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing SearchProviders",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/search/service": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Searchs service and returns details",
+                "tags": [
+                    "search"
+                ],
+                "summary": "Searchs Services",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sort by price",
+                        "name": "by_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price",
+                        "name": "price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Duration",
+                        "name": "duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListServicesResponses"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing SearchServices",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/services": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of services with pagination",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Lists all services with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListServicesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/services/popular": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves service details",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Retrieves a popular service",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListServicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/services/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves service details by service ID",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Retrieves a service by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the status and, once processed, the result or error of an operation",
+                "tags": [
+                    "operations"
+                ],
+                "summary": "Retrieves the outcome of an asynchronous request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid operation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Operation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of providers with pagination",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Lists all providers with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListProvidersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers a new provider and returns the provider details",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Registers a new provider",
+                "parameters": [
+                    {
+                        "description": "Provider details",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Provider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists pending bookings addressed to the current provider, oldest first",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Lists incoming booking requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListBookingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Provider accepts a pending booking addressed to them",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Accepts a booking request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves an in_progress booking to completed",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Completes the wash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/en-route": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves a confirmed booking to en_route",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Marks washer as en route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/no-show": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves a confirmed or en_route booking to no_show",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Marks customer as no-show",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.BookingTransition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Provider rejects a pending booking addressed to them",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Rejects a booking request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.BookingTransition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/bookings/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves an en_route booking to in_progress",
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Starts the wash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/notifications": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new notification",
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Creates notification",
+                "parameters": [
+                    {
+                        "description": "Receiver ID, Title and Message",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.NewNotification"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Notification created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/notifications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Gets notification by ID",
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Gets notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/services": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of services with pagination",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Lists all services with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ListServicesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new service and returns the service details",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Creates a new service",
+                "parameters": [
+                    {
+                        "description": "Service details",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.CreateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/services/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates service details by service ID",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Updates a service by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated service details",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.UpdateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service data or ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves provider details by provider ID",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Retrieves a provider by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates provider details by provider ID",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Updates a provider by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated provider details",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.UpdateProviderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider data or ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a provider by provider ID",
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Deletes a provider by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/{id}/documents": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Attaches a business license or insurance document (PDF, JPEG or PNG) to the caller's provider application",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Uploads a provider application document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "business_license or insurance",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.ProviderDocument"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/{id}/documents/{document_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a document of the caller's provider application",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Downloads a provider application document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Document file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Resource belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider or document not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/{id}/pricing": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the pricing rules the provider set on top of the platform defaults",
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Gets a provider's pricing rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.PricingRules"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller does not own the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets vehicle class multipliers, travel fee, time-of-day rules, the weekend multiplier and the cancellation policy of the provider. Sections left empty fall back to the platform defaults",
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Replaces a provider's pricing rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rules",
+                        "name": "rules",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.PricingRules"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.PricingRules"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pricing rules",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller does not own the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Drops the provider's own rules so its bookings are priced with the platform defaults",
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Removes a provider's pricing rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pricing rules deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller does not own the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider has no pricing rules",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments": {
+            "post": {
+                "description": "Called by the payment provider when a payment changes, e.g. once a 3-D Secure challenge is finished. Requests must carry a valid Payment-Signature header instead of a token. Events that were already applied are acknowledged again",
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Receives payment provider events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "t=\u003cunix time\u003e,v1=\u003chex HMAC-SHA256 of \\",
+                        "name": "Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/booking.PaymentEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/booking.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Event does not apply to the payment's status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error while processing request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "booking.AvailableSlotsResponse": {
+            "type": "object",
+            "properties": {
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.TimeSlot"
+                    }
+                }
+            }
+        },
+        "booking.BookingQuote": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "price_breakdown": {
+                    "$ref": "#/definitions/booking.PriceBreakdown"
+                },
+                "quote_id": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "booking.BookingResponse": {
+            "type": "object",
+            "properties": {
+                "cancellation_fee": {
+                    "description": "Kept from the payment, or owed, when the booking was canceled late",
+                    "type": "number"
+                },
+                "cancellation_fee_override": {
+                    "$ref": "#/definitions/booking.CancellationFeeOverride"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/booking.GeoPoint"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "description": "Status of the latest payment, empty while unpaid",
+                    "type": "string"
+                },
+                "price_breakdown": {
+                    "description": "Itemized total_price",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/booking.PriceBreakdown"
+                        }
+                    ]
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "scheduled_time": {
+                    "type": "string"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.StatusChange"
+                    }
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vehicle_class": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.CancellationFeeOverride": {
+            "type": "object",
+            "properties": {
+                "original_fee": {
+                    "description": "Fee charged by the cancellation policy",
+                    "type": "number"
+                },
+                "overridden_at": {
+                    "type": "string"
+                },
+                "overridden_by": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.CancellationPolicy": {
+            "type": "object",
+            "properties": {
+                "en_route_fee_percent": {
+                    "type": "number"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.CancellationTier"
+                    }
+                }
+            }
+        },
+        "booking.CancellationTier": {
+            "type": "object",
+            "properties": {
+                "fee_percent": {
+                    "description": "Share of the total price, 50 = half",
+                    "type": "number"
+                },
+                "hours_before": {
+                    "description": "Applies to cancellations less than this many hours before the start",
+                    "type": "number"
+                }
+            }
+        },
+        "booking.CreatePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Ignored: the booking's total price is charged",
+                    "type": "number"
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "Set by the service from the booking",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "Payment method token handed to the payment provider",
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "description": "Ignored: assigned by the payment provider",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.CreateServiceRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration in minutes",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "description": "Provider user offering the service",
+                    "type": "string"
+                }
+            }
+        },
+        "booking.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "body": {
+                    "description": "Original message body",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "poison": {
+                    "description": "The body could not be decoded, so it was never retried",
+                    "type": "boolean"
+                },
+                "queue": {
+                    "description": "Queue the message was originally published to",
+                    "type": "string"
+                },
+                "replayed_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"dead\" or \"replayed\"",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "booking.ListBookingsResponse": {
+            "type": "object",
+            "properties": {
+                "bookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.BookingResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "dead_letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.DeadLetter"
+                    }
+                }
+            }
+        },
+        "booking.ListPaymentsResponse": {
+            "type": "object",
+            "properties": {
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.PaymentResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListProvidersResponse": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "description": "List of providers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.ProviderResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListProvidersResponses": {
+            "type": "object",
+            "properties": {
+                "provideres": {
+                    "description": "List of providers matching the filter criteria",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.Providers"
+                    }
+                }
+            }
+        },
+        "booking.ListRefundsResponse": {
+            "type": "object",
+            "properties": {
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.RefundResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.ReviewResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListServicesResponse": {
+            "type": "object",
+            "properties": {
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.ServiceResponse"
+                    }
+                }
+            }
+        },
+        "booking.ListServicesResponses": {
+            "type": "object",
+            "properties": {
+                "services": {
+                    "description": "List of services matching the filter criteria",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.Service"
+                    }
+                }
+            }
+        },
+        "booking.NewNotification": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.PaymentEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "payment.authorized, payment.declined, payment.captured, payment.voided, payment.refunded or payment.failed",
+                    "type": "string"
+                }
+            }
+        },
+        "booking.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "description": "Why the payment was declined or failed",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "Taken from the booking",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "description": "Sum of the succeeded refunds",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.PaymentStatusChange"
+                    }
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.PaymentStatusChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "\"api\", or the type of the provider event that caused the change",
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.PriceBreakdown": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.PriceItem"
+                    }
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "booking.PriceItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "code": {
+                    "description": "base, vehicle_class, time_of_day, weekend, travel or booking_fee",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.PricingRules": {
+            "type": "object",
+            "properties": {
+                "booking_fee": {
+                    "description": "Flat fee per booking, platform defaults only",
+                    "type": "number"
+                },
+                "cancellation": {
+                    "description": "Fees of canceling bookings, none = free",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/booking.CancellationPolicy"
+                        }
+                    ]
+                },
+                "provider_id": {
+                    "description": "Empty for the platform defaults",
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "description": "Share of the subtotal, 0.12 = 12%, platform defaults only",
+                    "type": "number"
+                },
+                "time_rules": {
+                    "description": "The first matching rule applies",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.TimeRule"
+                    }
+                },
+                "travel_fee": {
+                    "$ref": "#/definitions/booking.TravelFee"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "vehicle_multipliers": {
+                    "description": "Multiplier of the service price per vehicle class",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "weekend_multiplier": {
+                    "description": "Multiplier for Saturday and Sunday, 0 = none",
+                    "type": "number"
+                }
+            }
+        },
+        "booking.ProviderDocument": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size in bytes",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "business_license or insurance",
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.ProviderResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.ProviderDocument"
+                    }
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/booking.GeoPoint"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.WorkingHours"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "submitted, under_review, approved, rejected, suspended",
+                    "type": "string"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.StatusChange"
+                    }
+                },
+                "timezone": {
+                    "description": "IANA time zone of the schedule",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.Providers": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability time range (e.g., \"09:00-18:00\")",
+                    "type": "string"
+                },
+                "averageRating": {
+                    "description": "Average rating of the provider",
+                    "type": "number"
+                },
+                "companyName": {
+                    "description": "Company name of the provider",
+                    "type": "string"
+                },
+                "distanceKm": {
+                    "description": "Distance from the search center, set when center is given",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "Unique ID of the provider",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "Location of the provider",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the provider",
+                    "type": "string"
+                },
+                "numberOfComments": {
+                    "description": "Number of comments for the provider",
+                    "type": "integer"
+                },
+                "point": {
+                    "description": "Coordinates of the provider",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/booking.GeoPoint"
+                        }
+                    ]
+                }
+            }
+        },
+        "booking.PurgeDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "booking.RefundPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "0 refunds everything not refunded yet",
+                    "type": "number"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "description": "Set by the gateway",
+                    "type": "string"
+                }
+            }
+        },
+        "booking.RefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "description": "User who asked for the refund or canceled the booking",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "succeeded or failed",
+                    "type": "string"
+                }
+            }
+        },
+        "booking.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.Service": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "Duration of the service in minutes",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Unique ID of the service",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "Location of the service",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the service",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price of the service",
+                    "type": "number"
+                }
+            }
+        },
+        "booking.ServiceResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "booking.CreateServiceRequest": {
+        "booking.StatusChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "booking.TimeRule": {
             "type": "object",
             "properties": {
-                "description": {
+                "end": {
+                    "description": "\"20:00\"",
                     "type": "string"
                 },
-                "duration": {
-                    "description": "Duration in minutes",
-                    "type": "integer"
+                "multiplier": {
+                    "type": "number"
                 },
                 "name": {
+                    "description": "Shown on the price breakdown, e.g. \"Rush hour\"",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "start": {
+                    "description": "\"17:00\"",
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "0 = Sunday ... 6 = Saturday, empty = every day",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "booking.GeoPoint": {
+        "booking.TimeSlot": {
             "type": "object",
             "properties": {
-                "latitude": {
-                    "type": "number"
+                "end_time": {
+                    "description": "RFC3339",
+                    "type": "string"
                 },
-                "longitude": {
-                    "type": "number"
+                "start_time": {
+                    "description": "RFC3339",
+                    "type": "string"
                 }
             }
         },
-        "booking.ListBookingsResponse": {
+        "booking.TravelFee": {
             "type": "object",
             "properties": {
-                "bookings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.BookingResponse"
-                    }
+                "free_km": {
+                    "type": "number"
+                },
+                "max_km": {
+                    "type": "number"
+                },
+                "per_km": {
+                    "type": "number"
                 }
             }
         },
-        "booking.ListPaymentsResponse": {
+        "booking.UpdateBookingRequest": {
             "type": "object",
             "properties": {
-                "payments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.PaymentResponse"
-                    }
+                "id": {
+                    "type": "string"
+                },
+                "service_id": {
+                    "type": "string"
                 }
             }
         },
-        "booking.ListProvidersResponse": {
+        "booking.UpdateProviderRequest": {
             "type": "object",
             "properties": {
-                "providers": {
-                    "description": "List of providers",
+                "availability": {
+                    "description": "Updated array of available time slots",
+                    "type": "string"
+                },
+                "average_rating": {
+                    "description": "Updated average rating",
+                    "type": "number"
+                },
+                "company_name": {
+                    "description": "Updated company name",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Updated description",
+                    "type": "string"
+                },
+                "holidays": {
+                    "description": "Updated days off in YYYY-MM-DD format",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/booking.ProviderResponse"
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Provider ID",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "Updated location",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/booking.GeoPoint"
+                        }
+                    ]
+                },
+                "schedule": {
+                    "description": "Updated weekly working hours",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/booking.WorkingHours"
+                    }
+                },
+                "services": {
+                    "description": "Updated array of service IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "timezone": {
+                    "description": "Updated IANA time zone of the schedule",
+                    "type": "string"
                 }
             }
         },
-        "booking.ListProvidersResponses": {
+        "booking.UpdateServiceRequest": {
             "type": "object",
             "properties": {
-                "provideres": {
-                    "description": "List of providers matching the filter criteria",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.Providers"
-                    }
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
                 }
             }
         },
-        "booking.ListReviewsResponse": {
+        "booking.WorkingHours": {
             "type": "object",
             "properties": {
-                "reviews": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.ReviewResponse"
-                    }
+                "end": {
+                    "description": "Closing time, \"18:00\"",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "Opening time, \"09:00\"",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "0 = Sunday ... 6 = Saturday",
+                    "type": "integer"
                 }
             }
         },
-        "booking.ListServicesResponse": {
+        "models.AcceptInvitation": {
             "type": "object",
+            "required": [
+                "token"
+            ],
             "properties": {
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.ServiceResponse"
-                    }
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "booking.ListServicesResponses": {
+        "models.AssignRole": {
             "type": "object",
+            "required": [
+                "role"
+            ],
             "properties": {
-                "services": {
-                    "description": "List of services matching the filter criteria",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/booking.Service"
-                    }
+                "role": {
+                    "type": "string",
+                    "example": "support"
                 }
             }
         },
-        "booking.NewNotification": {
+        "models.Booking": {
             "type": "object",
             "properties": {
-                "message": {
+                "location": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "organization_id": {
+                    "description": "Bills the booking to an organization of the user",
                     "type": "string"
                 },
-                "title": {
+                "provider_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "quote_id": {
+                    "description": "Keeps the price of a quote from /customer/bookings/quote",
+                    "type": "string"
+                },
+                "scheduled_time": {
+                    "type": "string"
+                },
+                "service_id": {
                     "type": "string"
+                },
+                "vehicle_class": {
+                    "type": "string",
+                    "example": "sedan"
                 }
             }
         },
-        "booking.Notification": {
+        "models.BookingTransition": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "reason": {
                     "type": "string"
+                }
+            }
+        },
+        "models.CancellationFeeOverride": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "fee": {
+                    "type": "number"
                 },
-                "message": {
+                "reason": {
                     "type": "string"
+                }
+            }
+        },
+        "models.CreateOrganization": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "billing_email": {
+                    "type": "string",
+                    "example": "billing@acme.com"
                 },
-                "title": {
+                "name": {
+                    "type": "string",
+                    "example": "Acme Logistics"
+                }
+            }
+        },
+        "models.CreateRole": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "support"
                 }
             }
         },
-        "booking.PaymentResponse": {
+        "models.GeoPoint": {
             "type": "object",
             "properties": {
-                "amount": {
+                "latitude": {
                     "type": "number"
                 },
-                "booking_id": {
-                    "type": "string"
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.InviteMember": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "driver@acme.com"
                 },
+                "role": {
+                    "type": "string",
+                    "example": "driver"
+                }
+            }
+        },
+        "models.MemberRole": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "manager"
+                }
+            }
+        },
+        "models.Operation": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "payment_method": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "kind": {
                     "type": "string"
                 },
-                "transaction_id": {
-                    "type": "string"
+                "result": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
                 },
                 "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Permission": {
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "GET"
                 },
-                "user_id": {
-                    "type": "string"
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/admin/users"
                 }
             }
         },
-        "booking.ProviderResponse": {
+        "models.Provider": {
             "type": "object",
             "properties": {
+                "availability": {
+                    "type": "string"
+                },
                 "average_rating": {
                     "type": "number"
                 },
                 "company_name": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "location": {
-                    "$ref": "#/definitions/booking.GeoPoint"
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkingHours"
+                    }
                 },
                 "services": {
                     "type": "array",
@@ -2252,48 +6557,36 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "updated_at": {
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "models.ProviderReview": {
+            "type": "object",
+            "properties": {
+                "reason": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "example": "approved"
                 }
             }
         },
-        "booking.Providers": {
+        "models.Review": {
             "type": "object",
             "properties": {
-                "availability": {
-                    "description": "Availability time range (e.g., \"09:00-18:00\")",
+                "comment": {
                     "type": "string"
                 },
-                "averageRating": {
-                    "description": "Average rating of the provider",
+                "rating": {
                     "type": "number"
-                },
-                "companyName": {
-                    "description": "Company name of the provider",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Unique ID of the provider",
-                    "type": "string"
-                },
-                "location": {
-                    "description": "Location of the provider",
-                    "type": "string"
-                },
-                "name": {
-                    "description": "Name of the provider",
-                    "type": "string"
-                },
-                "numberOfComments": {
-                    "description": "Number of comments for the provider",
-                    "type": "integer"
                 }
             }
         },
-        "booking.ReviewResponse": {
+        "models.ReviewRequest": {
             "type": "object",
             "properties": {
                 "booking_id": {
@@ -2302,271 +6595,282 @@ const docTemplate = `{
                 "comment": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
                 "provider_id": {
                     "type": "string"
                 },
                 "rating": {
                     "type": "number"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
                 }
             }
         },
-        "booking.Service": {
+        "models.Role": {
             "type": "object",
             "properties": {
-                "duration": {
-                    "description": "Duration of the service in minutes",
-                    "type": "integer"
+                "built_in": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "description": "Unique ID of the service",
+                "created_at": {
                     "type": "string"
                 },
-                "location": {
-                    "description": "Location of the service",
+                "description": {
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name of the service",
                     "type": "string"
-                },
-                "price": {
-                    "description": "Price of the service",
-                    "type": "number"
                 }
             }
         },
-        "booking.ServiceResponse": {
+        "models.RolePermissions": {
             "type": "object",
             "properties": {
+                "built_in": {
+                    "type": "boolean"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "updated_at": {
-                    "type": "string"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Permission"
+                    }
                 }
             }
         },
-        "booking.UpdateBookingRequest": {
+        "models.UUser": {
             "type": "object",
             "properties": {
-                "id": {
+                "first_name": {
                     "type": "string"
                 },
-                "service_id": {
+                "last_name": {
+                    "type": "string"
+                },
+                "phone_number": {
                     "type": "string"
                 }
             }
         },
-        "booking.UpdateProviderRequest": {
+        "models.UserRoles": {
             "type": "object",
             "properties": {
-                "availability": {
-                    "description": "Updated array of available time slots",
-                    "type": "string"
-                },
-                "average_rating": {
-                    "description": "Updated average rating",
-                    "type": "number"
-                },
-                "company_name": {
-                    "description": "Updated company name",
-                    "type": "string"
+                "extra_roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "description": {
-                    "description": "Updated description",
+                "role": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Provider ID",
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WorkingHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "18:00"
                 },
-                "location": {
-                    "description": "Updated location",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/booking.GeoPoint"
-                        }
-                    ]
+                "start": {
+                    "type": "string",
+                    "example": "09:00"
                 },
-                "services": {
-                    "description": "Updated array of service IDs",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "weekday": {
+                    "type": "integer"
                 }
             }
         },
-        "booking.UpdateServiceRequest": {
+        "users.GetProfileResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "last_name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "phone_number": {
+                    "type": "string"
+                },
+                "phone_verified": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "Should be 'customer', 'provider', or 'admin'",
+                    "type": "string"
                 }
             }
         },
-        "models.Booking": {
+        "users.Invitation": {
             "type": "object",
             "properties": {
-                "location": {
-                    "$ref": "#/definitions/models.GeoPoint"
+                "created_at": {
+                    "type": "string"
                 },
-                "provider_id": {
+                "email": {
                     "type": "string"
                 },
-                "scheduled_time": {
+                "expires_at": {
                     "type": "string"
                 },
-                "service_id": {
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "models.GeoPoint": {
+        "users.Invitations": {
             "type": "object",
             "properties": {
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.Invitation"
+                    }
                 }
             }
         },
-        "models.Provider": {
+        "users.OrganizationMember": {
             "type": "object",
             "properties": {
-                "availability": {
+                "email": {
                     "type": "string"
                 },
-                "average_rating": {
-                    "type": "number"
+                "first_name": {
+                    "type": "string"
                 },
-                "company_name": {
+                "joined_at": {
                     "type": "string"
                 },
-                "description": {
+                "last_name": {
                     "type": "string"
                 },
-                "location": {
-                    "$ref": "#/definitions/models.GeoPoint"
+                "organization_id": {
+                    "type": "string"
                 },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "role": {
+                    "description": "owner, manager or driver",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "models.Review": {
+        "users.OrganizationMembers": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string"
-                },
-                "rating": {
-                    "type": "number"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.OrganizationMember"
+                    }
                 }
             }
         },
-        "models.ReviewRequest": {
+        "users.OrganizationResponse": {
             "type": "object",
             "properties": {
-                "booking_id": {
+                "billing_email": {
                     "type": "string"
                 },
-                "comment": {
+                "created_at": {
                     "type": "string"
                 },
-                "provider_id": {
+                "created_by": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
-                }
-            }
-        },
-        "models.UUser": {
-            "type": "object",
-            "properties": {
-                "first_name": {
+                "id": {
                     "type": "string"
                 },
-                "last_name": {
+                "name": {
                     "type": "string"
                 },
-                "phone_number": {
+                "role": {
+                    "description": "Role of the requesting user in the organization",
                     "type": "string"
                 }
             }
         },
-        "users.GetProfileResponse": {
+        "users.OrganizationResponses": {
+            "type": "object",
+            "properties": {
+                "organizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.OrganizationResponse"
+                    }
+                }
+            }
+        },
+        "users.SecurityEvent": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "details": {
                     "type": "string"
                 },
-                "first_name": {
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "last_name": {
+                "ip": {
                     "type": "string"
                 },
-                "phone_number": {
+                "type": {
+                    "description": "login_failed, login_succeeded, login_blocked, account_locked, ip_locked",
                     "type": "string"
                 },
-                "role": {
-                    "description": "Should be 'customer', 'provider', or 'admin'",
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
+        "users.SecurityEvents": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.SecurityEvent"
+                    }
+                }
+            }
+        },
         "users.UpdateProfileRequest": {
             "type": "object",
             "properties": {
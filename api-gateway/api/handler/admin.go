@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -55,7 +56,7 @@ func (h *Handler) GetUser(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param user body users.UpdateProfileRequest true "New user data"
 // @Success 200 {object} users.UserResponse
-// @Failure 400 {object} string "Invalid user id"
+// @Failure 400 {object} string "Invalid user id or unknown role"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /admin/user [put]
 func (h *Handler) UpdateUser(c *gin.Context) {
@@ -75,7 +76,11 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 	resp, err := h.Admin.UpdateProfileA(ctx, &req)
 	if err != nil {
 		er := errors.Wrap(err, "error updating user").Error()
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		if strings.Contains(err.Error(), "unknown role") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
 		h.Log.Error(er)
 		return
 	}
@@ -7,9 +7,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 )
 
 // CreateBooking godoc
@@ -18,8 +20,12 @@ import (
 // @Tags bookings
 // @Security ApiKeyAuth
 // @Param booking body models.Booking true "Booking details"
-// @Success 200 {object} booking.BookingResponse
+// @Param wait query string false "Wait up to this long for the outcome, e.g. 5s"
+// @Success 200 {object} models.Operation "Request processed"
+// @Success 202 {object} models.Operation "Request accepted, poll /operations/{id} for the outcome"
 // @Failure 400 {object} string "Invalid booking data"
+// @Failure 403 {object} string "User is not a member of the organization"
+// @Failure 404 {object} string "Organization not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/bookings [post]
 func (h *Handler) CreateBooking(c *gin.Context) {
@@ -40,12 +46,19 @@ func (h *Handler) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	if !h.organizationMember(c, req.OrganizationID, id.(string)) {
+		return
+	}
+
 	req1 := &pb.CreateBookingRequest{
-		UserId:        id.(string),
-		ProviderId:    req.ProviderID,
-		ServiceId:     req.ServiceID,
-		ScheduledTime: req.ScheduledTime,
-		Location:      &pb.GeoPoint{Longitude: req.Location.Longitude, Latitude: req.Location.Latitude},
+		UserId:         id.(string),
+		ProviderId:     req.ProviderID,
+		ServiceId:      req.ServiceID,
+		ScheduledTime:  req.ScheduledTime,
+		Location:       &pb.GeoPoint{Longitude: req.Location.Longitude, Latitude: req.Location.Latitude},
+		OrganizationId: req.OrganizationID,
+		VehicleClass:   req.VehicleClass,
+		QuoteId:        req.QuoteID,
 	}
 
 	body, err := json.Marshal(req1)
@@ -55,13 +68,7 @@ func (h *Handler) CreateBooking(c *gin.Context) {
 		h.Log.Error(er)
 		return
 	}
-	err = h.Broker.CreateBooking(body)
-	if err != nil {
-		er := errors.Wrap(err, "invalid booking data").Error()
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
-		h.Log.Error(er)
-		return
-	}
+	h.publishOperation(c, h.Broker.CreateBooking, body)
 	//ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	//defer cancel()
 
@@ -80,7 +87,69 @@ func (h *Handler) CreateBooking(c *gin.Context) {
 	//}
 
 	h.Log.Info("CreateBooking handler is completed")
-	c.JSON(http.StatusOK, gin.H{"booking": "CREATED BY RABBITMQ"})
+}
+
+// QuoteBooking godoc
+// @Summary Quotes the price of a booking
+// @Description Prices a booking without creating it. Pass the returned quote_id when creating the booking before it expires to be charged the quoted price
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param booking body models.Booking true "Booking details"
+// @Success 200 {object} booking.BookingQuote
+// @Failure 400 {object} string "Invalid booking data or the provider cannot take the booking"
+// @Failure 403 {object} string "User is not a member of the organization"
+// @Failure 404 {object} string "Service, provider or organization not found"
+// @Failure 409 {object} string "Provider is already booked at the requested time"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/bookings/quote [post]
+func (h *Handler) QuoteBooking(c *gin.Context) {
+	h.Log.Info("QuoteBooking handler is starting")
+
+	var req models.Booking
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid booking data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if !h.organizationMember(c, req.OrganizationID, userID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Booking.QuoteBooking(ctx, &pb.CreateBookingRequest{
+		UserId:         userID,
+		ProviderId:     req.ProviderID,
+		ServiceId:      req.ServiceID,
+		ScheduledTime:  req.ScheduledTime,
+		Location:       &pb.GeoPoint{Longitude: req.Location.Longitude, Latitude: req.Location.Latitude},
+		OrganizationId: req.OrganizationID,
+		VehicleClass:   req.VehicleClass,
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error quoting booking").Error()
+		switch {
+		case strings.Contains(er, "not found"):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		case strings.Contains(er, "already booked"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		case strings.Contains(er, "invalid"), strings.Contains(er, "unknown vehicle class"),
+			strings.Contains(er, "not approved"), strings.Contains(er, "not working"),
+			strings.Contains(er, "outside the provider's service area"):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("QuoteBooking handler is completed")
+	c.JSON(http.StatusOK, resp)
 }
 
 // GetBooking godoc
@@ -91,6 +160,7 @@ func (h *Handler) CreateBooking(c *gin.Context) {
 // @Param id path string true "Booking ID"
 // @Success 200 {object} booking.BookingResponse
 // @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Booking not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/bookings/{id} [get]
@@ -127,7 +197,9 @@ func (h *Handler) GetBooking(c *gin.Context) {
 // @Param booking body booking.UpdateBookingRequest true "Updated booking details"
 // @Success 200 {object} booking.BookingResponse
 // @Failure 400 {object} string "Invalid booking data or ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Requested time is not available or the booking can no longer be changed"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/bookings/{id} [put]
 func (h *Handler) UpdateBooking(c *gin.Context) {
@@ -156,9 +228,16 @@ func (h *Handler) UpdateBooking(c *gin.Context) {
 	resp, err := h.Booking.UpdateBooking(ctx, &req)
 	if err != nil {
 		er := errors.Wrap(err, "error updating booking").Error()
-		if err.Error() == "booking not found" {
+		switch {
+		case strings.Contains(err.Error(), "can no longer be changed"),
+			strings.Contains(err.Error(), "status has already changed"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		case strings.Contains(err.Error(), "booking not found"):
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
-		} else {
+		case strings.Contains(err.Error(), "provider is already booked"),
+			strings.Contains(err.Error(), "provider is not working"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		default:
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
 		}
 		h.Log.Error(er)
@@ -171,12 +250,16 @@ func (h *Handler) UpdateBooking(c *gin.Context) {
 
 // CancelBooking godoc
 // @Summary Cancels a booking by ID
-// @Description Cancels booking by booking ID
+// @Description Cancels booking by booking ID. The provider's cancellation policy may charge a fee depending on how close to the scheduled time the booking is canceled; the result carries it as cancellation_fee. The fee is kept from the payment and the rest refunded
 // @Tags bookings
 // @Security ApiKeyAuth
 // @Param id path string true "Booking ID"
-// @Success 200 {object} booking.BookingResponse
+// @Param reason query string false "Cancellation reason"
+// @Param wait query string false "Wait up to this long for the outcome, e.g. 5s"
+// @Success 200 {object} models.Operation "Request processed"
+// @Success 202 {object} models.Operation "Request accepted, poll /operations/{id} for the outcome"
 // @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Booking not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/bookings/{id} [delete]
@@ -191,7 +274,15 @@ func (h *Handler) CancelBooking(c *gin.Context) {
 		return
 	}
 
-	req1 := &pb.IdRequest{Id: id}
+	userID, ok := c.Get("user_id")
+	if !ok {
+		er := errors.New("user id not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	req1 := &pb.BookingTransitionRequest{Id: id, ActorId: userID.(string), Reason: c.Query("reason")}
 	body, err := json.Marshal(req1)
 	if err != nil {
 		er := errors.Wrap(err, "invalid booking data").Error()
@@ -199,7 +290,7 @@ func (h *Handler) CancelBooking(c *gin.Context) {
 		h.Log.Error(er)
 		return
 	}
-	err = h.Broker.CancelBooking(body)
+	h.publishOperation(c, h.Broker.CancelBooking, body)
 
 	//ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	//defer cancel()
@@ -217,7 +308,6 @@ func (h *Handler) CancelBooking(c *gin.Context) {
 	//}
 
 	h.Log.Info("CancelBooking handler is completed")
-	c.JSON(http.StatusOK, gin.H{"booking": "CANCELED BY RABBITMQ"})
 }
 
 // ListBookings godoc
@@ -314,3 +404,349 @@ func (h *Handler) ListBookings1(c *gin.Context) {
 	h.Log.Info("ListBookings handler is completed")
 	c.JSON(http.StatusOK, gin.H{"bookings": resp})
 }
+
+// GetAvailableSlots godoc
+// @Summary Lists free booking slots
+// @Description Lists the start times at which the provider can still take the service on the given day
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param provider_id query string true "Provider ID"
+// @Param service_id query string true "Service ID"
+// @Param date query string true "Day in YYYY-MM-DD format"
+// @Success 200 {object} booking.AvailableSlotsResponse
+// @Failure 400 {object} string "Missing or invalid query parameters"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/bookings/slots [get]
+func (h *Handler) GetAvailableSlots(c *gin.Context) {
+	h.Log.Info("GetAvailableSlots handler is starting")
+
+	req := &pb.AvailableSlotsRequest{
+		ProviderId: c.Query("provider_id"),
+		ServiceId:  c.Query("service_id"),
+		Date:       c.Query("date"),
+	}
+	if req.ProviderId == "" || req.ServiceId == "" || req.Date == "" {
+		er := errors.New("provider_id, service_id and date are required").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Booking.GetAvailableSlots(ctx, req)
+	if err != nil {
+		er := errors.Wrap(err, "error getting available slots").Error()
+		if strings.Contains(err.Error(), "invalid date format") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("GetAvailableSlots handler is completed")
+	c.JSON(http.StatusOK, gin.H{"slots": resp.Slots})
+}
+
+// ConfirmBooking godoc
+// @Summary Confirms a booking
+// @Description Moves a pending booking to confirmed
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Param data body models.BookingTransition false "Reason"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/bookings/{id}/confirm [post]
+func (h *Handler) ConfirmBooking(c *gin.Context) {
+	h.transitionBooking(c, "ConfirmBooking", h.Booking.ConfirmBooking)
+}
+
+// OverrideCancellationFee godoc
+// @Summary Overrides the cancellation fee of a booking
+// @Description Lowers or waives the fee charged for canceling a booking, e.g. to settle a dispute. The difference is refunded and the original fee kept on the booking
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Param data body models.CancellationFeeOverride true "New fee and reason"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid fee or missing reason"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Booking is not canceled"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/bookings/{id}/cancellation-fee [put]
+func (h *Handler) OverrideCancellationFee(c *gin.Context) {
+	h.Log.Info("OverrideCancellationFee handler is starting")
+
+	var body models.CancellationFeeOverride
+	if err := c.ShouldBindJSON(&body); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Booking.OverrideCancellationFee(ctx, &pb.CancellationFeeOverrideRequest{
+		BookingId: c.Param("id"),
+		Fee:       body.Fee,
+		Reason:    body.Reason,
+		ActorId:   c.GetString("user_id"),
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error overriding cancellation fee").Error()
+		switch {
+		case strings.Contains(er, "not found"):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		case strings.Contains(er, "booking is not canceled"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		case strings.Contains(er, "invalid cancellation fee override"), strings.Contains(er, "reason is required"):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("OverrideCancellationFee handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListIncomingBookings godoc
+// @Summary Lists incoming booking requests
+// @Description Lists pending bookings addressed to the current provider, oldest first
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param page query int false "Page number"
+// @Param limit query int false "Page limit"
+// @Success 200 {object} booking.ListBookingsResponse
+// @Failure 400 {object} string "Invalid pagination"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings [get]
+func (h *Handler) ListIncomingBookings(c *gin.Context) {
+	h.Log.Info("ListIncomingBookings handler is starting")
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		er := "invalid page number"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		er := "invalid limit value"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+	resp, err := h.Booking.ListIncomingBookings(ctx, &pb.ListIncomingBookingsRequest{
+		ProviderUserId: c.MustGet("user_id").(string),
+		Page:           int32(page),
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error listing incoming bookings").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ListIncomingBookings handler is completed")
+	c.JSON(http.StatusOK, gin.H{"bookings": resp})
+}
+
+// AcceptBooking godoc
+// @Summary Accepts a booking request
+// @Description Provider accepts a pending booking addressed to them
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Booking belongs to another provider"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/accept [post]
+func (h *Handler) AcceptBooking(c *gin.Context) {
+	h.transitionBooking(c, "AcceptBooking", h.Booking.AcceptBooking)
+}
+
+// RejectBooking godoc
+// @Summary Rejects a booking
+// @Description Moves a pending booking to rejected
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Param data body models.BookingTransition false "Reason"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/bookings/{id}/reject [post]
+func (h *Handler) RejectBooking(c *gin.Context) {
+	h.transitionBooking(c, "RejectBooking", h.Booking.RejectBooking)
+}
+
+// DeclineBooking godoc
+// @Summary Rejects a booking request
+// @Description Provider rejects a pending booking addressed to them
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Param data body models.BookingTransition false "Reason"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Booking belongs to another provider"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/reject [post]
+func (h *Handler) DeclineBooking(c *gin.Context) {
+	h.transitionBooking(c, "DeclineBooking", h.Booking.DeclineBooking)
+}
+
+// MarkEnRoute godoc
+// @Summary Marks washer as en route
+// @Description Moves a confirmed booking to en_route
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/en-route [post]
+func (h *Handler) MarkEnRoute(c *gin.Context) {
+	h.transitionBooking(c, "MarkEnRoute", h.Booking.MarkEnRoute)
+}
+
+// StartBooking godoc
+// @Summary Starts the wash
+// @Description Moves an en_route booking to in_progress
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/start [post]
+func (h *Handler) StartBooking(c *gin.Context) {
+	h.transitionBooking(c, "StartBooking", h.Booking.StartBooking)
+}
+
+// CompleteBooking godoc
+// @Summary Completes the wash
+// @Description Moves an in_progress booking to completed
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/complete [post]
+func (h *Handler) CompleteBooking(c *gin.Context) {
+	h.transitionBooking(c, "CompleteBooking", h.Booking.CompleteBooking)
+}
+
+// MarkNoShow godoc
+// @Summary Marks customer as no-show
+// @Description Moves a confirmed or en_route booking to no_show
+// @Tags bookings
+// @Security ApiKeyAuth
+// @Param id path string true "Booking ID"
+// @Param data body models.BookingTransition false "Reason"
+// @Success 200 {object} booking.BookingResponse
+// @Failure 400 {object} string "Invalid booking ID"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Booking not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/bookings/{id}/no-show [post]
+func (h *Handler) MarkNoShow(c *gin.Context) {
+	h.transitionBooking(c, "MarkNoShow", h.Booking.MarkNoShow)
+}
+
+type bookingTransitionFunc func(ctx context.Context, in *pb.BookingTransitionRequest, opts ...grpc.CallOption) (*pb.BookingResponse, error)
+
+// transitionBooking runs one booking lifecycle RPC on behalf of the current user.
+func (h *Handler) transitionBooking(c *gin.Context, name string, transition bookingTransitionFunc) {
+	h.Log.Info(name + " handler is starting")
+
+	id := c.Param("id")
+	if id == "" {
+		er := errors.New("booking ID not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		er := errors.New("user id not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	var body models.BookingTransition
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			er := errors.Wrap(err, "invalid data").Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+			h.Log.Error(er)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := transition(ctx, &pb.BookingTransitionRequest{
+		Id:      id,
+		ActorId: userID.(string),
+		Reason:  body.Reason,
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error changing booking status").Error()
+		switch {
+		case strings.Contains(err.Error(), "invalid booking status transition"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		case strings.Contains(err.Error(), "booking is not assigned to this provider"):
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+		case strings.Contains(err.Error(), "booking not found"):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info(name + " handler is completed")
+	c.JSON(http.StatusOK, gin.H{"booking": resp})
+}
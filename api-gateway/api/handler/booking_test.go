@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"Api_Gateway/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteBookingOrganization(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		orgID  string
+		status int
+	}{
+		{name: "Personal", userID: "user123", status: http.StatusOK},
+		{name: "Member", userID: "user123", orgID: "org123", status: http.StatusOK},
+		{name: "NotMember", userID: "user456", orgID: "org123", status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			h.Organization.(*organizations).members[[2]string{"org123", "user123"}] = "driver"
+
+			w := serve(h.QuoteBooking, tt.userID, http.MethodPost, "/customer/bookings/quote", models.Booking{
+				ProviderID:     "provider123",
+				ServiceID:      "service123",
+				ScheduledTime:  "2030-01-08T10:00:00Z",
+				OrganizationID: tt.orgID,
+				VehicleClass:   "sedan",
+			})
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+
+			quoted := h.Booking.(*bookings).quoted
+			if tt.status != http.StatusOK {
+				require.Empty(t, quoted)
+				return
+			}
+			require.Len(t, quoted, 1)
+			require.Equal(t, tt.orgID, quoted[0].OrganizationId, "the quote is priced for the organization it bills")
+			require.Equal(t, tt.userID, quoted[0].UserId)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ListDeadLetters godoc
+// @Summary Lists dead-lettered messages
+// @Description Lists broker messages that failed for good, newest first
+// @Tags dead-letters
+// @Security ApiKeyAuth
+// @Param queue query string false "Original queue, e.g. create_booking"
+// @Param status query string false "dead or replayed"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page limit"
+// @Success 200 {object} booking.ListDeadLettersResponse
+// @Failure 400 {object} string "Invalid pagination"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/dead-letters [get]
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	h.Log.Info("ListDeadLetters handler is starting")
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		er := "invalid page number"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		er := "invalid limit value"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.DeadLetter.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{
+		Queue:  c.Query("queue"),
+		Status: c.Query("status"),
+		Page:   int32(page),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error listing dead letters").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ListDeadLetters handler is completed")
+	c.JSON(http.StatusOK, gin.H{"dead_letters": resp.DeadLetters})
+}
+
+// GetDeadLetter godoc
+// @Summary Inspects a dead-lettered message
+// @Description Returns the body, last error and attempt count of a dead-lettered message
+// @Tags dead-letters
+// @Security ApiKeyAuth
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} booking.DeadLetter
+// @Failure 404 {object} string "Dead letter not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/dead-letters/{id} [get]
+func (h *Handler) GetDeadLetter(c *gin.Context) {
+	h.Log.Info("GetDeadLetter handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.DeadLetter.GetDeadLetter(ctx, &pb.IdRequest{Id: c.Param("id")})
+	if err != nil {
+		h.abortDeadLetterError(c, err, "error getting dead letter")
+		return
+	}
+
+	h.Log.Info("GetDeadLetter handler is completed")
+	c.JSON(http.StatusOK, gin.H{"dead_letter": resp})
+}
+
+// ReplayDeadLetter godoc
+// @Summary Replays a dead-lettered message
+// @Description Publishes the message to its original queue again with a fresh retry budget
+// @Tags dead-letters
+// @Security ApiKeyAuth
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} booking.DeadLetter
+// @Failure 404 {object} string "Dead letter not found"
+// @Failure 409 {object} string "Dead letter already replayed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/dead-letters/{id}/replay [post]
+func (h *Handler) ReplayDeadLetter(c *gin.Context) {
+	h.Log.Info("ReplayDeadLetter handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.DeadLetter.ReplayDeadLetter(ctx, &pb.IdRequest{Id: c.Param("id")})
+	if err != nil {
+		h.abortDeadLetterError(c, err, "error replaying dead letter")
+		return
+	}
+
+	h.Log.Info("ReplayDeadLetter handler is completed")
+	c.JSON(http.StatusOK, gin.H{"dead_letter": resp})
+}
+
+// DeleteDeadLetter godoc
+// @Summary Deletes a dead-lettered message
+// @Description Drops a single dead-lettered message for good
+// @Tags dead-letters
+// @Security ApiKeyAuth
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} string "Dead letter deleted"
+// @Failure 404 {object} string "Dead letter not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/dead-letters/{id} [delete]
+func (h *Handler) DeleteDeadLetter(c *gin.Context) {
+	h.Log.Info("DeleteDeadLetter handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	_, err := h.DeadLetter.DeleteDeadLetter(ctx, &pb.IdRequest{Id: c.Param("id")})
+	if err != nil {
+		h.abortDeadLetterError(c, err, "error deleting dead letter")
+		return
+	}
+
+	h.Log.Info("DeleteDeadLetter handler is completed")
+	c.JSON(http.StatusOK, gin.H{"message": "dead letter deleted"})
+}
+
+// PurgeDeadLetters godoc
+// @Summary Purges dead-lettered messages
+// @Description Drops every dead-lettered message of a queue, or of all queues when no queue is given
+// @Tags dead-letters
+// @Security ApiKeyAuth
+// @Param queue query string false "Original queue, e.g. create_booking"
+// @Success 200 {object} booking.PurgeDeadLettersResponse
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/dead-letters [delete]
+func (h *Handler) PurgeDeadLetters(c *gin.Context) {
+	h.Log.Info("PurgeDeadLetters handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.DeadLetter.PurgeDeadLetters(ctx, &pb.PurgeDeadLettersRequest{Queue: c.Query("queue")})
+	if err != nil {
+		er := errors.Wrap(err, "error purging dead letters").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("PurgeDeadLetters handler is completed")
+	c.JSON(http.StatusOK, gin.H{"deleted": resp.Deleted})
+}
+
+func (h *Handler) abortDeadLetterError(c *gin.Context, err error, msg string) {
+	er := errors.Wrap(err, msg).Error()
+	switch {
+	case strings.Contains(err.Error(), "invalid dead letter ID"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	case strings.Contains(err.Error(), "dead letter not found or not"):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+	case strings.Contains(err.Error(), "dead letter not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
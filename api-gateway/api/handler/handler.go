@@ -6,6 +6,8 @@ import (
 	pb "Api_Gateway/genproto/users"
 	"Api_Gateway/pkg"
 	"Api_Gateway/pkg/logger"
+	"Api_Gateway/pkg/rbac"
+	"github.com/casbin/casbin/v2"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"log/slog"
 	"time"
@@ -18,6 +20,7 @@ type str string
 type Handler struct {
 	User           pb.AuthServiceClient
 	Admin          pb.AdminClient
+	Organization   pb.OrganizationClient
 	Booking        pbb.BookingServiceClient
 	Service        pbb.ServiceManagementServiceClient
 	Payment        pbb.PaymentServiceClient
@@ -25,16 +28,23 @@ type Handler struct {
 	Provider       pbb.ProviderServiceClient
 	Search         pbb.SearchingServiceClient
 	Notification   pbb.NotificationsClient
+	Operation      pbb.OperationServiceClient
+	DeadLetter     pbb.DeadLetterServiceClient
+	Pricing        pbb.PricingServiceClient
 	Log            *slog.Logger
 	ContextTimeout time.Duration
 	UserIDKey      str
 	Broker         *broker.MsgBroker
+	Enforcer       *casbin.SyncedEnforcer
+	Roles          *rbac.RoleStore
+	WebhookSecret  string
 }
 
-func NewHandler(cfg *config.Config, conn *amqp.Channel) *Handler {
+func NewHandler(cfg *config.Config, enforcer *casbin.SyncedEnforcer, roles *rbac.RoleStore, conn *amqp.Channel) *Handler {
 	return &Handler{
 		User:           pkg.NewUserClient(cfg),
 		Admin:          pkg.NewAdminClient(cfg),
+		Organization:   pkg.NewOrganizationClient(cfg),
 		Booking:        pkg.NewBookingClient(cfg),
 		Service:        pkg.NewServiceClient(cfg),
 		Payment:        pkg.NewPaymentClient(cfg),
@@ -42,9 +52,15 @@ func NewHandler(cfg *config.Config, conn *amqp.Channel) *Handler {
 		Provider:       pkg.NewProviderClient(cfg),
 		Notification:   pkg.NewNotificationClient(cfg),
 		Search:         pkg.NewSearchingClient(cfg),
+		Operation:      pkg.NewOperationClient(cfg),
+		DeadLetter:     pkg.NewDeadLetterClient(cfg),
+		Pricing:        pkg.NewPricingClient(cfg),
 		Log:            logger.NewLogger(),
 		ContextTimeout: 5 * time.Second,
 		UserIDKey:      str("user_id"),
 		Broker:         broker.NewMsgBroker(conn, logger.NewLogger()),
+		Enforcer:       enforcer,
+		Roles:          roles,
+		WebhookSecret:  cfg.PAYMENT_WEBHOOK_SECRET,
 	}
 }
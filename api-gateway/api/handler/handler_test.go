@@ -0,0 +1,88 @@
+package handler
+
+import (
+	pbb "Api_Gateway/genproto/booking"
+	pb "Api_Gateway/genproto/users"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// organizations answers membership lookups from members, keyed by organization
+// and user ID. Unknown pairs fail the way the organization service does.
+type organizations struct {
+	pb.OrganizationClient
+	members map[[2]string]string
+}
+
+func (o *organizations) GetMembership(ctx context.Context, in *pb.OrganizationRequest, opts ...grpc.CallOption) (*pb.OrganizationMember, error) {
+	role, ok := o.members[[2]string{in.OrganizationId, in.UserId}]
+	if !ok {
+		return nil, errors.New("user is not a member of the organization")
+	}
+	return &pb.OrganizationMember{OrganizationId: in.OrganizationId, UserId: in.UserId, Role: role}, nil
+}
+
+// bookings serves the bookings in byID and records the quote and list requests it receives.
+type bookings struct {
+	pbb.BookingServiceClient
+	byID   map[string]*pbb.BookingResponse
+	quoted []*pbb.CreateBookingRequest
+	listed []*pbb.ListBookingsRequest
+}
+
+func (b *bookings) GetBooking(ctx context.Context, in *pbb.IdRequest, opts ...grpc.CallOption) (*pbb.BookingResponse, error) {
+	booking, ok := b.byID[in.Id]
+	if !ok {
+		return nil, errors.New("booking not found")
+	}
+	return booking, nil
+}
+
+func (b *bookings) QuoteBooking(ctx context.Context, in *pbb.CreateBookingRequest, opts ...grpc.CallOption) (*pbb.BookingQuote, error) {
+	b.quoted = append(b.quoted, in)
+	return &pbb.BookingQuote{QuoteId: "quote123", TotalPrice: 50}, nil
+}
+
+func (b *bookings) ListBookings(ctx context.Context, in *pbb.ListBookingsRequest, opts ...grpc.CallOption) (*pbb.ListBookingsResponse, error) {
+	b.listed = append(b.listed, in)
+	return &pbb.ListBookingsResponse{}, nil
+}
+
+func newTestHandler() *Handler {
+	return &Handler{
+		Organization:   &organizations{members: make(map[[2]string]string)},
+		Booking:        &bookings{byID: make(map[string]*pbb.BookingResponse)},
+		Log:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ContextTimeout: time.Second,
+	}
+}
+
+// serve runs handle for a request made by userID and returns the recorded response.
+func serve(handle gin.HandlerFunc, userID, method, target string, body interface{}) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	c.Request = httptest.NewRequest(method, target, &buf)
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", userID)
+
+	handle(c)
+	return w
+}
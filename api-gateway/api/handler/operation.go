@@ -0,0 +1,154 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"Api_Gateway/models"
+	"Api_Gateway/producer"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// MaxOperationWait caps how long a request may wait for the outcome of its operation.
+const MaxOperationWait = 30 * time.Second
+
+// GetOperation godoc
+// @Summary Retrieves the outcome of an asynchronous request
+// @Description Returns the status and, once processed, the result or error of an operation
+// @Tags operations
+// @Security ApiKeyAuth
+// @Param id path string true "Operation ID"
+// @Success 200 {object} models.Operation
+// @Failure 400 {object} string "Invalid operation ID"
+// @Failure 404 {object} string "Operation not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /operations/{id} [get]
+func (h *Handler) GetOperation(c *gin.Context) {
+	h.Log.Info("GetOperation handler is starting")
+
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		er := errors.New("invalid operation ID").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Operation.GetOperation(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		if strings.Contains(err.Error(), "operation not found") {
+			// The consumer has not picked the request up yet.
+			c.JSON(http.StatusOK, gin.H{"operation": models.Operation{ID: id, Status: models.OperationPending}})
+			return
+		}
+		er := errors.Wrap(err, "error getting operation").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	if resp.UserId != c.GetString("user_id") && currentRole(c) != "admin" {
+		er := errors.New("operation not found").Error()
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("GetOperation handler is completed")
+	c.JSON(http.StatusOK, gin.H{"operation": operationToModel(resp)})
+}
+
+// publishOperation publishes body as a tracked operation. It answers with the operation ID
+// right away, or with the outcome when the client passes ?wait=<duration> and the
+// booking service replies in time.
+func (h *Handler) publishOperation(c *gin.Context, publish func(producer.Operation, []byte) error, body []byte) {
+	var wait time.Duration
+	if w := c.Query("wait"); w != "" {
+		d, err := time.ParseDuration(w)
+		if err != nil || d < 0 {
+			er := errors.New("wait must be a duration such as 5s").Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+			h.Log.Error(er)
+			return
+		}
+		wait = min(d, MaxOperationWait)
+	}
+
+	op := producer.Operation{
+		ID:     uuid.NewString(),
+		UserID: c.GetString("user_id"),
+		Reply:  wait > 0,
+	}
+
+	var reply <-chan []byte
+	if op.Reply {
+		var release func()
+		reply, release = h.Broker.Await(op.ID)
+		defer release()
+	}
+
+	if err := publish(op, body); err != nil {
+		er := errors.Wrap(err, "error publishing request").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	if op.Reply {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case body := <-reply:
+			var resp pb.OperationResponse
+			if err := json.Unmarshal(body, &resp); err == nil {
+				c.JSON(http.StatusOK, gin.H{"operation": operationToModel(&resp)})
+				return
+			}
+			h.Log.Error("invalid operation reply", "operation_id", op.ID)
+		case <-timer.C:
+		case <-c.Request.Context().Done():
+		}
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"operation": models.Operation{ID: op.ID, Status: models.OperationPending}})
+}
+
+func operationToModel(op *pb.OperationResponse) models.Operation {
+	m := models.Operation{
+		ID:        op.Id,
+		Kind:      op.Kind,
+		Status:    op.Status,
+		Error:     op.Error,
+		CreatedAt: op.CreatedAt,
+		UpdatedAt: op.UpdatedAt,
+	}
+	if op.Result != "" {
+		m.Result = json.RawMessage(op.Result)
+	}
+	return m
+}
+
+// currentRole returns the role claim of the authenticated user.
+func currentRole(c *gin.Context) string {
+	claims, ok := c.Get("claims")
+	if !ok {
+		return ""
+	}
+	mapClaims, ok := claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	role, _ := mapClaims["role"].(string)
+	return role
+}
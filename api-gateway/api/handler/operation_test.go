@@ -0,0 +1,84 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// operations serves the recorded operations in byID.
+type operations struct {
+	pb.OperationServiceClient
+	byID map[string]*pb.OperationResponse
+}
+
+func (o *operations) GetOperation(ctx context.Context, in *pb.IdRequest, opts ...grpc.CallOption) (*pb.OperationResponse, error) {
+	op, ok := o.byID[in.Id]
+	if !ok {
+		return nil, errors.New("operation not found")
+	}
+	return op, nil
+}
+
+func TestGetOperation(t *testing.T) {
+	const (
+		recorded = "6f1c2a4e-8b3d-4f5a-9c7e-1d2b3a4c5e6f"
+		unknown  = "0a9b8c7d-6e5f-4a3b-2c1d-0e9f8a7b6c5d"
+	)
+
+	tests := []struct {
+		name   string
+		id     string
+		userID string
+		role   string
+		status int
+		state  string
+	}{
+		{name: "Owner", id: recorded, userID: "user123", status: http.StatusOK, state: "succeeded"},
+		{name: "Admin", id: recorded, userID: "admin123", role: "admin", status: http.StatusOK, state: "succeeded"},
+		{name: "OtherUser", id: recorded, userID: "user456", status: http.StatusNotFound},
+		{name: "NotProcessedYet", id: unknown, userID: "user123", status: http.StatusOK, state: "pending"},
+		{name: "InvalidID", id: "op123", userID: "user123", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			h.Operation = &operations{byID: map[string]*pb.OperationResponse{
+				recorded: {Id: recorded, Kind: "booking.create", Status: "succeeded", UserId: "user123", Result: `{"id":"booking123"}`},
+			}}
+
+			w := serve(func(c *gin.Context) {
+				c.Params = gin.Params{{Key: "id", Value: tt.id}}
+				c.Set("claims", jwt.MapClaims{"role": tt.role})
+				h.GetOperation(c)
+			}, tt.userID, http.MethodGet, "/operations/"+tt.id, nil)
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Operation struct {
+					ID     string          `json:"id"`
+					Status string          `json:"status"`
+					Result json.RawMessage `json:"result"`
+				} `json:"operation"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, tt.id, resp.Operation.ID)
+			require.Equal(t, tt.state, resp.Operation.Status)
+			if tt.state == "succeeded" {
+				require.JSONEq(t, `{"id":"booking123"}`, string(resp.Operation.Result))
+			}
+		})
+	}
+}
@@ -0,0 +1,606 @@
+package handler
+
+import (
+	pbb "Api_Gateway/genproto/booking"
+	pb "Api_Gateway/genproto/users"
+	"Api_Gateway/models"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// CreateOrganization godoc
+// @Summary Creates an organization
+// @Description Creates a fleet or company account; the caller becomes its first owner
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param organization body models.CreateOrganization true "Organization details"
+// @Success 201 {object} users.OrganizationResponse
+// @Failure 400 {object} string "Invalid organization data"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations [post]
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	h.Log.Info("CreateOrganization handler is starting")
+
+	var req models.CreateOrganization
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid organization data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.CreateOrganization(ctx, &pb.CreateOrganizationRequest{
+		Name:         req.Name,
+		BillingEmail: req.BillingEmail,
+		OwnerId:      c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error creating organization"))
+		return
+	}
+
+	h.Log.Info("CreateOrganization handler is completed")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListOrganizations godoc
+// @Summary Lists the user's organizations
+// @Description Lists the organizations the caller belongs to together with the caller's role in each
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Success 200 {object} users.OrganizationResponses
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations [get]
+func (h *Handler) ListOrganizations(c *gin.Context) {
+	h.Log.Info("ListOrganizations handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.ListOrganizations(ctx, &pb.Id{UserId: c.GetString("user_id")})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error listing organizations"))
+		return
+	}
+
+	h.Log.Info("ListOrganizations handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetOrganization godoc
+// @Summary Gets an organization
+// @Description Retrieves an organization the caller belongs to
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} users.OrganizationResponse
+// @Failure 400 {object} string "Invalid organization id"
+// @Failure 403 {object} string "Caller is not a member of the organization"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id} [get]
+func (h *Handler) GetOrganization(c *gin.Context) {
+	h.Log.Info("GetOrganization handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.GetOrganization(ctx, &pb.OrganizationRequest{
+		OrganizationId: orgID,
+		UserId:         c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error getting organization"))
+		return
+	}
+
+	h.Log.Info("GetOrganization handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListOrganizationMembers godoc
+// @Summary Lists organization members
+// @Description Lists the members of an organization the caller belongs to
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} users.OrganizationMembers
+// @Failure 400 {object} string "Invalid organization id"
+// @Failure 403 {object} string "Caller is not a member of the organization"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/members [get]
+func (h *Handler) ListOrganizationMembers(c *gin.Context) {
+	h.Log.Info("ListOrganizationMembers handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.ListMembers(ctx, &pb.OrganizationRequest{
+		OrganizationId: orgID,
+		UserId:         c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error listing organization members"))
+		return
+	}
+
+	h.Log.Info("ListOrganizationMembers handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateOrganizationMember godoc
+// @Summary Changes a member's role
+// @Description Changes the role of an organization member. Only owners can change roles and the last owner cannot be demoted
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "Member user ID"
+// @Param role body models.MemberRole true "New role: owner, manager or driver"
+// @Success 200 {object} users.OrganizationMember
+// @Failure 400 {object} string "Invalid id or role"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization or member not found"
+// @Failure 409 {object} string "Organization must keep at least one owner"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/members/{user_id} [put]
+func (h *Handler) UpdateOrganizationMember(c *gin.Context) {
+	h.Log.Info("UpdateOrganizationMember handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		er := errors.Wrap(err, "invalid user id").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	var req models.MemberRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.UpdateMemberRole(ctx, &pb.UpdateMemberRoleRequest{
+		OrganizationId: orgID,
+		ActorId:        c.GetString("user_id"),
+		UserId:         userID,
+		Role:           req.Role,
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error updating organization member"))
+		return
+	}
+
+	h.Log.Info("UpdateOrganizationMember handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveOrganizationMember godoc
+// @Summary Removes a member
+// @Description Removes a member from an organization. Members can leave on their own, owners can remove anyone and managers can remove drivers
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "Member user ID"
+// @Success 200 {string} string "Member removed successfully"
+// @Failure 400 {object} string "Invalid id"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization or member not found"
+// @Failure 409 {object} string "Organization must keep at least one owner"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/members/{user_id} [delete]
+func (h *Handler) RemoveOrganizationMember(c *gin.Context) {
+	h.Log.Info("RemoveOrganizationMember handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		er := errors.Wrap(err, "invalid user id").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	_, err := h.Organization.RemoveMember(ctx, &pb.MemberRequest{
+		OrganizationId: orgID,
+		ActorId:        c.GetString("user_id"),
+		UserId:         userID,
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error removing organization member"))
+		return
+	}
+
+	h.Log.Info("RemoveOrganizationMember handler is completed")
+	c.JSON(http.StatusOK, "Member removed successfully")
+}
+
+// InviteOrganizationMember godoc
+// @Summary Invites a member
+// @Description Emails an invitation to join the organization. Owners and managers can invite, only owners can invite owners
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param invitation body models.InviteMember true "Invitee email and role"
+// @Success 201 {object} users.Invitation
+// @Failure 400 {object} string "Invalid email or role"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 409 {object} string "User is already a member of the organization"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/invitations [post]
+func (h *Handler) InviteOrganizationMember(c *gin.Context) {
+	h.Log.Info("InviteOrganizationMember handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	var req models.InviteMember
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid invitation data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.InviteMember(ctx, &pb.InviteMemberRequest{
+		OrganizationId: orgID,
+		ActorId:        c.GetString("user_id"),
+		Email:          req.Email,
+		Role:           req.Role,
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error inviting organization member"))
+		return
+	}
+
+	h.Log.Info("InviteOrganizationMember handler is completed")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListOrganizationInvitations godoc
+// @Summary Lists pending invitations
+// @Description Lists the invitations of an organization that were not accepted yet
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} users.Invitations
+// @Failure 400 {object} string "Invalid organization id"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/invitations [get]
+func (h *Handler) ListOrganizationInvitations(c *gin.Context) {
+	h.Log.Info("ListOrganizationInvitations handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.ListInvitations(ctx, &pb.OrganizationRequest{
+		OrganizationId: orgID,
+		UserId:         c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error listing invitations"))
+		return
+	}
+
+	h.Log.Info("ListOrganizationInvitations handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeOrganizationInvitation godoc
+// @Summary Revokes an invitation
+// @Description Revokes a pending invitation so its token can no longer be accepted
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {string} string "Invitation revoked successfully"
+// @Failure 400 {object} string "Invalid id or unknown invitation"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/invitations/{invitation_id} [delete]
+func (h *Handler) RevokeOrganizationInvitation(c *gin.Context) {
+	h.Log.Info("RevokeOrganizationInvitation handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+	invitationID := c.Param("invitation_id")
+	if _, err := uuid.Parse(invitationID); err != nil {
+		er := errors.Wrap(err, "invalid invitation id").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	_, err := h.Organization.RevokeInvitation(ctx, &pb.InvitationRequest{
+		OrganizationId: orgID,
+		ActorId:        c.GetString("user_id"),
+		InvitationId:   invitationID,
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error revoking invitation"))
+		return
+	}
+
+	h.Log.Info("RevokeOrganizationInvitation handler is completed")
+	c.JSON(http.StatusOK, "Invitation revoked successfully")
+}
+
+// AcceptOrganizationInvitation godoc
+// @Summary Accepts an invitation
+// @Description Joins the organization with the token from the invitation email. The caller's verified email must match the invitation
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param invitation body models.AcceptInvitation true "Invitation token"
+// @Success 200 {object} users.OrganizationMember
+// @Failure 400 {object} string "Invalid or expired invitation, or email not verified"
+// @Failure 409 {object} string "User is already a member of the organization"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/invitations/accept [post]
+func (h *Handler) AcceptOrganizationInvitation(c *gin.Context) {
+	h.Log.Info("AcceptOrganizationInvitation handler is starting")
+
+	var req models.AcceptInvitation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Organization.AcceptInvitation(ctx, &pb.AcceptInvitationRequest{
+		Token:  req.Token,
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error accepting invitation"))
+		return
+	}
+
+	h.Log.Info("AcceptOrganizationInvitation handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListOrganizationBookings godoc
+// @Summary Lists organization bookings
+// @Description Lists the bookings billed to the organization. Only owners and managers can see them
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page limit"
+// @Success 200 {object} booking.ListBookingsResponse
+// @Failure 400 {object} string "Invalid organization id or pagination parameters"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/bookings [get]
+func (h *Handler) ListOrganizationBookings(c *gin.Context) {
+	h.Log.Info("ListOrganizationBookings handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+	page, limit, ok := h.pagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	if !h.organizationManager(ctx, c, orgID) {
+		return
+	}
+
+	resp, err := h.Booking.ListBookings(ctx, &pbb.ListBookingsRequest{
+		Page:           int32(page),
+		Limit:          int32(limit),
+		OrganizationId: orgID,
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error listing organization bookings").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ListOrganizationBookings handler is completed")
+	c.JSON(http.StatusOK, gin.H{"bookings": resp})
+}
+
+// ListOrganizationPayments godoc
+// @Summary Lists organization payments
+// @Description Lists the payments billed to the organization. Only owners and managers can see them
+// @Tags organizations
+// @Security ApiKeyAuth
+// @Param org_id path string true "Organization ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page limit"
+// @Success 200 {object} booking.ListPaymentsResponse
+// @Failure 400 {object} string "Invalid organization id or pagination parameters"
+// @Failure 403 {object} string "Caller's organization role does not allow this action"
+// @Failure 404 {object} string "Organization not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/organizations/{org_id}/payments [get]
+func (h *Handler) ListOrganizationPayments(c *gin.Context) {
+	h.Log.Info("ListOrganizationPayments handler is starting")
+
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+	page, limit, ok := h.pagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	if !h.organizationManager(ctx, c, orgID) {
+		return
+	}
+
+	resp, err := h.Payment.ListPayments(ctx, &pbb.ListPaymentsRequest{
+		Page:           int32(page),
+		Limit:          int32(limit),
+		OrganizationId: orgID,
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error listing organization payments").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ListOrganizationPayments handler is completed")
+	c.JSON(http.StatusOK, gin.H{"payments": resp})
+}
+
+// organizationID reads and validates the org_id path parameter.
+func (h *Handler) organizationID(c *gin.Context) (string, bool) {
+	orgID := c.Param("org_id")
+	if _, err := uuid.Parse(orgID); err != nil {
+		er := errors.Wrap(err, "invalid organization id").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return "", false
+	}
+	return orgID, true
+}
+
+func (h *Handler) pagination(c *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		er := "invalid page number"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return 0, 0, false
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		er := "invalid limit value"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return 0, 0, false
+	}
+	return page, limit, true
+}
+
+// organizationMember aborts the request unless the user belongs to the organization.
+// Personal requests, without an organization, always pass.
+func (h *Handler) organizationMember(c *gin.Context, orgID, userID string) bool {
+	if orgID == "" {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	_, err := h.Organization.GetMembership(ctx, &pb.OrganizationRequest{
+		OrganizationId: orgID,
+		UserId:         userID,
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error checking organization membership"))
+		return false
+	}
+	return true
+}
+
+// organizationManager aborts the request unless the caller is an owner or a
+// manager of the organization. Drivers only see their own bookings and payments.
+func (h *Handler) organizationManager(ctx context.Context, c *gin.Context, orgID string) bool {
+	member, err := h.Organization.GetMembership(ctx, &pb.OrganizationRequest{
+		OrganizationId: orgID,
+		UserId:         c.GetString("user_id"),
+	})
+	if err != nil {
+		h.abortOrganizationError(c, errors.Wrap(err, "error checking organization membership"))
+		return false
+	}
+	if member.Role != "owner" && member.Role != "manager" {
+		er := "organization role does not allow this action"
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+		h.Log.Error(er)
+		return false
+	}
+	return true
+}
+
+// abortOrganizationError maps the errors of the organization service to HTTP statuses.
+func (h *Handler) abortOrganizationError(c *gin.Context, err error) {
+	er := err.Error()
+	switch {
+	case strings.Contains(er, "not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	case strings.Contains(er, "not a member"), strings.Contains(er, "does not allow"):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+	case strings.Contains(er, "already a member"), strings.Contains(er, "at least one owner"):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+	case strings.Contains(er, "invalid"), strings.Contains(er, "required"),
+		strings.Contains(er, "must be verified"), strings.Contains(er, "another email"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
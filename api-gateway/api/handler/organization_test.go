@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOrganizationBookings(t *testing.T) {
+	const orgID = "3b241101-e2bb-4255-8caf-4136c566a962"
+
+	tests := []struct {
+		name   string
+		orgID  string
+		userID string
+		query  string
+		status int
+	}{
+		{name: "Owner", orgID: orgID, userID: "owner1", status: http.StatusOK},
+		{name: "Manager", orgID: orgID, userID: "manager1", query: "?page=2&limit=5", status: http.StatusOK},
+		{name: "Driver", orgID: orgID, userID: "driver1", status: http.StatusForbidden},
+		{name: "NotMember", orgID: orgID, userID: "user1", status: http.StatusForbidden},
+		{name: "InvalidOrganizationID", orgID: "org1", userID: "owner1", status: http.StatusBadRequest},
+		{name: "InvalidPage", orgID: orgID, userID: "owner1", query: "?page=0", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			members := h.Organization.(*organizations).members
+			members[[2]string{orgID, "owner1"}] = "owner"
+			members[[2]string{orgID, "manager1"}] = "manager"
+			members[[2]string{orgID, "driver1"}] = "driver"
+
+			w := serve(func(c *gin.Context) {
+				c.Params = gin.Params{{Key: "org_id", Value: tt.orgID}}
+				h.ListOrganizationBookings(c)
+			}, tt.userID, http.MethodGet, "/customer/organizations/"+tt.orgID+"/bookings"+tt.query, nil)
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+
+			listed := h.Booking.(*bookings).listed
+			if tt.status != http.StatusOK {
+				require.Empty(t, listed)
+				return
+			}
+			require.Len(t, listed, 1)
+			require.Equal(t, orgID, listed[0].OrganizationId)
+			require.Empty(t, listed[0].UserId, "organization bookings are not filtered by the caller")
+		})
+	}
+}
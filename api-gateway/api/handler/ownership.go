@@ -0,0 +1,63 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+)
+
+// The lookups below return the user that owns a record, for middleware.Ownership.
+
+// BookingOwner returns the customer who made the booking.
+func (h *Handler) BookingOwner(ctx context.Context, id string) (string, error) {
+	booking, err := h.Booking.GetBooking(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return booking.UserId, nil
+}
+
+// BookingProviderOwner returns the user running the provider the booking is addressed to.
+func (h *Handler) BookingProviderOwner(ctx context.Context, id string) (string, error) {
+	booking, err := h.Booking.GetBooking(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return h.ProviderOwner(ctx, booking.ProviderId)
+}
+
+// PaymentOwner returns the customer who made the payment.
+func (h *Handler) PaymentOwner(ctx context.Context, id string) (string, error) {
+	payment, err := h.Payment.GetPayment(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return payment.UserId, nil
+}
+
+// ReviewOwner returns the customer who wrote the review.
+func (h *Handler) ReviewOwner(ctx context.Context, id string) (string, error) {
+	review, err := h.Review.GetReviewById(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return review.UserId, nil
+}
+
+// ProviderOwner returns the user who registered the provider.
+func (h *Handler) ProviderOwner(ctx context.Context, id string) (string, error) {
+	provider, err := h.Provider.GetProvider(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return provider.UserId, nil
+}
+
+// ServiceOwner returns the provider user offering the service. Services created
+// before services had an owner belong to nobody and can only be changed by admins.
+func (h *Handler) ServiceOwner(ctx context.Context, id string) (string, error) {
+	service, err := h.Service.GetServiceByID(ctx, &pb.IdRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return service.UserId, nil
+}
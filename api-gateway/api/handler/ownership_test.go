@@ -0,0 +1,82 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// providers serves the providers in byID.
+type providers struct {
+	pb.ProviderServiceClient
+	byID map[string]*pb.ProviderResponse
+}
+
+func (p *providers) GetProvider(ctx context.Context, in *pb.IdRequest, opts ...grpc.CallOption) (*pb.ProviderResponse, error) {
+	provider, ok := p.byID[in.Id]
+	if !ok {
+		return nil, errors.New("provider not found")
+	}
+	return provider, nil
+}
+
+// services serves the services in byID.
+type services struct {
+	pb.ServiceManagementServiceClient
+	byID map[string]*pb.ServiceResponse
+}
+
+func (s *services) GetServiceByID(ctx context.Context, in *pb.IdRequest, opts ...grpc.CallOption) (*pb.ServiceResponse, error) {
+	service, ok := s.byID[in.Id]
+	if !ok {
+		return nil, errors.New("service not found")
+	}
+	return service, nil
+}
+
+func TestOwnerLookups(t *testing.T) {
+	h := newTestHandler()
+	h.Booking.(*bookings).byID = map[string]*pb.BookingResponse{
+		"booking1": {Id: "booking1", UserId: "customer1", ProviderId: "provider1"},
+		"booking2": {Id: "booking2", UserId: "customer1", ProviderId: "provider2"},
+	}
+	h.Provider = &providers{byID: map[string]*pb.ProviderResponse{
+		"provider1": {Id: "provider1", UserId: "driver1"},
+	}}
+	h.Service = &services{byID: map[string]*pb.ServiceResponse{
+		"service1": {Id: "service1", UserId: "driver1"},
+		"legacy":   {Id: "legacy"},
+	}}
+
+	tests := []struct {
+		name    string
+		lookup  func(ctx context.Context, id string) (string, error)
+		id      string
+		owner   string
+		errText string
+	}{
+		{name: "Booking", lookup: h.BookingOwner, id: "booking1", owner: "customer1"},
+		{name: "BookingNotFound", lookup: h.BookingOwner, id: "booking3", errText: "booking not found"},
+		{name: "BookingProvider", lookup: h.BookingProviderOwner, id: "booking1", owner: "driver1"},
+		{name: "BookingProviderNotFound", lookup: h.BookingProviderOwner, id: "booking2", errText: "provider not found"},
+		{name: "Provider", lookup: h.ProviderOwner, id: "provider1", owner: "driver1"},
+		{name: "Service", lookup: h.ServiceOwner, id: "service1", owner: "driver1"},
+		{name: "ServiceWithoutOwner", lookup: h.ServiceOwner, id: "legacy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, err := tt.lookup(context.Background(), tt.id)
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.owner, owner)
+		})
+	}
+}
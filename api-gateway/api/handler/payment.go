@@ -8,16 +8,21 @@ import (
 	"github.com/pkg/errors"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // CreatePayment godoc
 // @Summary Creates a new payment
-// @Description Processes a payment and returns the payment details
+// @Description Charges the total price of the booking to the payment method. Amount and transaction ID are set by the service. Payment methods needing 3-D Secure stay in requires_action until the payment provider reports the outcome
 // @Tags payments
 // @Security ApiKeyAuth
 // @Param payment body booking.CreatePaymentRequest true "Payment details"
-// @Success 200 {object} booking.PaymentResponse
+// @Param wait query string false "Wait up to this long for the outcome, e.g. 5s"
+// @Success 200 {object} models.Operation "Request processed"
+// @Success 202 {object} models.Operation "Request accepted, poll /operations/{id} for the outcome"
 // @Failure 400 {object} string "Invalid payment data"
+// @Failure 403 {object} string "Booking belongs to another user"
+// @Failure 404 {object} string "Booking not found"
 // @Failure 500 {object} string "Server error while processing payment"
 // @Router /customer/payments [post]
 func (h *Handler) CreatePayment(c *gin.Context) {
@@ -37,6 +42,9 @@ func (h *Handler) CreatePayment(c *gin.Context) {
 		return
 	}
 	req.UserId = Id.(string)
+	if !h.bookingPayer(c, req.BookingId, req.UserId) {
+		return
+	}
 	//ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	//defer cancel()
 
@@ -47,7 +55,7 @@ func (h *Handler) CreatePayment(c *gin.Context) {
 		h.Log.Error(er)
 		return
 	}
-	err = h.Broker.Payment(body)
+	h.publishOperation(c, h.Broker.Payment, body)
 
 	//resp, err := h.Payment.CreatePayment(ctx, req)
 	//if err != nil {
@@ -58,7 +66,35 @@ func (h *Handler) CreatePayment(c *gin.Context) {
 	//}
 
 	h.Log.Info("CreatePayment handler is completed")
-	c.JSON(http.StatusOK, gin.H{"payment": "PAYMENT BY RABBITMQ"})
+}
+
+// bookingPayer aborts the request unless the user made the booking or belongs to
+// the organization it is billed to.
+func (h *Handler) bookingPayer(c *gin.Context, bookingID, userID string) bool {
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	booking, err := h.Booking.GetBooking(ctx, &pb.IdRequest{Id: bookingID})
+	if err != nil {
+		er := errors.Wrap(err, "error getting booking").Error()
+		if strings.Contains(er, "not found") {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return false
+	}
+	if booking.UserId == userID {
+		return true
+	}
+	if booking.OrganizationId == "" {
+		er := "booking belongs to another user"
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+		h.Log.Error(er)
+		return false
+	}
+	return h.organizationMember(c, booking.OrganizationId, userID)
 }
 
 // GetPayment godoc
@@ -69,6 +105,7 @@ func (h *Handler) CreatePayment(c *gin.Context) {
 // @Param id path string true "Payment ID"
 // @Success 200 {object} booking.PaymentResponse
 // @Failure 400 {object} string "Invalid payment ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Payment not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/payments/{id} [get]
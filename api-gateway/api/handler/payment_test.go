@@ -0,0 +1,46 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingPayer(t *testing.T) {
+	tests := []struct {
+		name      string
+		bookingID string
+		userID    string
+		ok        bool
+		status    int
+	}{
+		{name: "Owner", bookingID: "personal", userID: "user123", ok: true},
+		{name: "OrganizationMember", bookingID: "organization", userID: "user456", ok: true},
+		{name: "OtherUser", bookingID: "personal", userID: "user456", status: http.StatusForbidden},
+		{name: "NotMember", bookingID: "organization", userID: "user789", status: http.StatusForbidden},
+		{name: "NotFound", bookingID: "missing", userID: "user123", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			h.Organization.(*organizations).members[[2]string{"org123", "user456"}] = "driver"
+			h.Booking.(*bookings).byID = map[string]*pb.BookingResponse{
+				"personal":     {Id: "personal", UserId: "user123"},
+				"organization": {Id: "organization", UserId: "user123", OrganizationId: "org123"},
+			}
+
+			var ok bool
+			w := serve(func(c *gin.Context) {
+				ok = h.bookingPayer(c, tt.bookingID, c.GetString("user_id"))
+			}, tt.userID, http.MethodPost, "/customer/payments", nil)
+			require.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				require.Equal(t, tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
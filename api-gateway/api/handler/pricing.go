@@ -0,0 +1,181 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// GetPricingDefaults godoc
+// @Summary Gets the platform pricing rules
+// @Description Returns the default pricing rules, including the booking fee and tax rate, that apply to every provider
+// @Tags pricing
+// @Security ApiKeyAuth
+// @Success 200 {object} booking.PricingRules
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/pricing [get]
+func (h *Handler) GetPricingDefaults(c *gin.Context) {
+	h.Log.Info("GetPricingDefaults handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Pricing.GetPricingRules(ctx, &pb.PricingRulesRequest{})
+	if err != nil {
+		h.abortPricingError(c, errors.Wrap(err, "error getting pricing rules"))
+		return
+	}
+
+	h.Log.Info("GetPricingDefaults handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetPricingDefaults godoc
+// @Summary Replaces the platform pricing rules
+// @Description Replaces the default pricing rules. Providers' own rules override everything but the booking fee and tax rate
+// @Tags pricing
+// @Security ApiKeyAuth
+// @Param rules body booking.PricingRules true "Pricing rules"
+// @Success 200 {object} booking.PricingRules
+// @Failure 400 {object} string "Invalid pricing rules"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/pricing [put]
+func (h *Handler) SetPricingDefaults(c *gin.Context) {
+	h.Log.Info("SetPricingDefaults handler is starting")
+
+	var req pb.PricingRules
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid pricing rules").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	req.ProviderId = ""
+	req.UpdatedBy = c.GetString("user_id")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Pricing.SetPricingRules(ctx, &req)
+	if err != nil {
+		h.abortPricingError(c, errors.Wrap(err, "error saving pricing rules"))
+		return
+	}
+
+	h.Log.Info("SetPricingDefaults handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProviderPricing godoc
+// @Summary Gets a provider's pricing rules
+// @Description Returns the pricing rules the provider set on top of the platform defaults
+// @Tags pricing
+// @Security ApiKeyAuth
+// @Param id path string true "Provider ID"
+// @Success 200 {object} booking.PricingRules
+// @Failure 403 {object} string "Caller does not own the provider"
+// @Failure 404 {object} string "Provider not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/{id}/pricing [get]
+// @Router /admin/providers/{id}/pricing [get]
+func (h *Handler) GetProviderPricing(c *gin.Context) {
+	h.Log.Info("GetProviderPricing handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Pricing.GetPricingRules(ctx, &pb.PricingRulesRequest{ProviderId: c.Param("id")})
+	if err != nil {
+		h.abortPricingError(c, errors.Wrap(err, "error getting pricing rules"))
+		return
+	}
+
+	h.Log.Info("GetProviderPricing handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetProviderPricing godoc
+// @Summary Replaces a provider's pricing rules
+// @Description Sets vehicle class multipliers, travel fee, time-of-day rules, the weekend multiplier and the cancellation policy of the provider. Sections left empty fall back to the platform defaults
+// @Tags pricing
+// @Security ApiKeyAuth
+// @Param id path string true "Provider ID"
+// @Param rules body booking.PricingRules true "Pricing rules"
+// @Success 200 {object} booking.PricingRules
+// @Failure 400 {object} string "Invalid pricing rules"
+// @Failure 403 {object} string "Caller does not own the provider"
+// @Failure 404 {object} string "Provider not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/{id}/pricing [put]
+// @Router /admin/providers/{id}/pricing [put]
+func (h *Handler) SetProviderPricing(c *gin.Context) {
+	h.Log.Info("SetProviderPricing handler is starting")
+
+	var req pb.PricingRules
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid pricing rules").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	req.ProviderId = c.Param("id")
+	req.UpdatedBy = c.GetString("user_id")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Pricing.SetPricingRules(ctx, &req)
+	if err != nil {
+		h.abortPricingError(c, errors.Wrap(err, "error saving pricing rules"))
+		return
+	}
+
+	h.Log.Info("SetProviderPricing handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteProviderPricing godoc
+// @Summary Removes a provider's pricing rules
+// @Description Drops the provider's own rules so its bookings are priced with the platform defaults
+// @Tags pricing
+// @Security ApiKeyAuth
+// @Param id path string true "Provider ID"
+// @Success 200 {string} string "Pricing rules deleted successfully"
+// @Failure 403 {object} string "Caller does not own the provider"
+// @Failure 404 {object} string "Provider has no pricing rules"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/{id}/pricing [delete]
+// @Router /admin/providers/{id}/pricing [delete]
+func (h *Handler) DeleteProviderPricing(c *gin.Context) {
+	h.Log.Info("DeleteProviderPricing handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	_, err := h.Pricing.DeletePricingRules(ctx, &pb.PricingRulesRequest{ProviderId: c.Param("id")})
+	if err != nil {
+		h.abortPricingError(c, errors.Wrap(err, "error deleting pricing rules"))
+		return
+	}
+
+	h.Log.Info("DeleteProviderPricing handler is completed")
+	c.JSON(http.StatusOK, "Pricing rules deleted successfully")
+}
+
+// abortPricingError maps the errors of the pricing service to HTTP statuses.
+func (h *Handler) abortPricingError(c *gin.Context, err error) {
+	er := err.Error()
+	switch {
+	case strings.Contains(er, "not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	case strings.Contains(er, "invalid"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
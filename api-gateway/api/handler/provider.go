@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // RegisterProvider godoc
@@ -39,6 +40,11 @@ func (h *Handler) RegisterProvider(c *gin.Context) {
 		return
 	}
 
+	schedule := make([]*pb.WorkingHours, 0, len(req.Schedule))
+	for _, wh := range req.Schedule {
+		schedule = append(schedule, &pb.WorkingHours{Weekday: wh.Weekday, Start: wh.Start, End: wh.End})
+	}
+
 	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	defer cancel()
 
@@ -50,10 +56,17 @@ func (h *Handler) RegisterProvider(c *gin.Context) {
 		Availability:  req.Availability,
 		AverageRating: req.AverageRating,
 		Location:      &pb.GeoPoint{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+		Schedule:      schedule,
+		Holidays:      req.Holidays,
+		Timezone:      req.Timezone,
 	})
 	if err != nil {
 		er := errors.Wrap(err, "error registering provider").Error()
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		if strings.Contains(er, "invalid timezone") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
 		h.Log.Error(er)
 		return
 	}
@@ -246,6 +259,7 @@ func (h *Handler) GetProvider1(c *gin.Context) {
 // @Param id path string true "Provider ID"
 // @Success 200 {object} booking.ProviderResponse
 // @Failure 400 {object} string "Invalid provider ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Provider not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /provider/{id} [get]
@@ -288,6 +302,7 @@ func (h *Handler) GetProvider2(c *gin.Context) {
 // @Param provider body booking.UpdateProviderRequest true "Updated provider details"
 // @Success 200 {object} booking.ProviderResponse
 // @Failure 400 {object} string "Invalid provider data or ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Provider not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /provider/{id} [put]
@@ -319,6 +334,8 @@ func (h *Handler) UpdateProvider(c *gin.Context) {
 		er := errors.Wrap(err, "error updating provider").Error()
 		if err.Error() == "provider not found" {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		} else if strings.Contains(er, "invalid timezone") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
 		} else {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
 		}
@@ -338,6 +355,7 @@ func (h *Handler) UpdateProvider(c *gin.Context) {
 // @Param id path string true "Provider ID"
 // @Success 204 {object} string "No Content"
 // @Failure 400 {object} string "Invalid provider ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Provider not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /provider/{id} [delete]
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"Api_Gateway/models"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// maxDocumentSize matches the default MAX_DOCUMENT_SIZE of the booking service.
+const maxDocumentSize = 3 << 20
+
+// UploadProviderDocument godoc
+// @Summary Uploads a provider application document
+// @Description Attaches a business license or insurance document (PDF, JPEG or PNG) to the caller's provider application
+// @Tags providers
+// @Security ApiKeyAuth
+// @Accept multipart/form-data
+// @Param id path string true "Provider ID"
+// @Param type formData string true "business_license or insurance"
+// @Param file formData file true "Document file"
+// @Success 200 {object} booking.ProviderDocument
+// @Failure 400 {object} string "Invalid document"
+// @Failure 403 {object} string "Provider is not owned by the caller"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Provider not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/{id}/documents [post]
+func (h *Handler) UploadProviderDocument(c *gin.Context) {
+	h.Log.Info("UploadProviderDocument handler is starting")
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		er := errors.New("user id not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		er := errors.Wrap(err, "document file not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	if header.Size > maxDocumentSize {
+		er := "document is larger than " + strconv.Itoa(maxDocumentSize) + " bytes"
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		er := errors.Wrap(err, "error reading document").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxDocumentSize))
+	if err != nil {
+		er := errors.Wrap(err, "error reading document").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Provider.UploadProviderDocument(ctx, &pb.UploadProviderDocumentRequest{
+		ProviderId: c.Param("id"),
+		UserId:     userID.(string),
+		Type:       c.PostForm("type"),
+		FileName:   header.Filename,
+		Content:    content,
+	})
+	if err != nil {
+		h.abortProviderError(c, err, "error uploading document")
+		return
+	}
+
+	h.Log.Info("UploadProviderDocument handler is completed")
+	c.JSON(http.StatusOK, gin.H{"document": resp})
+}
+
+// GetProviderDocument godoc
+// @Summary Downloads a provider application document
+// @Description Returns a document of the caller's provider application
+// @Tags providers
+// @Security ApiKeyAuth
+// @Produce octet-stream
+// @Param id path string true "Provider ID"
+// @Param document_id path string true "Document ID"
+// @Success 200 {file} file "Document file"
+// @Failure 403 {object} string "Provider is not owned by the caller"
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Provider or document not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /provider/{id}/documents/{document_id} [get]
+func (h *Handler) GetProviderDocument(c *gin.Context) {
+	h.Log.Info("GetProviderDocument handler is starting")
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		er := errors.New("user id not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.sendProviderDocument(c, userID.(string))
+	h.Log.Info("GetProviderDocument handler is completed")
+}
+
+// ReviewProviderDocument godoc
+// @Summary Downloads a document of a provider application
+// @Description Lets an admin read any document attached to a provider application
+// @Tags provider-applications
+// @Security ApiKeyAuth
+// @Produce octet-stream
+// @Param id path string true "Provider ID"
+// @Param document_id path string true "Document ID"
+// @Success 200 {file} file "Document file"
+// @Failure 404 {object} string "Provider or document not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/providers/{id}/documents/{document_id} [get]
+func (h *Handler) ReviewProviderDocument(c *gin.Context) {
+	h.Log.Info("ReviewProviderDocument handler is starting")
+	h.sendProviderDocument(c, "")
+	h.Log.Info("ReviewProviderDocument handler is completed")
+}
+
+// ListProviderApplications godoc
+// @Summary Lists provider applications
+// @Description Lists provider applications oldest first, by default the ones waiting for review (submitted and under_review)
+// @Tags provider-applications
+// @Security ApiKeyAuth
+// @Param status query string false "submitted, under_review, approved, rejected or suspended"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page limit"
+// @Success 200 {object} booking.ListProvidersResponse
+// @Failure 400 {object} string "Invalid filter"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/provider-applications [get]
+func (h *Handler) ListProviderApplications(c *gin.Context) {
+	h.Log.Info("ListProviderApplications handler is starting")
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		er := "invalid page number"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		er := "invalid limit value"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Provider.ListProviderApplications(ctx, &pb.ListProviderApplicationsRequest{
+		Status: c.Query("status"),
+		Page:   int32(page),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		h.abortProviderError(c, err, "error listing provider applications")
+		return
+	}
+
+	h.Log.Info("ListProviderApplications handler is completed")
+	c.JSON(http.StatusOK, gin.H{"providers": resp.Providers})
+}
+
+// ReviewProvider godoc
+// @Summary Reviews a provider application
+// @Description Moves a provider application to under_review, approved, rejected or suspended. Approval needs a business license and insurance document, rejection and suspension need a reason.
+// @Tags provider-applications
+// @Security ApiKeyAuth
+// @Param id path string true "Provider ID"
+// @Param review body models.ProviderReview true "Decision"
+// @Success 200 {object} booking.ProviderResponse
+// @Failure 400 {object} string "Invalid decision"
+// @Failure 404 {object} string "Provider not found"
+// @Failure 409 {object} string "Status change not allowed"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/providers/{id}/review [post]
+func (h *Handler) ReviewProvider(c *gin.Context) {
+	h.Log.Info("ReviewProvider handler is starting")
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		er := errors.New("user id not provided").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	var req models.ProviderReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Provider.ReviewProvider(ctx, &pb.ReviewProviderRequest{
+		ProviderId: c.Param("id"),
+		Status:     req.Status,
+		ReviewerId: userID.(string),
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.abortProviderError(c, err, "error reviewing provider")
+		return
+	}
+
+	h.Log.Info("ReviewProvider handler is completed")
+	c.JSON(http.StatusOK, gin.H{"provider": resp})
+}
+
+// sendProviderDocument writes the requested document as the response body. An empty
+// userID reads the document without an ownership check.
+func (h *Handler) sendProviderDocument(c *gin.Context, userID string) {
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Provider.GetProviderDocument(ctx, &pb.ProviderDocumentRequest{
+		ProviderId: c.Param("id"),
+		DocumentId: c.Param("document_id"),
+		UserId:     userID,
+	})
+	if err != nil {
+		h.abortProviderError(c, err, "error getting document")
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": resp.Document.FileName})
+	c.Header("Content-Disposition", disposition)
+	c.Data(http.StatusOK, resp.Document.ContentType, resp.Content)
+}
+
+func (h *Handler) abortProviderError(c *gin.Context, err error, msg string) {
+	er := errors.Wrap(err, msg).Error()
+	switch {
+	case strings.Contains(err.Error(), "invalid document"),
+		strings.Contains(err.Error(), "reason is required"),
+		strings.Contains(err.Error(), "unknown provider status"),
+		strings.Contains(err.Error(), "invalid provider ID"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	case strings.Contains(err.Error(), "provider is not owned by this user"):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": er})
+	case strings.Contains(err.Error(), "invalid provider status transition"),
+		strings.Contains(err.Error(), "required documents are missing"),
+		strings.Contains(err.Error(), "its status has already changed"):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+	case strings.Contains(err.Error(), "provider not found"),
+		strings.Contains(err.Error(), "document not found"),
+		strings.Contains(err.Error(), "blob not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
@@ -0,0 +1,442 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/users"
+	"Api_Gateway/models"
+	"Api_Gateway/pkg/rbac"
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// permissionMethods are the HTTP methods a permission can be granted for.
+var permissionMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// rbacPath is the prefix of the role management API. The admin role keeps its
+// permissions there so admins cannot lock themselves out.
+const rbacPath = "/api/v1/admin/roles"
+
+// ListRoles godoc
+// @Summary Lists roles
+// @Description Lists all roles with the permissions granted to them
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.RolePermissions
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles [get]
+func (h *Handler) ListRoles(c *gin.Context) {
+	h.Log.Info("ListRoles handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	roles, err := h.Roles.List(ctx)
+	if err != nil {
+		h.abortRBACError(c, err, "error listing roles")
+		return
+	}
+
+	resp := make([]models.RolePermissions, 0, len(roles))
+	for _, role := range roles {
+		resp = append(resp, models.RolePermissions{Role: role, Permissions: h.permissions(role.Name)})
+	}
+
+	h.Log.Info("ListRoles handler is completed")
+	c.JSON(http.StatusOK, gin.H{"roles": resp})
+}
+
+// CreateRole godoc
+// @Summary Creates a role
+// @Description Creates a role without permissions; grant them with /admin/roles/{role}/permissions
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param role body models.CreateRole true "Role"
+// @Success 201 {object} models.Role
+// @Failure 400 {object} string "Invalid role"
+// @Failure 409 {object} string "Role already exists"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles [post]
+func (h *Handler) CreateRole(c *gin.Context) {
+	h.Log.Info("CreateRole handler is starting")
+
+	var req models.CreateRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	if !validRoleName(req.Name) {
+		er := "role name must be 2-20 lowercase letters, digits, '-' or '_'"
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	role, err := h.Roles.Create(ctx, req.Name, req.Description)
+	if err != nil {
+		h.abortRBACError(c, err, "error creating role")
+		return
+	}
+
+	h.Log.Info("CreateRole handler is completed")
+	c.JSON(http.StatusCreated, gin.H{"role": role})
+}
+
+// DeleteRole godoc
+// @Summary Deletes a role
+// @Description Deletes a custom role together with its permissions and user assignments
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param role path string true "Role name"
+// @Success 200 {object} string "Role deleted"
+// @Failure 404 {object} string "Role not found"
+// @Failure 409 {object} string "Built-in role or role still in use"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles/{role} [delete]
+func (h *Handler) DeleteRole(c *gin.Context) {
+	h.Log.Info("DeleteRole handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	role := c.Param("role")
+	if err := h.Roles.Delete(ctx, role); err != nil {
+		h.abortRBACError(c, err, "error deleting role")
+		return
+	}
+	if _, err := h.Enforcer.DeleteRole(role); err != nil {
+		h.abortRBACError(c, err, "error deleting role policies")
+		return
+	}
+
+	h.Log.Info("DeleteRole handler is completed")
+	c.JSON(http.StatusOK, gin.H{"message": "role deleted"})
+}
+
+// ListPermissions godoc
+// @Summary Lists the permissions of a role
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param role path string true "Role name"
+// @Success 200 {object} []models.Permission
+// @Failure 404 {object} string "Role not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles/{role}/permissions [get]
+func (h *Handler) ListPermissions(c *gin.Context) {
+	h.Log.Info("ListPermissions handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	role := c.Param("role")
+	if err := h.checkRole(ctx, role); err != nil {
+		h.abortRBACError(c, err, "error listing permissions")
+		return
+	}
+
+	h.Log.Info("ListPermissions handler is completed")
+	c.JSON(http.StatusOK, gin.H{"permissions": h.permissions(role)})
+}
+
+// GrantPermission godoc
+// @Summary Grants a permission to a role
+// @Description Allows the role to call a path, as registered in the router, with a method. Takes effect on every gateway instance.
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param role path string true "Role name"
+// @Param permission body models.Permission true "Permission"
+// @Success 200 {object} []models.Permission
+// @Failure 400 {object} string "Invalid permission"
+// @Failure 404 {object} string "Role not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles/{role}/permissions [post]
+func (h *Handler) GrantPermission(c *gin.Context) {
+	h.Log.Info("GrantPermission handler is starting")
+
+	role := c.Param("role")
+	perm, ok := h.bindPermission(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	if err := h.checkRole(ctx, role); err != nil {
+		h.abortRBACError(c, err, "error granting permission")
+		return
+	}
+	if _, err := h.Enforcer.AddPolicy(role, perm.Path, perm.Method); err != nil {
+		h.abortRBACError(c, err, "error granting permission")
+		return
+	}
+
+	h.Log.Info("GrantPermission handler is completed")
+	c.JSON(http.StatusOK, gin.H{"permissions": h.permissions(role)})
+}
+
+// RevokePermission godoc
+// @Summary Revokes a permission from a role
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param role path string true "Role name"
+// @Param permission body models.Permission true "Permission"
+// @Success 200 {object} []models.Permission
+// @Failure 400 {object} string "Invalid permission"
+// @Failure 404 {object} string "Role or permission not found"
+// @Failure 409 {object} string "Protected permission"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/roles/{role}/permissions [delete]
+func (h *Handler) RevokePermission(c *gin.Context) {
+	h.Log.Info("RevokePermission handler is starting")
+
+	role := c.Param("role")
+	perm, ok := h.bindPermission(c)
+	if !ok {
+		return
+	}
+	if role == "admin" && strings.HasPrefix(perm.Path, rbacPath) {
+		er := "admins cannot lose access to role management"
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	removed, err := h.Enforcer.RemovePolicy(role, perm.Path, perm.Method)
+	if err != nil {
+		h.abortRBACError(c, err, "error revoking permission")
+		return
+	}
+	if !removed {
+		er := "permission not found"
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("RevokePermission handler is completed")
+	c.JSON(http.StatusOK, gin.H{"permissions": h.permissions(role)})
+}
+
+// GetUserRoles godoc
+// @Summary Lists the roles of a user
+// @Description Returns the role of the user's profile and the roles assigned on top of it
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.UserRoles
+// @Failure 400 {object} string "Invalid user ID"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/users/{id}/roles [get]
+func (h *Handler) GetUserRoles(c *gin.Context) {
+	h.Log.Info("GetUserRoles handler is starting")
+
+	id, ok := h.userIDParam(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.userRoles(ctx, id)
+	if err != nil {
+		h.abortRBACError(c, err, "error getting user roles")
+		return
+	}
+
+	h.Log.Info("GetUserRoles handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// AssignRole godoc
+// @Summary Assigns a role to a user
+// @Description Gives the user the permissions of another role in addition to the role of their profile
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param role body models.AssignRole true "Role"
+// @Success 200 {object} models.UserRoles
+// @Failure 400 {object} string "Invalid data"
+// @Failure 404 {object} string "Role not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/users/{id}/roles [post]
+func (h *Handler) AssignRole(c *gin.Context) {
+	h.Log.Info("AssignRole handler is starting")
+
+	id, ok := h.userIDParam(c)
+	if !ok {
+		return
+	}
+	var req models.AssignRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	if err := h.checkRole(ctx, req.Role); err != nil {
+		h.abortRBACError(c, err, "error assigning role")
+		return
+	}
+	if _, err := h.Enforcer.AddRoleForUser(id, req.Role); err != nil {
+		h.abortRBACError(c, err, "error assigning role")
+		return
+	}
+
+	resp, err := h.userRoles(ctx, id)
+	if err != nil {
+		h.abortRBACError(c, err, "error getting user roles")
+		return
+	}
+
+	h.Log.Info("AssignRole handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// UnassignRole godoc
+// @Summary Removes an assigned role from a user
+// @Description Removes a role assigned with POST /admin/users/{id}/roles. The role of the profile is changed with PUT /admin/user.
+// @Tags rbac
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param role path string true "Role name"
+// @Success 200 {object} models.UserRoles
+// @Failure 400 {object} string "Invalid user ID"
+// @Failure 404 {object} string "Role not assigned"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/users/{id}/roles/{role} [delete]
+func (h *Handler) UnassignRole(c *gin.Context) {
+	h.Log.Info("UnassignRole handler is starting")
+
+	id, ok := h.userIDParam(c)
+	if !ok {
+		return
+	}
+
+	removed, err := h.Enforcer.DeleteRoleForUser(id, c.Param("role"))
+	if err != nil {
+		h.abortRBACError(c, err, "error removing role")
+		return
+	}
+	if !removed {
+		er := "role is not assigned to the user"
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.userRoles(ctx, id)
+	if err != nil {
+		h.abortRBACError(c, err, "error getting user roles")
+		return
+	}
+
+	h.Log.Info("UnassignRole handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) permissions(role string) []models.Permission {
+	rules, _ := h.Enforcer.GetFilteredPolicy(0, role)
+	perms := make([]models.Permission, 0, len(rules))
+	for _, rule := range rules {
+		perms = append(perms, models.Permission{Path: rule[1], Method: rule[2]})
+	}
+	return perms
+}
+
+func (h *Handler) userRoles(ctx context.Context, id string) (*models.UserRoles, error) {
+	profile, err := h.Admin.GetProfile(ctx, &pb.Id{UserId: id})
+	if err != nil {
+		return nil, err
+	}
+	extra, err := h.Enforcer.GetRolesForUser(id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserRoles{UserID: id, Role: profile.Role, ExtraRoles: extra}, nil
+}
+
+func (h *Handler) checkRole(ctx context.Context, role string) error {
+	ok, err := h.Roles.Exists(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return rbac.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (h *Handler) bindPermission(c *gin.Context) (*models.Permission, bool) {
+	var perm models.Permission
+	if err := c.ShouldBindJSON(&perm); err != nil {
+		er := errors.Wrap(err, "invalid data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return nil, false
+	}
+	perm.Method = strings.ToUpper(perm.Method)
+	if !strings.HasPrefix(perm.Path, "/api/v1/") || !slices.Contains(permissionMethods, perm.Method) {
+		er := "path must start with /api/v1/ and method must be one of " + strings.Join(permissionMethods, ", ")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return nil, false
+	}
+	return &perm, true
+}
+
+func (h *Handler) userIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		er := errors.Wrap(err, "invalid user id").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return "", false
+	}
+	return id, true
+}
+
+func validRoleName(name string) bool {
+	if len(name) < 2 || len(name) > 20 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Handler) abortRBACError(c *gin.Context, err error, msg string) {
+	er := errors.Wrap(err, msg).Error()
+	switch {
+	case errors.Is(err, rbac.ErrRoleNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	case errors.Is(err, rbac.ErrRoleExists), errors.Is(err, rbac.ErrBuiltInRole), errors.Is(err, rbac.ErrRoleInUse):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+	case strings.Contains(err.Error(), "user not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
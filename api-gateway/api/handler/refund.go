@@ -0,0 +1,94 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// RefundPayment godoc
+// @Summary Refunds a payment
+// @Description Returns part or all of a captured payment to the customer. An amount of 0 refunds everything not refunded yet. Canceled bookings are refunded automatically
+// @Tags payments
+// @Security ApiKeyAuth
+// @Param id path string true "Payment ID"
+// @Param refund body booking.RefundPaymentRequest true "Amount and reason of the refund"
+// @Success 200 {object} booking.RefundResponse
+// @Failure 400 {object} string "Invalid refund or payment is not refundable"
+// @Failure 404 {object} string "Payment not found"
+// @Failure 502 {object} string "Payment provider rejected the refund"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/payments/{id}/refunds [post]
+func (h *Handler) RefundPayment(c *gin.Context) {
+	h.Log.Info("RefundPayment handler is starting")
+
+	var req pb.RefundPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		er := errors.Wrap(err, "invalid refund data").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	req.PaymentId = c.Param("id")
+	req.RequestedBy = c.GetString("user_id")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Payment.RefundPayment(ctx, &req)
+	if err != nil {
+		h.abortRefundError(c, errors.Wrap(err, "error refunding payment"))
+		return
+	}
+
+	h.Log.Info("RefundPayment handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListRefunds godoc
+// @Summary Lists the refunds of a payment
+// @Description Returns every refund attempt of the payment, oldest first, including the ones the payment provider rejected
+// @Tags payments
+// @Security ApiKeyAuth
+// @Param id path string true "Payment ID"
+// @Success 200 {object} booking.ListRefundsResponse
+// @Failure 403 {object} string "Resource belongs to another user"
+// @Failure 404 {object} string "Payment not found"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /customer/payments/{id}/refunds [get]
+// @Router /admin/payments/{id}/refunds [get]
+func (h *Handler) ListRefunds(c *gin.Context) {
+	h.Log.Info("ListRefunds handler is starting")
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Payment.ListRefunds(ctx, &pb.ListRefundsRequest{PaymentId: c.Param("id")})
+	if err != nil {
+		h.abortRefundError(c, errors.Wrap(err, "error listing refunds"))
+		return
+	}
+
+	h.Log.Info("ListRefunds handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
+
+// abortRefundError maps the errors of refunds to HTTP statuses.
+func (h *Handler) abortRefundError(c *gin.Context, err error) {
+	er := err.Error()
+	switch {
+	case strings.Contains(er, "not found"):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+	case strings.Contains(er, "payment provider rejected"):
+		c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": er})
+	case strings.Contains(er, "not refundable"), strings.Contains(er, "invalid"), strings.Contains(er, "reason is required"):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+	}
+	h.Log.Error(er)
+}
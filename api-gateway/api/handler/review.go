@@ -17,7 +17,9 @@ import (
 // @Tags reviews
 // @Security ApiKeyAuth
 // @Param review body models.ReviewRequest true "Review details"
-// @Success 200 {object} booking.ReviewResponse
+// @Param wait query string false "Wait up to this long for the outcome, e.g. 5s"
+// @Success 200 {object} models.Operation "Request processed"
+// @Success 202 {object} models.Operation "Request accepted, poll /operations/{id} for the outcome"
 // @Failure 400 {object} string "Invalid review data"
 // @Failure 500 {object} string "Server error while processing review"
 // @Router /customer/reviews [post]
@@ -55,7 +57,7 @@ func (h *Handler) CreateReview(c *gin.Context) {
 		h.Log.Error(er)
 		return
 	}
-	err = h.Broker.Review(body)
+	h.publishOperation(c, h.Broker.Review, body)
 
 	//ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	//defer cancel()
@@ -75,7 +77,6 @@ func (h *Handler) CreateReview(c *gin.Context) {
 	//}
 
 	h.Log.Info("CreateReview handler is completed")
-	c.JSON(http.StatusOK, gin.H{"review": req.ProviderID})
 }
 
 // ListReviews godoc
@@ -227,6 +228,7 @@ func (h *Handler) GetReview1(c *gin.Context) {
 // @Param review body models.Review true "Review details"
 // @Success 200 {object} booking.ReviewResponse
 // @Failure 400 {object} string "Invalid review data"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Review not found"
 // @Failure 500 {object} string "Server error while processing review"
 // @Router /customer/reviews/{id} [put]
@@ -280,6 +282,7 @@ func (h *Handler) UpdateReview(c *gin.Context) {
 // @Param id path string true "Review ID"
 // @Success 200 {object} string "Review successfully deleted"
 // @Failure 400 {object} string "Invalid review ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Review not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /customer/reviews/{id} [delete]
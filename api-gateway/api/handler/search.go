@@ -73,17 +73,19 @@ func (h *Handler) SearchServices(c *gin.Context) {
 // @Param by_rating query bool false "Sort by rating"
 // @Param by_noc query bool false "Sort by number of comments"
 // @Param company query string false "Company Name"
-// @Param location query string false "Location"
+// @Param lat query number false "Latitude of the search center"
+// @Param lng query number false "Longitude of the search center"
+// @Param radius_km query number false "Search radius in kilometers"
 // @Param availability query string false "Ishlash Vaqt oralig`i (8:00-20:00)"
 // @Param page query int false "Page number"
 // @Param limit query int false "Limit"
 // @Success 200 {object} booking.ListProvidersResponses
+// @Failure 400 {object} string "Invalid search center or radius"
 // @Failure 500 {object} string "Server error while processing SearchProviders"
 // @Router /customer/search/provider [get]
 func (h *Handler) SearchProviders(c *gin.Context) {
 	page := c.DefaultQuery("page", "0")
 	limit := c.DefaultQuery("limit", "10")
-	loc := c.Query("location")
 	byRating := c.DefaultQuery("by_rating", "false")
 	byNoc := c.DefaultQuery("by_noc", "false")
 	availability := c.Query("availability")
@@ -98,7 +100,6 @@ func (h *Handler) SearchProviders(c *gin.Context) {
 		limitInt = 10
 	}
 	req := &booking.Filter{
-		Location:         loc,
 		ByRating:         byRating == "true",
 		NumberOfComments: byNoc == "true",
 		CompanyName:      company,
@@ -107,6 +108,24 @@ func (h *Handler) SearchProviders(c *gin.Context) {
 		Limit:            int32(limitInt),
 	}
 
+	if lat, lng := c.Query("lat"), c.Query("lng"); lat != "" || lng != "" {
+		latF, err1 := strconv.ParseFloat(lat, 64)
+		lngF, err2 := strconv.ParseFloat(lng, 64)
+		if err1 != nil || err2 != nil || latF < -90 || latF > 90 || lngF < -180 || lngF > 180 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lng must be valid coordinates"})
+			return
+		}
+		req.Center = &booking.GeoPoint{Latitude: latF, Longitude: lngF}
+	}
+	if radius := c.Query("radius_km"); radius != "" {
+		radiusF, err := strconv.ParseFloat(radius, 64)
+		if err != nil || radiusF < 0 || req.Center == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "radius_km must be a positive number and needs lat and lng"})
+			return
+		}
+		req.RadiusKm = radiusF
+	}
+
 	res, err := h.Search.SearchProviders(c, req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/users"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ListSecurityEvents godoc
+// @Summary Lists security events
+// @Description Lists failed and successful logins, blocked attempts and account/IP lockouts, newest first
+// @Tags admin
+// @Security ApiKeyAuth
+// @Param type query string false "Event type (login_failed, login_succeeded, login_blocked, account_locked, ip_locked)"
+// @Param user_id query string false "User ID"
+// @Param email query string false "Email"
+// @Param ip query string false "Client IP"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of events per page"
+// @Success 200 {object} users.SecurityEvents
+// @Failure 400 {object} string "Invalid pagination parameters"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /admin/security-events [get]
+func (h *Handler) ListSecurityEvents(c *gin.Context) {
+	h.Log.Info("ListSecurityEvents handler is invoked")
+
+	var p, l int
+	for _, q := range []struct {
+		value string
+		dst   *int
+	}{{c.Query("page"), &p}, {c.Query("limit"), &l}} {
+		if q.value == "" {
+			continue
+		}
+		v, err := strconv.Atoi(q.value)
+		if err != nil {
+			er := errors.Wrap(err, "invalid pagination parameters").Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+			h.Log.Error(er)
+			return
+		}
+		*q.dst = v
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Admin.ListSecurityEvents(ctx, &pb.SecurityEventFilter{
+		Type:   c.Query("type"),
+		UserId: c.Query("user_id"),
+		Email:  strings.ToLower(strings.TrimSpace(c.Query("email"))),
+		Ip:     c.Query("ip"),
+		Page:   int32(p),
+		Limit:  int32(l),
+	})
+	if err != nil {
+		er := errors.Wrap(err, "error listing security events").Error()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("ListSecurityEvents handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
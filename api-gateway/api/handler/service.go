@@ -29,6 +29,7 @@ func (h *Handler) CreateService(c *gin.Context) {
 		h.Log.Error(er)
 		return
 	}
+	req.UserId = c.GetString("user_id")
 
 	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
 	defer cancel()
@@ -230,6 +231,7 @@ func (h *Handler) GetService1(c *gin.Context) {
 // @Param service body booking.UpdateServiceRequest true "Updated service details"
 // @Success 200 {object} booking.ServiceResponse
 // @Failure 400 {object} string "Invalid service data or ID"
+// @Failure 403 {object} string "Resource belongs to another user"
 // @Failure 404 {object} string "Service not found"
 // @Failure 500 {object} string "Server error while processing request"
 // @Router /provider/services/{id} [put]
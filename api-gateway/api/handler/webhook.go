@@ -0,0 +1,77 @@
+package handler
+
+import (
+	pb "Api_Gateway/genproto/booking"
+	"Api_Gateway/pkg/webhook"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// PaymentWebhook godoc
+// @Summary Receives payment provider events
+// @Description Called by the payment provider when a payment changes, e.g. once a 3-D Secure challenge is finished. Requests must carry a valid Payment-Signature header instead of a token. Events that were already applied are acknowledged again
+// @Tags payments
+// @Param Payment-Signature header string true "t=<unix time>,v1=<hex HMAC-SHA256 of \"<unix time>.<body>\">"
+// @Param event body booking.PaymentEvent true "Payment event"
+// @Success 200 {object} booking.PaymentResponse
+// @Failure 400 {object} string "Invalid event"
+// @Failure 401 {object} string "Invalid signature"
+// @Failure 404 {object} string "Payment not found"
+// @Failure 409 {object} string "Event does not apply to the payment's status"
+// @Failure 500 {object} string "Server error while processing request"
+// @Router /webhooks/payments [post]
+func (h *Handler) PaymentWebhook(c *gin.Context) {
+	h.Log.Info("PaymentWebhook handler is starting")
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		er := errors.Wrap(err, "failed to read event").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+	if err := webhook.Verify(h.WebhookSecret, c.GetHeader(webhook.SignatureHeader), body, time.Now()); err != nil {
+		er := err.Error()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	var event pb.PaymentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		er := errors.Wrap(err, "invalid event").Error()
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		h.Log.Error(er)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, h.ContextTimeout)
+	defer cancel()
+
+	resp, err := h.Payment.HandlePaymentEvent(ctx, &event)
+	if err != nil {
+		er := errors.Wrap(err, "error handling payment event").Error()
+		switch {
+		case strings.Contains(er, "not found"):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+		case strings.Contains(er, "invalid payment status transition"), strings.Contains(er, "status has already changed"):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": er})
+		case strings.Contains(er, "unsupported payment event"), strings.Contains(er, "does not match"):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": er})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+		}
+		h.Log.Error(er)
+		return
+	}
+
+	h.Log.Info("PaymentWebhook handler is completed")
+	c.JSON(http.StatusOK, resp)
+}
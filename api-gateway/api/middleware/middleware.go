@@ -10,7 +10,7 @@ import (
 )
 
 type casbinPermission struct {
-	enforcer *casbin.Enforcer
+	enforcer *casbin.SyncedEnforcer
 }
 
 func (c *casbinPermission) GetRole(ctx *gin.Context) (string, int) {
@@ -27,6 +27,7 @@ func (c *casbinPermission) GetRole(ctx *gin.Context) (string, int) {
 		return "Unauthorized", http.StatusUnauthorized
 	}
 	ctx.Set("user_id", claims["user_id"])
+	ctx.Set("role", role)
 	return role, http.StatusOK
 }
 
@@ -40,13 +41,19 @@ func (c *casbinPermission) CheckPermission(ctx *gin.Context) (bool, error) {
 	fmt.Println("subject", subject, "action", acrtion, "object", object)
 
 	allow, err := c.enforcer.Enforce(subject, object, acrtion)
-	if err != nil {
-		return false, err
+	if err != nil || allow {
+		return allow, err
+	}
+
+	// Roles assigned on top of the profile role are granted to the user ID.
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		return false, nil
 	}
-	return allow, nil
+	return c.enforcer.Enforce(userID, object, acrtion)
 }
 
-func PermissionMiddleware(enf *casbin.Enforcer) gin.HandlerFunc {
+func PermissionMiddleware(enf *casbin.SyncedEnforcer, revocations *RevocationChecker) gin.HandlerFunc {
 	casbHandler := &casbinPermission{enforcer: enf}
 	return func(ctx *gin.Context) {
 		res, err := casbHandler.CheckPermission(ctx)
@@ -76,6 +83,11 @@ func PermissionMiddleware(enf *casbin.Enforcer) gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Token invalid claims: %s", err)})
 			return
 		}
+
+		if revoked, reason := revocations.IsRevoked(ctx, claims); revoked {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Token revoked: %s", reason)})
+			return
+		}
 		ctx.Set("claims", claims)
 		ctx.Next()
 	}
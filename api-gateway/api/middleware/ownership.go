@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// AdminRole may act on resources of every user.
+const AdminRole = "admin"
+
+// OwnerLookup returns the ID of the user that owns the resource with the given ID.
+type OwnerLookup func(ctx context.Context, id string) (string, error)
+
+// Ownership closes the gap casbin leaves open: casbin decides whether a role may
+// call a route, Ownership decides whether the caller may touch the record the
+// route points at.
+type Ownership struct {
+	enforcer *casbin.SyncedEnforcer
+	timeout  time.Duration
+}
+
+func NewOwnership(enf *casbin.SyncedEnforcer, timeout time.Duration) *Ownership {
+	return &Ownership{enforcer: enf, timeout: timeout}
+}
+
+// Owner rejects the request unless the user_id claim, set by casbinPermission.GetRole,
+// belongs to the owner of the resource named by the param path parameter. Admins,
+// by profile role or by an assigned role, are exempt.
+func (o *Ownership) Owner(param string, lookup OwnerLookup) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("user_id")
+		if userID == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user id not provided"})
+			return
+		}
+		if o.isAdmin(ctx, userID) {
+			ctx.Next()
+			return
+		}
+
+		c, cancel := context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+
+		owner, err := lookup(c, ctx.Param(param))
+		if err != nil {
+			er := errors.Wrap(err, "error checking resource owner").Error()
+			if strings.Contains(err.Error(), "not found") {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": er})
+			} else {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": er})
+			}
+			return
+		}
+		if owner != userID {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you do not own this resource"})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (o *Ownership) isAdmin(ctx *gin.Context, userID string) bool {
+	if ctx.GetString("role") == AdminRole {
+		return true
+	}
+	ok, err := o.enforcer.HasRoleForUser(userID, AdminRole)
+	return err == nil && ok
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	e, err := casbin.NewSyncedEnforcer("../../config/model.conf")
+	require.NoError(t, err)
+	_, err = e.AddGroupingPolicy("user9", AdminRole)
+	require.NoError(t, err)
+
+	lookup := func(ctx context.Context, id string) (string, error) {
+		switch id {
+		case "booking1":
+			return "user1", nil
+		case "broken":
+			return "", errors.New("connection refused")
+		}
+		return "", errors.New("booking not found")
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		role   string
+		id     string
+		status int
+	}{
+		{name: "Owner", userID: "user1", id: "booking1", status: http.StatusOK},
+		{name: "OtherUser", userID: "user2", id: "booking1", status: http.StatusForbidden},
+		{name: "AdminByProfile", userID: "user2", role: AdminRole, id: "booking1", status: http.StatusOK},
+		{name: "AdminByAssignedRole", userID: "user9", id: "booking1", status: http.StatusOK},
+		{name: "NotFound", userID: "user1", id: "booking2", status: http.StatusNotFound},
+		{name: "LookupFailed", userID: "user1", id: "broken", status: http.StatusInternalServerError},
+		{name: "NoUser", id: "booking1", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/bookings/:id", func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			}, NewOwnership(e, time.Second).Owner("id", lookup), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bookings/"+tt.id, nil))
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	pbu "Api_Gateway/genproto/users"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// maxCachedTokens bounds the local revocation cache.
+const maxCachedTokens = 10000
+
+// RevocationChecker asks the Auth service whether an access token was revoked
+// and remembers the answer for a short while, so that most requests do not pay
+// for a round trip. Revoked tokens are remembered until they expire.
+type RevocationChecker struct {
+	client pbu.AuthServiceClient
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]revocation
+}
+
+type revocation struct {
+	revoked   bool
+	reason    string
+	expiresAt time.Time
+}
+
+func NewRevocationChecker(client pbu.AuthServiceClient, ttl time.Duration) *RevocationChecker {
+	return &RevocationChecker{
+		client: client,
+		ttl:    ttl,
+		cache:  make(map[string]revocation),
+	}
+}
+
+// IsRevoked reports whether the token with the given claims was revoked. When the
+// Auth service cannot be reached the token is let through, as its signature has
+// already been verified.
+func (r *RevocationChecker) IsRevoked(ctx context.Context, claims jwt.MapClaims) (bool, string) {
+	jti, _ := claims["jti"].(string)
+	if jti != "" {
+		r.mu.Lock()
+		entry, ok := r.cache[jti]
+		r.mu.Unlock()
+		if ok && time.Now().Before(entry.expiresAt) {
+			return entry.revoked, entry.reason
+		}
+	}
+
+	sid, _ := claims["sid"].(string)
+	userID, _ := claims["user_id"].(string)
+	iat, _ := claims["iat"].(float64)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	resp, err := r.client.CheckToken(ctx, &pbu.CheckTokenRequest{
+		Jti:       jti,
+		SessionId: sid,
+		UserId:    userID,
+		IssuedAt:  int64(iat),
+	})
+	if err != nil {
+		log.Println("failed to check token revocation:", err)
+		return false, ""
+	}
+
+	if jti != "" {
+		expiresAt := time.Now().Add(r.ttl)
+		if exp, ok := claims["exp"].(float64); ok && resp.Revoked {
+			expiresAt = time.Unix(int64(exp), 0)
+		}
+		r.store(jti, revocation{revoked: resp.Revoked, reason: resp.Reason, expiresAt: expiresAt})
+	}
+
+	return resp.Revoked, resp.Reason
+}
+
+func (r *RevocationChecker) store(jti string, entry revocation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.cache) >= maxCachedTokens {
+		now := time.Now()
+		for k, e := range r.cache {
+			if now.After(e.expiresAt) {
+				delete(r.cache, k)
+			}
+		}
+		if len(r.cache) >= maxCachedTokens {
+			r.cache = make(map[string]revocation)
+		}
+	}
+	r.cache[jti] = entry
+}
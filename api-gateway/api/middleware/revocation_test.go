@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	pbu "Api_Gateway/genproto/users"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// authChecker answers CheckToken from revoked, keyed by jti, and counts the calls.
+type authChecker struct {
+	pbu.AuthServiceClient
+	revoked map[string]bool
+	down    bool
+	calls   int
+}
+
+func (a *authChecker) CheckToken(ctx context.Context, in *pbu.CheckTokenRequest, opts ...grpc.CallOption) (*pbu.CheckTokenResponse, error) {
+	a.calls++
+	if a.down {
+		return nil, errors.New("connection refused")
+	}
+	if a.revoked[in.Jti] {
+		return &pbu.CheckTokenResponse{Revoked: true, Reason: "token revoked"}, nil
+	}
+	return &pbu.CheckTokenResponse{}, nil
+}
+
+func TestIsRevoked(t *testing.T) {
+	claims := func(jti string) jwt.MapClaims {
+		return jwt.MapClaims{"jti": jti, "user_id": "user1", "exp": float64(time.Now().Add(time.Hour).Unix())}
+	}
+
+	tests := []struct {
+		name    string
+		jti     string
+		ttl     time.Duration
+		revoked bool
+		down    bool
+		calls   int
+	}{
+		{name: "ActiveCached", jti: "jti1", ttl: time.Minute, calls: 1},
+		{name: "ActiveCacheExpired", jti: "jti1", ttl: -time.Second, calls: 2},
+		{name: "RevokedCachedUntilExpiry", jti: "jti2", ttl: -time.Second, revoked: true, calls: 1},
+		{name: "NoJTI", ttl: time.Minute, calls: 2},
+		{name: "AuthDown", jti: "jti1", ttl: time.Minute, down: true, calls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &authChecker{revoked: map[string]bool{"jti2": true}, down: tt.down}
+			r := NewRevocationChecker(auth, tt.ttl)
+
+			for i := 0; i < 2; i++ {
+				revoked, _ := r.IsRevoked(context.Background(), claims(tt.jti))
+				require.Equal(t, tt.revoked, revoked)
+			}
+			require.Equal(t, tt.calls, auth.calls)
+		})
+	}
+}
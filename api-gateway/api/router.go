@@ -3,6 +3,7 @@ package api
 import (
 	"Api_Gateway/api/handler"
 	"Api_Gateway/api/middleware"
+	"Api_Gateway/pkg/rbac"
 	"github.com/casbin/casbin/v2"
 	amqp "github.com/rabbitmq/amqp091-go"
 
@@ -23,14 +24,18 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-func NewRouter(cfg *config.Config, casbin *casbin.Enforcer, conn *amqp.Channel) *gin.Engine {
-	h := handler.NewHandler(cfg, conn)
+func NewRouter(cfg *config.Config, casbin *casbin.SyncedEnforcer, roles *rbac.RoleStore, conn *amqp.Channel) *gin.Engine {
+	h := handler.NewHandler(cfg, casbin, roles, conn)
 
 	router := gin.Default()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// The payment provider calls the webhook without a user token; its requests are
+	// authenticated by their signature instead of the permission middleware.
+	router.POST("/api/v1/webhooks/payments", h.PaymentWebhook)
 
 	api := router.Group("/api/v1")
-	api.Use(middleware.PermissionMiddleware(casbin))
+	api.Use(middleware.PermissionMiddleware(casbin, middleware.NewRevocationChecker(h.User, cfg.REVOCATION_CACHE_TTL)))
+	owner := middleware.NewOwnership(casbin, h.ContextTimeout)
 
 	// Admin routes
 	admin := api.Group("/admin")
@@ -42,11 +47,45 @@ func NewRouter(cfg *config.Config, casbin *casbin.Enforcer, conn *amqp.Channel)
 			u.DELETE("/:id", h.DeleteUser)
 		}
 		admin.GET("/users", h.FetchUsers)
+		admin.GET("/security-events", h.ListSecurityEvents)
 		admin.GET("/bookings/all/:id", h.ListBookings)
+		admin.POST("/bookings/:id/confirm", h.ConfirmBooking)
+		admin.POST("/bookings/:id/reject", h.RejectBooking)
+		admin.PUT("/bookings/:id/cancellation-fee", h.OverrideCancellationFee)
+		dl := admin.Group("/dead-letters")
+		{
+			dl.GET("", h.ListDeadLetters)
+			dl.GET("/:id", h.GetDeadLetter)
+			dl.POST("/:id/replay", h.ReplayDeadLetter)
+			dl.DELETE("/:id", h.DeleteDeadLetter)
+			dl.DELETE("", h.PurgeDeadLetters)
+		}
 		admin.GET("/providers/:id", h.GetProvider1)
+		admin.GET("/provider-applications", h.ListProviderApplications)
+		admin.POST("/providers/:id/review", h.ReviewProvider)
+		admin.GET("/providers/:id/documents/:document_id", h.ReviewProviderDocument)
 		admin.GET("/services/:id", h.GetService1)
 		admin.GET("/reviews/:id", h.GetReview1)
 		admin.GET("/payments/:id", h.GetPayment1)
+		admin.POST("/payments/:id/refunds", h.RefundPayment)
+		admin.GET("/payments/:id/refunds", h.ListRefunds)
+		r := admin.Group("/roles")
+		{
+			r.GET("", h.ListRoles)
+			r.POST("", h.CreateRole)
+			r.DELETE("/:role", h.DeleteRole)
+			r.GET("/:role/permissions", h.ListPermissions)
+			r.POST("/:role/permissions", h.GrantPermission)
+			r.DELETE("/:role/permissions", h.RevokePermission)
+		}
+		admin.GET("/pricing", h.GetPricingDefaults)
+		admin.PUT("/pricing", h.SetPricingDefaults)
+		admin.GET("/providers/:id/pricing", h.GetProviderPricing)
+		admin.PUT("/providers/:id/pricing", h.SetProviderPricing)
+		admin.DELETE("/providers/:id/pricing", h.DeleteProviderPricing)
+		admin.GET("/users/:id/roles", h.GetUserRoles)
+		admin.POST("/users/:id/roles", h.AssignRole)
+		admin.DELETE("/users/:id/roles/:role", h.UnassignRole)
 	}
 
 	// Customer routes
@@ -61,24 +100,27 @@ func NewRouter(cfg *config.Config, casbin *casbin.Enforcer, conn *amqp.Channel)
 		b := customer.Group("/bookings")
 		{
 			b.POST("", h.CreateBooking)
-			b.GET("/:id", h.GetBooking)
-			b.PUT("/:id", h.UpdateBooking)
-			b.DELETE("/:id", h.CancelBooking)
+			b.POST("/quote", h.QuoteBooking)
+			b.GET("/:id", owner.Owner("id", h.BookingOwner), h.GetBooking)
+			b.PUT("/:id", owner.Owner("id", h.BookingOwner), h.UpdateBooking)
+			b.DELETE("/:id", owner.Owner("id", h.BookingOwner), h.CancelBooking)
 			b.GET("/all", h.ListBookings1)
+			b.GET("/slots", h.GetAvailableSlots)
 		}
 		tr := customer.Group("/payments")
 		{
 			tr.POST("/", h.CreatePayment)
-			tr.GET("/:id", h.GetPayment)
+			tr.GET("/:id", owner.Owner("id", h.PaymentOwner), h.GetPayment)
+			tr.GET("/:id/refunds", owner.Owner("id", h.PaymentOwner), h.ListRefunds)
 			tr.GET("/", h.ListPaymentse)
 		}
 		r := customer.Group("/reviews")
 		{
 			r.POST("/", h.CreateReview)
 			r.GET("/:id", h.GetReview)
-			r.PUT("/:id", h.UpdateReview)
+			r.PUT("/:id", owner.Owner("id", h.ReviewOwner), h.UpdateReview)
 			r.GET("/", h.ListReviews)
-			r.DELETE("/:id", h.DeleteReview)
+			r.DELETE("/:id", owner.Owner("id", h.ReviewOwner), h.DeleteReview)
 		}
 		s := customer.Group("/services")
 		{
@@ -91,21 +133,50 @@ func NewRouter(cfg *config.Config, casbin *casbin.Enforcer, conn *amqp.Channel)
 			sr.GET("/provider", h.SearchProviders)
 			sr.GET("/service", h.SearchServices)
 		}
+		o := customer.Group("/organizations")
+		{
+			o.POST("", h.CreateOrganization)
+			o.GET("", h.ListOrganizations)
+			o.POST("/invitations/accept", h.AcceptOrganizationInvitation)
+			o.GET("/:org_id", h.GetOrganization)
+			o.GET("/:org_id/members", h.ListOrganizationMembers)
+			o.PUT("/:org_id/members/:user_id", h.UpdateOrganizationMember)
+			o.DELETE("/:org_id/members/:user_id", h.RemoveOrganizationMember)
+			o.POST("/:org_id/invitations", h.InviteOrganizationMember)
+			o.GET("/:org_id/invitations", h.ListOrganizationInvitations)
+			o.DELETE("/:org_id/invitations/:invitation_id", h.RevokeOrganizationInvitation)
+			o.GET("/:org_id/bookings", h.ListOrganizationBookings)
+			o.GET("/:org_id/payments", h.ListOrganizationPayments)
+		}
 	}
 
+	api.GET("/operations/:id", h.GetOperation)
+
 	// Provider routes
 	provider := api.Group("/provider")
 	{
 		provider.POST("/", h.RegisterProvider)
 		provider.GET("/", h.ListProviders1)
-		provider.GET("/:id", h.GetProvider2)
-		provider.PUT("/:id", h.UpdateProvider)
-		provider.DELETE("/:id", h.DeleteProvider)
+		provider.GET("/:id", owner.Owner("id", h.ProviderOwner), h.GetProvider2)
+		provider.PUT("/:id", owner.Owner("id", h.ProviderOwner), h.UpdateProvider)
+		provider.DELETE("/:id", owner.Owner("id", h.ProviderOwner), h.DeleteProvider)
+		provider.POST("/:id/documents", owner.Owner("id", h.ProviderOwner), h.UploadProviderDocument)
+		provider.GET("/:id/documents/:document_id", owner.Owner("id", h.ProviderOwner), h.GetProviderDocument)
+		provider.GET("/:id/pricing", owner.Owner("id", h.ProviderOwner), h.GetProviderPricing)
+		provider.PUT("/:id/pricing", owner.Owner("id", h.ProviderOwner), h.SetProviderPricing)
+		provider.DELETE("/:id/pricing", owner.Owner("id", h.ProviderOwner), h.DeleteProviderPricing)
 		provider.GET("/services", h.ListServices1)
-		provider.PUT("/services/:id", h.UpdateService)
+		provider.PUT("/services/:id", owner.Owner("id", h.ServiceOwner), h.UpdateService)
 		provider.POST("/services", h.CreateService)
 		provider.POST("/notifications", h.CreateNotification)
 		provider.GET("/notifications/:id", h.GetNotification)
+		provider.GET("/bookings", h.ListIncomingBookings)
+		provider.POST("/bookings/:id/accept", owner.Owner("id", h.BookingProviderOwner), h.AcceptBooking)
+		provider.POST("/bookings/:id/reject", owner.Owner("id", h.BookingProviderOwner), h.DeclineBooking)
+		provider.POST("/bookings/:id/en-route", owner.Owner("id", h.BookingProviderOwner), h.MarkEnRoute)
+		provider.POST("/bookings/:id/start", owner.Owner("id", h.BookingProviderOwner), h.StartBooking)
+		provider.POST("/bookings/:id/complete", owner.Owner("id", h.BookingProviderOwner), h.CompleteBooking)
+		provider.POST("/bookings/:id/no-show", owner.Owner("id", h.BookingProviderOwner), h.MarkNoShow)
 	}
 
 	return router
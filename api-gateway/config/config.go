@@ -3,21 +3,27 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 )
 
 type Config struct {
-	HTTP_PORT            string
-	AUTH_SERVICE_PORT    string
-	BOOKING_SERVICE_PORT string
-	DB_HOST              string
-	DB_PORT              int
-	DB_USER              string
-	DB_PASSWORD          string
-	DB_NAME              string
-	ACCESS_TOKEN         string
+	// APP_ENV is "production" unless set; only "development" accepts placeholder secrets.
+	APP_ENV                string
+	HTTP_PORT              string
+	AUTH_SERVICE_PORT      string
+	BOOKING_SERVICE_PORT   string
+	DB_HOST                string
+	DB_PORT                int
+	DB_USER                string
+	DB_PASSWORD            string
+	DB_NAME                string
+	AUTH_JWKS_URL          string
+	JWKS_CACHE_TTL         time.Duration
+	REVOCATION_CACHE_TTL   time.Duration
+	PAYMENT_WEBHOOK_SECRET string
 }
 
 func Load() *Config {
@@ -27,6 +33,7 @@ func Load() *Config {
 	}
 	cfg := Config{}
 
+	cfg.APP_ENV = cast.ToString(coalesce("APP_ENV", "production"))
 	cfg.HTTP_PORT = cast.ToString(coalesce("HTTP_PORT", ":8080"))
 	cfg.AUTH_SERVICE_PORT = cast.ToString(coalesce("AUTH_SERVICE_PORT", ":50051"))
 	cfg.BOOKING_SERVICE_PORT = cast.ToString(coalesce("BOOKING_SERVICE_PORT", ":50052"))
@@ -37,11 +44,40 @@ func Load() *Config {
 	cfg.DB_PASSWORD = cast.ToString(coalesce("DB_PASSWORD", "123321"))
 	cfg.DB_NAME = cast.ToString(coalesce("DB_NAME", "auth_i"))
 
-	cfg.ACCESS_TOKEN = cast.ToString(coalesce("ACCESS_TOKEN", "access_key"))
+	cfg.AUTH_JWKS_URL = cast.ToString(coalesce("AUTH_JWKS_URL", "http://auth-service:8081/.well-known/jwks.json"))
+	cfg.JWKS_CACHE_TTL = cast.ToDuration(coalesce("JWKS_CACHE_TTL", "10m"))
+	cfg.REVOCATION_CACHE_TTL = cast.ToDuration(coalesce("REVOCATION_CACHE_TTL", "5s"))
+
+	cfg.PAYMENT_WEBHOOK_SECRET = cast.ToString(coalesce("PAYMENT_WEBHOOK_SECRET", placeholderSecret))
+
+	cfg.requireSecrets(map[string]string{
+		"PAYMENT_WEBHOOK_SECRET": cfg.PAYMENT_WEBHOOK_SECRET,
+	})
 
 	return &cfg
 }
 
+// placeholderSecret is what the sample .env ships for secrets.
+const placeholderSecret = "change-me"
+
+// Dev reports whether the service runs in development mode.
+func (c *Config) Dev() bool {
+	return c.APP_ENV == "development"
+}
+
+// requireSecrets stops the service when a secret is unset or still the placeholder,
+// unless it runs in development mode.
+func (c *Config) requireSecrets(secrets map[string]string) {
+	if c.Dev() {
+		return
+	}
+	for name, value := range secrets {
+		if value == "" || value == placeholderSecret {
+			log.Fatalf("%s must be set to a real secret; placeholders are only accepted with APP_ENV=development", name)
+		}
+	}
+}
+
 func coalesce(key string, value interface{}) interface{} {
 	val, exists := os.LookupEnv(key)
 	if exists {
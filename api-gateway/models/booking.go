@@ -1,11 +1,32 @@
 package models
 
 type Booking struct {
-	ProviderID    string   `bson:"provider_id" json:"provider_id"`
-	ServiceID     string   `bson:"service_id" json:"service_id"`
-	ScheduledTime string   `bson:"scheduled_time" json:"scheduled_time"`
-	Location      GeoPoint `bson:"location" json:"location"`
+	ProviderID     string   `bson:"provider_id" json:"provider_id"`
+	ServiceID      string   `bson:"service_id" json:"service_id"`
+	ScheduledTime  string   `bson:"scheduled_time" json:"scheduled_time"`
+	Location       GeoPoint `bson:"location" json:"location"`
+	OrganizationID string   `bson:"organization_id" json:"organization_id"` // Bills the booking to an organization of the user
+	VehicleClass   string   `bson:"vehicle_class" json:"vehicle_class" example:"sedan"`
+	QuoteID        string   `bson:"quote_id" json:"quote_id"` // Keeps the price of a quote from /customer/bookings/quote
 }
+
+// BookingTransition carries the optional reason attached to a booking status change.
+type BookingTransition struct {
+	Reason string `json:"reason"`
+}
+
+// CancellationFeeOverride lowers or waives the cancellation fee of a canceled booking.
+type CancellationFeeOverride struct {
+	Fee    float64 `json:"fee"`
+	Reason string  `json:"reason" binding:"required"`
+}
+
+// ProviderReview is an admin decision on a provider application.
+type ProviderReview struct {
+	Status string `json:"status" example:"approved"`
+	Reason string `json:"reason"`
+}
+
 type GeoPoint struct {
 	Longitude float64 `bson:"longitude" json:"longitude"`
 	Latitude  float64 `bson:"latitude" json:"latitude"`
@@ -23,10 +44,20 @@ type ReviewRequest struct {
 }
 
 type Provider struct {
-	CompanyName   string    `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
-	Description   string    `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Services      []string  `protobuf:"bytes,4,rep,name=services,proto3" json:"services,omitempty"`
-	Availability  string    `protobuf:"bytes,5,rep,name=availability,proto3" json:"availability,omitempty"`
-	AverageRating float64   `protobuf:"fixed64,6,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
-	Location      *GeoPoint `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	CompanyName   string         `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	Description   string         `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Services      []string       `protobuf:"bytes,4,rep,name=services,proto3" json:"services,omitempty"`
+	Availability  string         `protobuf:"bytes,5,rep,name=availability,proto3" json:"availability,omitempty"`
+	AverageRating float64        `protobuf:"fixed64,6,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	Location      *GeoPoint      `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	Schedule      []WorkingHours `json:"schedule,omitempty"`
+	Holidays      []string       `json:"holidays,omitempty"`
+	Timezone      string         `json:"timezone,omitempty" example:"Europe/Berlin"`
+}
+
+// WorkingHours is the opening window of a provider on one weekday (0 = Sunday).
+type WorkingHours struct {
+	Weekday int32  `json:"weekday"`
+	Start   string `json:"start" example:"09:00"`
+	End     string `json:"end" example:"18:00"`
 }
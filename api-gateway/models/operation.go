@@ -0,0 +1,17 @@
+package models
+
+import "encoding/json"
+
+// OperationPending is reported until the booking service has processed the request.
+const OperationPending = "pending"
+
+// Operation is the outcome of a request that was sent through the message broker.
+type Operation struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind,omitempty"`
+	Status    string          `json:"status" example:"succeeded"`
+	Error     string          `json:"error,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	CreatedAt string          `json:"created_at,omitempty"`
+	UpdatedAt string          `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+type CreateOrganization struct {
+	Name         string `json:"name" binding:"required" example:"Acme Logistics"`
+	BillingEmail string `json:"billing_email" example:"billing@acme.com"`
+}
+
+// InviteMember invites an email address into an organization with one of the
+// owner, manager or driver roles.
+type InviteMember struct {
+	Email string `json:"email" binding:"required" example:"driver@acme.com"`
+	Role  string `json:"role" binding:"required" example:"driver"`
+}
+
+type MemberRole struct {
+	Role string `json:"role" binding:"required" example:"manager"`
+}
+
+// AcceptInvitation carries the token from the invitation email.
+type AcceptInvitation struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Role is a named set of permissions. Users get one through their profile role or an assignment.
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	BuiltIn     bool      `json:"built_in"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateRole struct {
+	Name        string `json:"name" binding:"required" example:"support"`
+	Description string `json:"description"`
+}
+
+// Permission allows a role to call an API path, such as /api/v1/admin/users, with a method.
+type Permission struct {
+	Path   string `json:"path" binding:"required" example:"/api/v1/admin/users"`
+	Method string `json:"method" binding:"required" example:"GET"`
+}
+
+// RolePermissions is a role together with everything it is allowed to call.
+type RolePermissions struct {
+	Role
+	Permissions []Permission `json:"permissions"`
+}
+
+type AssignRole struct {
+	Role string `json:"role" binding:"required" example:"support"`
+}
+
+// UserRoles lists the role of the user's profile and the roles assigned on top of it.
+type UserRoles struct {
+	UserID     string   `json:"user_id"`
+	Role       string   `json:"role"`
+	ExtraRoles []string `json:"extra_roles"`
+}
@@ -35,6 +35,18 @@ func NewAdminClient(cfg *config.Config) pbu.AdminClient {
 	return pbu.NewAdminClient(conn)
 }
 
+func NewOrganizationClient(cfg *config.Config) pbu.OrganizationClient {
+	conn, err := grpc.NewClient(cfg.AUTH_SERVICE_PORT,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		log.Println(errors.Wrap(err, "failed to connect to the address"))
+		return nil
+	}
+
+	return pbu.NewOrganizationClient(conn)
+}
+
 func NewBookingClient(cfg *config.Config) pbb.BookingServiceClient {
 	conn, err := grpc.NewClient(cfg.BOOKING_SERVICE_PORT,
 		grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -100,3 +112,33 @@ func NewNotificationClient(cfg *config.Config) pbb.NotificationsClient {
 	}
 	return pbb.NewNotificationsClient(conn)
 }
+
+func NewOperationClient(cfg *config.Config) pbb.OperationServiceClient {
+	conn, err := grpc.NewClient(cfg.BOOKING_SERVICE_PORT,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Println(errors.Wrap(err, "failed to connect to the address"))
+		return nil
+	}
+	return pbb.NewOperationServiceClient(conn)
+}
+
+func NewPricingClient(cfg *config.Config) pbb.PricingServiceClient {
+	conn, err := grpc.NewClient(cfg.BOOKING_SERVICE_PORT,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Println(errors.Wrap(err, "failed to connect to the address"))
+		return nil
+	}
+	return pbb.NewPricingServiceClient(conn)
+}
+
+func NewDeadLetterClient(cfg *config.Config) pbb.DeadLetterServiceClient {
+	conn, err := grpc.NewClient(cfg.BOOKING_SERVICE_PORT,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Println(errors.Wrap(err, "failed to connect to the address"))
+		return nil
+	}
+	return pbb.NewDeadLetterServiceClient(conn)
+}
@@ -0,0 +1,135 @@
+// Package rbac keeps the casbin policy of the gateway in Postgres so roles and
+// permissions can change at runtime.
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/pkg/errors"
+)
+
+// ruleFields is the number of value columns (v0..v5) of casbin_rule.
+const ruleFields = 6
+
+// Adapter loads and saves casbin rules in the casbin_rule table.
+type Adapter struct {
+	db *sql.DB
+}
+
+func NewAdapter(db *sql.DB) *Adapter {
+	return &Adapter{db: db}
+}
+
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	rows, err := a.db.Query(`select ptype, v0, v1, v2, v3, v4, v5 from casbin_rule order by id`)
+	if err != nil {
+		return errors.Wrap(err, "failed to load policy")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ptype string
+		v := make([]string, ruleFields)
+		if err := rows.Scan(&ptype, &v[0], &v[1], &v[2], &v[3], &v[4], &v[5]); err != nil {
+			return errors.Wrap(err, "failed to read policy")
+		}
+		for len(v) > 0 && v[len(v)-1] == "" {
+			v = v[:len(v)-1]
+		}
+		if err := persist.LoadPolicyArray(append([]string{ptype}, v...), m); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// SavePolicy replaces every stored rule with the rules of the model.
+func (a *Adapter) SavePolicy(m model.Model) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to save policy")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`delete from casbin_rule`); err != nil {
+		return errors.Wrap(err, "failed to save policy")
+	}
+	for _, sec := range []string{"p", "g"} {
+		for ptype, ast := range m[sec] {
+			for _, rule := range ast.Policy {
+				if err := insertRule(context.Background(), tx, ptype, rule); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return insertRule(context.Background(), a.db, ptype, rule)
+}
+
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	v, err := ruleValues(rule)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.Exec(`
+		delete from casbin_rule
+		where ptype = $1 and v0 = $2 and v1 = $3 and v2 = $4 and v3 = $5 and v4 = $6 and v5 = $7`,
+		ptype, v[0], v[1], v[2], v[3], v[4], v[5])
+	return errors.Wrap(err, "failed to remove policy")
+}
+
+// RemoveFilteredPolicy removes the rules whose values, starting at fieldIndex, match
+// fieldValues. Empty values match anything.
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	if fieldIndex < 0 || fieldIndex+len(fieldValues) > ruleFields {
+		return errors.New("invalid policy filter")
+	}
+
+	where := []string{"ptype = $1"}
+	args := []interface{}{ptype}
+	for i, value := range fieldValues {
+		if value == "" {
+			continue
+		}
+		args = append(args, value)
+		where = append(where, fmt.Sprintf("v%d = $%d", fieldIndex+i, len(args)))
+	}
+
+	_, err := a.db.Exec(`delete from casbin_rule where `+strings.Join(where, " and "), args...)
+	return errors.Wrap(err, "failed to remove policies")
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertRule(ctx context.Context, db execer, ptype string, rule []string) error {
+	v, err := ruleValues(rule)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `
+		insert into casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+		values ($1, $2, $3, $4, $5, $6, $7)
+		on conflict do nothing`,
+		ptype, v[0], v[1], v[2], v[3], v[4], v[5])
+	return errors.Wrap(err, "failed to add policy")
+}
+
+func ruleValues(rule []string) ([]string, error) {
+	if len(rule) > ruleFields {
+		return nil, errors.Errorf("policy rule has more than %d values", ruleFields)
+	}
+	v := make([]string, ruleFields)
+	copy(v, rule)
+	return v, nil
+}
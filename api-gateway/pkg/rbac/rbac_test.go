@@ -0,0 +1,226 @@
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/casbin/casbin/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const modelPath = "../../config/model.conf"
+
+var (
+	loadPolicyQuery = regexp.QuoteMeta(`select ptype, v0, v1, v2, v3, v4, v5 from casbin_rule order by id`)
+	insertRuleQuery = regexp.QuoteMeta(`insert into casbin_rule (ptype, v0, v1, v2, v3, v4, v5)`)
+)
+
+func policyRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ptype", "v0", "v1", "v2", "v3", "v4", "v5"}).
+		AddRow("p", "admin", "/api/v1/admin/users", "GET", "", "", "").
+		AddRow("p", "admin", "/api/v1/admin/bookings/:id/reject", "POST", "", "", "").
+		AddRow("p", "customer", "/api/v1/bookings/*", "GET", "", "", "").
+		AddRow("p", "support", "/api/v1/admin/users", "GET", "", "", "").
+		AddRow("g", "user1", "support", "", "", "", "")
+}
+
+func TestEnforce(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectQuery(loadPolicyQuery).WillReturnRows(policyRows())
+
+	e, err := casbin.NewSyncedEnforcer(modelPath, NewAdapter(db))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	tests := []struct {
+		sub, obj, act string
+		allow         bool
+	}{
+		{"admin", "/api/v1/admin/users", "GET", true},
+		{"admin", "/api/v1/admin/bookings/:id/reject", "POST", true},
+		{"customer", "/api/v1/bookings/:id", "GET", true},
+		{"user1", "/api/v1/admin/users", "GET", true},
+		{"admin", "/api/v1/admin/users", "DELETE", false},
+		{"admin", "/api/v1/admin/users", "GE", false},
+		{"admin", "/api/v1/admin/users", "GETX", false},
+		{"admin", "/api/v1/admin/users", "", false},
+		{"admin", "/api/v1/admin/bookings/:id/reject", "GET", false},
+		{"customer", "/api/v1/admin/users", "GET", false},
+		{"user2", "/api/v1/admin/users", "GET", false},
+	}
+
+	for _, tt := range tests {
+		allow, err := e.Enforce(tt.sub, tt.obj, tt.act)
+		require.NoError(t, err)
+		require.Equal(t, tt.allow, allow, "%s %s %s", tt.sub, tt.act, tt.obj)
+	}
+}
+
+func TestRemoveFilteredPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldIndex int
+		values     []string
+		query      string
+		args       []driver.Value
+		errText    string
+	}{
+		{
+			name:   "Role",
+			values: []string{"auditor"},
+			query:  `delete from casbin_rule where ptype = $1 and v0 = $2`,
+			args:   []driver.Value{"p", "auditor"},
+		},
+		{
+			name:       "SkipsEmptyValues",
+			fieldIndex: 1,
+			values:     []string{"", "GET"},
+			query:      `delete from casbin_rule where ptype = $1 and v2 = $2`,
+			args:       []driver.Value{"p", "GET"},
+		},
+		{
+			name:       "OutOfRange",
+			fieldIndex: 5,
+			values:     []string{"a", "b"},
+			errText:    "invalid policy filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			if tt.query != "" {
+				mock.ExpectExec(tt.query).WithArgs(tt.args...).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = NewAdapter(db).RemoveFilteredPolicy("p", "p", tt.fieldIndex, tt.values...)
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRuleValues(t *testing.T) {
+	v, err := ruleValues([]string{"admin", "/api/v1/admin/users", "GET"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "/api/v1/admin/users", "GET", "", "", ""}, v)
+
+	_, err = ruleValues(make([]string, ruleFields+1))
+	require.Error(t, err)
+}
+
+func TestDeleteRole(t *testing.T) {
+	roleQuery := regexp.QuoteMeta(`select built_in from roles where name = $1`)
+	usersQuery := regexp.QuoteMeta(`select exists(select 1 from users where role = $1)`)
+	deleteQuery := regexp.QuoteMeta(`delete from roles where name = $1 and not built_in`)
+
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		err    error
+	}{
+		{
+			name: "Deleted",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(roleQuery).WithArgs("auditor").WillReturnRows(sqlmock.NewRows([]string{"built_in"}).AddRow(false))
+				mock.ExpectQuery(usersQuery).WithArgs("auditor").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(deleteQuery).WithArgs("auditor").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "NotFound",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(roleQuery).WithArgs("auditor").WillReturnError(sql.ErrNoRows)
+			},
+			err: ErrRoleNotFound,
+		},
+		{
+			name: "BuiltIn",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(roleQuery).WithArgs("auditor").WillReturnRows(sqlmock.NewRows([]string{"built_in"}).AddRow(true))
+			},
+			err: ErrBuiltInRole,
+		},
+		{
+			name: "InUse",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(roleQuery).WithArgs("auditor").WillReturnRows(sqlmock.NewRows([]string{"built_in"}).AddRow(false))
+				mock.ExpectQuery(usersQuery).WithArgs("auditor").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			err: ErrRoleInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			err = NewRoleStore(db).Delete(context.Background(), "auditor")
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectQuery(loadPolicyQuery).WillReturnRows(sqlmock.NewRows([]string{"ptype", "v0", "v1", "v2", "v3", "v4", "v5"}))
+
+	e, err := casbin.NewSyncedEnforcer(modelPath, NewAdapter(db))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "policy.csv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"# defaults\n"+
+			"p, admin, /api/v1/admin/users, GET\n"+
+			"\n"+
+			"p, admin, /api/v1/admin/users/:id, DELETE\n"), 0o600))
+
+	seededQuery := regexp.QuoteMeta(`select exists(select 1 from casbin_seeds where rule = $1)`)
+	recordQuery := regexp.QuoteMeta(`insert into casbin_seeds (rule) values ($1) on conflict do nothing`)
+
+	// The first default was applied before and revoked since; it must stay revoked.
+	mock.ExpectQuery(seededQuery).WithArgs("p, admin, /api/v1/admin/users, GET").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(seededQuery).WithArgs("p, admin, /api/v1/admin/users/:id, DELETE").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(insertRuleQuery).WithArgs("p", "admin", "/api/v1/admin/users/:id", "DELETE", "", "", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(recordQuery).WithArgs("p, admin, /api/v1/admin/users/:id, DELETE").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, Seed(e, db, path))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	allow, err := e.Enforce("admin", "/api/v1/admin/users/:id", "DELETE")
+	require.NoError(t, err)
+	require.True(t, allow)
+	allow, err = e.Enforce("admin", "/api/v1/admin/users", "GET")
+	require.NoError(t, err)
+	require.False(t, allow)
+}
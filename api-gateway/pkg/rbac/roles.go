@@ -0,0 +1,99 @@
+package rbac
+
+import (
+	"Api_Gateway/models"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrRoleExists   = errors.New("role already exists")
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrBuiltInRole is returned when deleting customer, provider, admin or support.
+	ErrBuiltInRole = errors.New("built-in roles cannot be deleted")
+	// ErrRoleInUse is returned when deleting a role that is still the role of a user
+	// profile, deleted profiles included.
+	ErrRoleInUse = errors.New("role is still the role of some users")
+)
+
+// RoleStore keeps the roles that permissions can be granted to and users assigned to.
+type RoleStore struct {
+	db *sql.DB
+}
+
+func NewRoleStore(db *sql.DB) *RoleStore {
+	return &RoleStore{db: db}
+}
+
+func (s *RoleStore) List(ctx context.Context) ([]models.Role, error) {
+	rows, err := s.db.QueryContext(ctx, `select name, description, built_in, created_at from roles order by name`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list roles")
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.BuiltIn, &role.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to read role")
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (s *RoleStore) Exists(ctx context.Context, name string) (bool, error) {
+	var ok bool
+	err := s.db.QueryRowContext(ctx, `select exists(select 1 from roles where name = $1)`, name).Scan(&ok)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check role")
+	}
+	return ok, nil
+}
+
+func (s *RoleStore) Create(ctx context.Context, name, description string) (*models.Role, error) {
+	role := models.Role{Name: name, Description: description}
+	err := s.db.QueryRowContext(ctx, `
+		insert into roles (name, description) values ($1, $2)
+		returning built_in, created_at`, name, description).Scan(&role.BuiltIn, &role.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, ErrRoleExists
+		}
+		return nil, errors.Wrap(err, "failed to create role")
+	}
+	return &role, nil
+}
+
+// Delete removes a custom role. Its permissions and user assignments are removed
+// from the policy by the caller.
+func (s *RoleStore) Delete(ctx context.Context, name string) error {
+	var builtIn bool
+	err := s.db.QueryRowContext(ctx, `select built_in from roles where name = $1`, name).Scan(&builtIn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return errors.Wrap(err, "failed to get role")
+	}
+	if builtIn {
+		return ErrBuiltInRole
+	}
+
+	var inUse bool
+	err = s.db.QueryRowContext(ctx, `select exists(select 1 from users where role = $1)`, name).Scan(&inUse)
+	if err != nil {
+		return errors.Wrap(err, "failed to check role users")
+	}
+	if inUse {
+		return ErrRoleInUse
+	}
+
+	_, err = s.db.ExecContext(ctx, `delete from roles where name = $1 and not built_in`, name)
+	return errors.Wrap(err, "failed to delete role")
+}
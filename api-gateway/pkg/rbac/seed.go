@@ -0,0 +1,63 @@
+package rbac
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/pkg/errors"
+)
+
+// Seed adds the default rules of a casbin CSV file to the policy. Every rule is only
+// applied once, so new defaults reach existing databases while a default an admin
+// revoked stays revoked.
+func Seed(e *casbin.SyncedEnforcer, db *sql.DB, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open default policy")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		r := csv.NewReader(strings.NewReader(line))
+		r.TrimLeadingSpace = true
+		rule, err := r.Read()
+		if err != nil || len(rule) < 2 {
+			return errors.Errorf("invalid default policy line %q", line)
+		}
+		key := strings.Join(rule, ", ")
+
+		var applied bool
+		err = db.QueryRow(`select exists(select 1 from casbin_seeds where rule = $1)`, key).Scan(&applied)
+		if err != nil {
+			return errors.Wrap(err, "failed to check default policy")
+		}
+		if applied {
+			continue
+		}
+
+		ptype, params := rule[0], rule[1:]
+		if strings.HasPrefix(ptype, "g") {
+			_, err = e.AddNamedGroupingPolicy(ptype, params)
+		} else {
+			_, err = e.AddNamedPolicy(ptype, params)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply default policy %q", key)
+		}
+
+		if _, err := db.Exec(`insert into casbin_seeds (rule) values ($1) on conflict do nothing`, key); err != nil {
+			return errors.Wrap(err, "failed to record default policy")
+		}
+	}
+	return scanner.Err()
+}
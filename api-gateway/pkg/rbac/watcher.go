@@ -0,0 +1,84 @@
+package rbac
+
+import (
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Channel is the Postgres notification channel that announces policy changes.
+const Channel = "casbin_policy"
+
+// Watcher tells every gateway instance to reload the policy when one of them changes
+// it, using Postgres LISTEN/NOTIFY.
+type Watcher struct {
+	db       *sql.DB
+	listener *pq.Listener
+	log      *slog.Logger
+
+	mu       sync.Mutex
+	callback func(string)
+	done     chan struct{}
+}
+
+// NewWatcher starts listening on Channel. dsn is the connection string of db.
+func NewWatcher(db *sql.DB, dsn string, log *slog.Logger) (*Watcher, error) {
+	w := &Watcher{db: db, log: log, done: make(chan struct{})}
+	w.listener = pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error("policy listener", "error", err)
+		}
+	})
+	if err := w.listener.Listen(Channel); err != nil {
+		w.listener.Close()
+		return nil, errors.Wrap(err, "failed to listen for policy changes")
+	}
+
+	go w.run()
+	return w, nil
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case n := <-w.listener.Notify:
+			// A nil notification means the connection was re-established and
+			// changes may have been missed, so reload as well.
+			payload := ""
+			if n != nil {
+				payload = n.Extra
+			}
+			w.mu.Lock()
+			callback := w.callback
+			w.mu.Unlock()
+			if callback != nil {
+				w.log.Info("Reloading policy", "change", payload)
+				callback(payload)
+			}
+		}
+	}
+}
+
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update notifies all instances, this one included, that the policy changed.
+func (w *Watcher) Update() error {
+	_, err := w.db.Exec(`select pg_notify($1, $2)`, Channel, "policy changed")
+	return errors.Wrap(err, "failed to notify policy change")
+}
+
+func (w *Watcher) Close() {
+	close(w.done)
+	w.listener.Close()
+}
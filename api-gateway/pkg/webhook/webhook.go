@@ -0,0 +1,56 @@
+// Package webhook verifies the signatures of the payment provider's webhook calls.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader carries the signature of a webhook: "t=<unix time>,v1=<hex HMAC-SHA256
+// of "<unix time>.<body>">".
+const SignatureHeader = "Payment-Signature"
+
+// Tolerance is how old a signature may be; older calls are treated as replays.
+const Tolerance = 5 * time.Minute
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Verify checks that header is a signature of body made with secret within
+// Tolerance of now.
+func Verify(secret, header string, body []byte, now time.Time) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return errors.Wrap(ErrInvalidSignature, "malformed header")
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > Tolerance || age < -Tolerance {
+		return errors.Wrap(ErrInvalidSignature, "timestamp outside tolerance")
+	}
+
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, "malformed signature")
+	}
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%s.%s", ts, body)
+	if !hmac.Equal(got, h.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
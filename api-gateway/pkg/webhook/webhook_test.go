@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, body []byte, at time.Time) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.%s", at.Unix(), body)
+	return fmt.Sprintf("t=%d,v1=%s", at.Unix(), hex.EncodeToString(h.Sum(nil)))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1","type":"payment.authorized"}`)
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		ok     bool
+	}{
+		{name: "Valid", header: sign("secret", body, now), body: body, ok: true},
+		{name: "Spaces", header: " t=1700000000 , v1=" + sign("secret", body, now)[len("t=1700000000,v1="):], body: body, ok: true},
+		{name: "SlightlyAhead", header: sign("secret", body, now.Add(time.Minute)), body: body, ok: true},
+		{name: "OtherSecret", header: sign("other", body, now), body: body},
+		{name: "TamperedBody", header: sign("secret", body, now), body: []byte(`{"id":"evt_1","type":"payment.declined"}`)},
+		{name: "Replayed", header: sign("secret", body, now.Add(-Tolerance-time.Second)), body: body},
+		{name: "FromTheFuture", header: sign("secret", body, now.Add(Tolerance+time.Second)), body: body},
+		{name: "OtherTimestamp", header: "t=1700000001,v1=" + sign("secret", body, now)[len("t=1700000000,v1="):], body: body},
+		{name: "NoTimestamp", header: "v1=abcd", body: body},
+		{name: "NoSignature", header: "t=1700000000", body: body},
+		{name: "NotHex", header: "t=1700000000,v1=xyz", body: body},
+		{name: "Empty", header: "", body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify("secret", tt.header, tt.body, now)
+			if tt.ok {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrInvalidSignature), "got %v", err)
+			}
+		})
+	}
+}
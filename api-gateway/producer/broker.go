@@ -3,59 +3,129 @@ package producer
 import (
 	"fmt"
 	"log/slog"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// replyQueue is RabbitMQ's direct reply-to pseudo queue. Replies to messages
+// published with it arrive on the channel that consumes it, without declaring a queue.
+const replyQueue = "amq.rabbitmq.reply-to"
+
 type (
 	MsgBroker struct {
 		channel *amqp.Channel
 		logger  *slog.Logger
+
+		mu      sync.Mutex
+		waiters map[string]chan []byte
+		replies bool
+	}
+
+	// Operation identifies a published request so that its outcome can be looked up later.
+	Operation struct {
+		ID     string
+		UserID string
+		// Reply asks the consumer to send the outcome straight back to this gateway.
+		Reply bool
 	}
 )
 
 func NewMsgBroker(channel *amqp.Channel, logger *slog.Logger) *MsgBroker {
-	return &MsgBroker{
+	b := &MsgBroker{
 		channel: channel,
 		logger:  logger,
+		waiters: make(map[string]chan []byte),
+	}
+
+	if channel != nil {
+		deliveries, err := channel.Consume(replyQueue, "", true, false, false, false, nil)
+		if err != nil {
+			logger.Error("Failed to consume replies, waiting for operations is disabled", "error", err.Error())
+		} else {
+			b.replies = true
+			go b.dispatchReplies(deliveries)
+		}
 	}
+
+	return b
 }
 
-func (b *MsgBroker) CreateBooking(body []byte) error {
+// Await registers interest in the reply to the operation and must be called before the
+// request is published. The returned channel receives the reply at most once; release
+// must be called when the caller stops waiting.
+func (b *MsgBroker) Await(operationID string) (reply <-chan []byte, release func()) {
+	ch := make(chan []byte, 1)
+
+	b.mu.Lock()
+	b.waiters[operationID] = ch
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.waiters, operationID)
+		b.mu.Unlock()
+	}
+}
+
+func (b *MsgBroker) dispatchReplies(deliveries <-chan amqp.Delivery) {
+	for d := range deliveries {
+		b.mu.Lock()
+		ch, ok := b.waiters[d.CorrelationId]
+		delete(b.waiters, d.CorrelationId)
+		b.mu.Unlock()
+
+		if ok {
+			ch <- d.Body
+		}
+	}
+}
+
+func (b *MsgBroker) CreateBooking(op Operation, body []byte) error {
 	fmt.Println("------------------------------------------CREATE BOOOK -----------------------------------")
-	return b.publishMessage("create_booking", body)
+	return b.publishMessage("create_booking", op, body)
 }
 
-func (b *MsgBroker) CancelBooking(body []byte) error {
+func (b *MsgBroker) CancelBooking(op Operation, body []byte) error {
 	fmt.Println("------------------------------------------Cancel BOOOK -----------------------------------")
 
-	return b.publishMessage("booking_cancelled", body)
+	return b.publishMessage("booking_cancelled", op, body)
 }
 
-func (b *MsgBroker) Payment(body []byte) error {
+func (b *MsgBroker) Payment(op Operation, body []byte) error {
 	fmt.Println("------------------------------------------PAYMENT ------------------------------")
-	return b.publishMessage("payment_processed", body)
+	return b.publishMessage("payment_processed", op, body)
 }
 
-func (b *MsgBroker) Review(body []byte) error {
+func (b *MsgBroker) Review(op Operation, body []byte) error {
 	fmt.Println("------------------------------------------REVIEW ------------------------------")
-	return b.publishMessage("review_submitted", body)
+	return b.publishMessage("review_submitted", op, body)
 }
 
 func (b *MsgBroker) CreateNotification(body []byte) error {
-	return b.publishMessage("create_notification", body)
+	return b.publishMessage("create_notification", Operation{}, body)
 }
 
-func (b *MsgBroker) publishMessage(queueName string, body []byte) error {
+func (b *MsgBroker) publishMessage(queueName string, op Operation, body []byte) error {
+	msg := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+	}
+	if op.ID != "" {
+		msg.MessageId = op.ID
+		msg.CorrelationId = op.ID
+		msg.Headers = amqp.Table{"user_id": op.UserID}
+		if op.Reply && b.replies {
+			msg.ReplyTo = replyQueue
+		}
+	}
+
 	err := b.channel.Publish(
 		"",
 		queueName,
 		false,
 		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
+		msg,
 	)
 	if err != nil {
 		b.logger.Error("Failed to publish message", "queue", queueName, "error", err.Error())
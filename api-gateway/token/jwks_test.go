@@ -0,0 +1,185 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer publishes keys the way the Auth service's /.well-known/jwks.json does.
+type jwksServer struct {
+	mu       sync.Mutex
+	keys     []jwk
+	requests int
+	down     bool
+}
+
+func (s *jwksServer) add(k jwk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, k)
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string][]jwk{"keys": s.keys})
+}
+
+func ed25519JWK(t *testing.T, kid string) (jwk, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return jwk{Kty: "OKP", Kid: kid, Alg: "EdDSA", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)}, priv
+}
+
+func newCache(url string) *jwksCache {
+	return &jwksCache{url: url, ttl: time.Hour, client: http.DefaultClient}
+}
+
+func TestPublicKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edJWK, edKey := ed25519JWK(t, "ed")
+
+	tests := []struct {
+		name    string
+		jwk     jwk
+		key     interface{}
+		errText string
+	}{
+		{name: "Ed25519", jwk: edJWK, key: edKey.Public()},
+		{
+			name: "RSA",
+			jwk: jwk{
+				Kty: "RSA",
+				Kid: "rsa",
+				N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			key: &rsaKey.PublicKey,
+		},
+		{name: "OtherCurve", jwk: jwk{Kty: "OKP", Kid: "x", Crv: "X25519"}, errText: "unsupported curve"},
+		{name: "ShortKey", jwk: jwk{Kty: "OKP", Kid: "x", Crv: "Ed25519", X: "AQAB"}, errText: "invalid public key"},
+		{name: "EC", jwk: jwk{Kty: "EC", Kid: "x"}, errText: "unsupported key type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.jwk.publicKey()
+			if tt.errText != "" {
+				require.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestJWKSCache(t *testing.T) {
+	first, firstKey := ed25519JWK(t, "2024-01")
+	server := &jwksServer{keys: []jwk{first}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	cache := newCache(ts.URL)
+
+	key, err := cache.Key("2024-01")
+	require.NoError(t, err)
+	require.Equal(t, firstKey.Public(), key)
+
+	_, err = cache.Key("2024-01")
+	require.NoError(t, err)
+	require.Equal(t, 1, server.requests, "fresh keys are served from the cache")
+
+	// A rotated key is fetched as soon as a token names it.
+	second, secondKey := ed25519JWK(t, "2024-02")
+	server.add(second)
+	cache.attemptedAt = time.Time{}
+	key, err = cache.Key("2024-02")
+	require.NoError(t, err)
+	require.Equal(t, secondKey.Public(), key)
+
+	// Unknown kids cannot make the gateway hammer the Auth service.
+	_, err = cache.Key("2023-12")
+	require.ErrorContains(t, err, `unknown signing key "2023-12"`)
+	_, err = cache.Key("2023-11")
+	require.Error(t, err)
+	require.Equal(t, 2, server.requests)
+
+	// Known keys stay trusted while the Auth service is down.
+	server.down = true
+	cache.fetchedAt = time.Time{}
+	cache.attemptedAt = time.Time{}
+	key, err = cache.Key("2024-01")
+	require.NoError(t, err)
+	require.Equal(t, firstKey.Public(), key)
+	_, err = cache.Key("2024-03")
+	require.Error(t, err)
+}
+
+func TestExtractClaims(t *testing.T) {
+	public, priv := ed25519JWK(t, "2024-01")
+	ts := httptest.NewServer(&jwksServer{keys: []jwk{public}})
+	defer ts.Close()
+	jwksOnce.Do(func() { jwks = newCache(ts.URL) })
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+	exp := time.Now().Add(time.Minute).Unix()
+
+	tests := []struct {
+		name    string
+		token   string
+		errText string
+	}{
+		{name: "Valid", token: sign(jwt.SigningMethodEdDSA, "2024-01", priv, jwt.MapClaims{"user_id": "user1", "exp": exp})},
+		{
+			name:    "Expired",
+			token:   sign(jwt.SigningMethodEdDSA, "2024-01", priv, jwt.MapClaims{"user_id": "user1", "exp": time.Now().Add(-time.Minute).Unix()}),
+			errText: "expired",
+		},
+		{
+			name:    "HMACWithPublicKey",
+			token:   sign(jwt.SigningMethodHS256, "2024-01", []byte(priv.Public().(ed25519.PublicKey)), jwt.MapClaims{"user_id": "user1", "exp": exp}),
+			errText: "unexpected signing method",
+		},
+		{
+			name:    "ChallengeToken",
+			token:   sign(jwt.SigningMethodEdDSA, "2024-01", priv, jwt.MapClaims{"user_id": "user1", "purpose": "mfa", "exp": exp}),
+			errText: "not an access token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ExtractClaims(tt.token)
+			if tt.errText != "" {
+				require.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "user1", claims["user_id"])
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package token
 
 import (
-	"Api_Gateway/config"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
 	"strings"
 
@@ -21,10 +22,24 @@ func ExtractClaims(tokenstr string) (jwt.MapClaims, error) {
 	tokenstr = strings.TrimPrefix(tokenstr, "\"")
 	tokenstr = strings.TrimSuffix(tokenstr, "\"")
 	token, err := jwt.ParseWithClaims(tokenstr, jwt.MapClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		kid, _ := t.Header["kid"].(string)
+		key, err := keys().Key(kid)
+		if err != nil {
+			return nil, err
 		}
-		return []byte(config.Load().ACCESS_TOKEN), nil
+
+		// The algorithm must match the key type, so a public key can never be used as an HMAC secret.
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if t.Method != jwt.SigningMethodRS256 {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+		case ed25519.PublicKey:
+			if t.Method != jwt.SigningMethodEdDSA {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+		}
+		return key, nil
 	})
 
 	if err != nil {
@@ -39,5 +54,9 @@ func ExtractClaims(tokenstr string) (jwt.MapClaims, error) {
 	if !ok || !token.Valid {
 		return nil, fmt.Errorf("failed to parse token claims")
 	}
+	// MFA challenge tokens are signed with the same keys but are not access tokens.
+	if _, ok := claims["purpose"]; ok {
+		return nil, fmt.Errorf("not an access token")
+	}
 	return claims, nil
 }